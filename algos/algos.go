@@ -2,19 +2,25 @@
 package algos
 
 import (
+	"fmt"
 	"github.com/DanTulovsky/mazes/solvealgos/dijkstra"
 	"log"
 
+	"github.com/DanTulovsky/mazes/automata/rules"
+	"github.com/DanTulovsky/mazes/dynamics"
 	"github.com/DanTulovsky/mazes/genalgos"
 	"github.com/DanTulovsky/mazes/genalgos/aldous_broder"
+	"github.com/DanTulovsky/mazes/genalgos/automaton"
 	"github.com/DanTulovsky/mazes/genalgos/bintree"
 	"github.com/DanTulovsky/mazes/genalgos/ellers"
 	gen_empty "github.com/DanTulovsky/mazes/genalgos/empty"
 	"github.com/DanTulovsky/mazes/genalgos/from_encoded_string"
 	"github.com/DanTulovsky/mazes/genalgos/fromfile"
 	"github.com/DanTulovsky/mazes/genalgos/full"
+	"github.com/DanTulovsky/mazes/genalgos/growing_tree"
 	"github.com/DanTulovsky/mazes/genalgos/hunt_and_kill"
 	"github.com/DanTulovsky/mazes/genalgos/kruskal"
+	"github.com/DanTulovsky/mazes/genalgos/origin_shift"
 	"github.com/DanTulovsky/mazes/genalgos/prim"
 	gen_rb "github.com/DanTulovsky/mazes/genalgos/recursive_backtracker"
 	"github.com/DanTulovsky/mazes/genalgos/recursive_division"
@@ -35,14 +41,18 @@ import (
 
 var Algorithms map[string]genalgos.Algorithmer = map[string]genalgos.Algorithmer{
 	"aldous-broder":         &aldous_broder.AldousBroder{},
+	"automaton-maze":        &automaton.Automaton{Rule: rules.MazeRule},
+	"automaton-mazectric":   &automaton.Automaton{Rule: rules.MazectricRule},
 	"bintree":               &bintree.Bintree{},
 	"ellers":                &ellers.Ellers{},
 	"empty":                 &gen_empty.Empty{},
 	"from-encoded-string":   &from_encoded_string.FromEncodedString{},
 	"fromfile":              &fromfile.Fromfile{},
 	"full":                  &full.Full{},
+	"growing-tree":          &growing_tree.GrowingTree{},
 	"hunt-and-kill":         &hunt_and_kill.HuntAndKill{},
 	"kruskal":               &kruskal.Kruskal{},
+	"origin-shift":          &origin_shift.OriginShift{},
 	"prim":                  &prim.Prim{},
 	"recursive-backtracker": &gen_rb.RecursiveBacktracker{},
 	"recursive-division":    &recursive_division.RecursiveDivision{},
@@ -131,3 +141,44 @@ func CheckCreateAlgo(a string) bool {
 	}
 	return false
 }
+
+// CheckCreateConfig returns an error if the options of the create algorithm in config are not valid
+func CheckCreateConfig(config *pb.MazeConfig) error {
+	switch {
+	case config.GetOriginShiftRate() < 0:
+		return fmt.Errorf("origin shift rate cannot be negative, got %v", config.GetOriginShiftRate())
+	case config.GetOriginShiftRate() > 0 && (config.GetAllowWeaving() || config.GetBraidProbability() > 0):
+		return fmt.Errorf("origin shift rate needs a perfect maze, without weaving or braiding")
+	}
+
+	if err := dynamics.CheckConfig(config.GetDynamics()); err != nil {
+		return err
+	}
+	if config.GetDynamics().GetPolicy() != "" {
+		switch {
+		case config.GetOriginShiftRate() > 0:
+			return fmt.Errorf("dynamics and origin shift rate cannot both change the maze")
+		case config.GetAllowWeaving():
+			return fmt.Errorf("dynamics does not support weaving")
+		}
+	}
+
+	switch config.GetCreateAlgo() {
+	case "automaton-maze", "automaton-mazectric":
+		return automaton.CheckConfig(config)
+	case "bintree", "ellers", "recursive-division", "sidewinder":
+		// these only know about rows and columns of square cells
+		return genalgos.CheckSquareGridConfig(config)
+	case "growing-tree":
+		_, err := growing_tree.ParseSelection(config.GetGrowingTree())
+		return err
+	case "origin-shift":
+		if config.GetOriginShiftSteps() < 0 {
+			return fmt.Errorf("origin shift steps cannot be negative, got %v", config.GetOriginShiftSteps())
+		}
+		if config.GetAllowWeaving() {
+			return fmt.Errorf("origin shift does not support weaving")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package algos
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sasha-s/go-deadlock"
+
+	"github.com/DanTulovsky/mazes/maze"
+	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/tevino/abool"
+)
+
+func init() {
+	// as in the programs without --enable_deadlock_detection: weaving read locks a cell while it holds the lock
+	deadlock.Opts.Disable = true
+}
+
+// every seed test compares the whole maze: passages, weave tunnels and the weights of the cells
+var seedtests = []struct {
+	name   string
+	config *pb.MazeConfig
+}{
+	{
+		name: "plain",
+		config: &pb.MazeConfig{
+			Rows:    10,
+			Columns: 15,
+		},
+	}, {
+		name: "braided",
+		config: &pb.MazeConfig{
+			Rows:             10,
+			Columns:          15,
+			BraidProbability: 0.5,
+		},
+	}, {
+		name: "levels",
+		config: &pb.MazeConfig{
+			Rows:    6,
+			Columns: 8,
+			Levels:  2,
+		},
+	}, {
+		name: "weave",
+		config: &pb.MazeConfig{
+			Rows:               10,
+			Columns:            15,
+			AllowWeaving:       true,
+			WeavingProbability: 0.5,
+		},
+	}, {
+		// weights are set for every maze, this one shows them
+		name: "weights",
+		config: &pb.MazeConfig{
+			Rows:             12,
+			Columns:          12,
+			ShowWeightValues: true,
+		},
+	},
+}
+
+// seedConfig returns the config of tt for algo and seed
+func seedConfig(algo string, config *pb.MazeConfig, seed int64) *pb.MazeConfig {
+	return &pb.MazeConfig{
+		Rows:               config.GetRows(),
+		Columns:            config.GetColumns(),
+		Levels:             config.GetLevels(),
+		BraidProbability:   config.GetBraidProbability(),
+		AllowWeaving:       config.GetAllowWeaving(),
+		WeavingProbability: config.GetWeavingProbability(),
+		ShowWeightValues:   config.GetShowWeightValues(),
+		CreateAlgo:         algo,
+		Seed:               seed,
+	}
+}
+
+// generate returns the cells of the maze algo generates from config with seed, as text
+func generate(algo string, config *pb.MazeConfig, seed int64) (string, error) {
+	config = seedConfig(algo, config, seed)
+
+	m, err := maze.NewMaze(config, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid config: %v", err)
+	}
+	if err := NewGenerator(algo).Apply(m, 0, abool.NewBool(true)); err != nil {
+		return "", fmt.Errorf("apply failed: %v", err)
+	}
+	if config.GetBraidProbability() > 0 {
+		m.Braid(config.GetBraidProbability())
+	}
+
+	return proto.CompactTextString(&pb.Maze{Cells: m.Proto().GetCells()}), nil
+}
+
+// seededAlgorithms are the generators that make a maze out of the config alone
+func seededAlgorithms() []string {
+	var algos []string
+	for algo := range Algorithms {
+		switch algo {
+		case "fromfile", "from-encoded-string":
+			continue
+		}
+		algos = append(algos, algo)
+	}
+	return algos
+}
+
+func TestSeed(t *testing.T) {
+	for _, algo := range seededAlgorithms() {
+		for _, tt := range seedtests {
+			if err := CheckCreateConfig(seedConfig(algo, tt.config, 42)); err != nil {
+				continue // e.g. automaton mazes have one level
+			}
+			want, err := generate(algo, tt.config, 42)
+			if err != nil {
+				t.Fatalf("%v (%v): %v", algo, tt.name, err)
+			}
+
+			// the same maze, also when generated at the same time as others
+			got := make([]string, 4)
+			errs := make([]error, len(got))
+			var wg sync.WaitGroup
+			for i := range got {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					got[i], errs[i] = generate(algo, tt.config, 42)
+				}(i)
+			}
+			wg.Wait()
+
+			for i := range got {
+				if errs[i] != nil {
+					t.Errorf("%v (%v): %v", algo, tt.name, errs[i])
+					continue
+				}
+				if got[i] != want {
+					t.Errorf("%v (%v): maze %v from seed 42 is not the same as the first one", algo, tt.name, i)
+				}
+			}
+		}
+	}
+}
+
+func TestSeedDiffers(t *testing.T) {
+	for _, algo := range seededAlgorithms() {
+		config := seedtests[0].config
+		one, err := generate(algo, config, 1)
+		if err != nil {
+			t.Fatalf("%v: %v", algo, err)
+		}
+		two, err := generate(algo, config, 2)
+		if err != nil {
+			t.Fatalf("%v: %v", algo, err)
+		}
+		if one == two {
+			t.Errorf("%v: mazes from seeds 1 and 2 are the same", algo)
+		}
+	}
+}
+
+func TestNoSeed(t *testing.T) {
+	config := &pb.MazeConfig{Rows: 3, Columns: 3}
+	if _, err := maze.NewMaze(config, nil); err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	if config.GetSeed() == 0 {
+		t.Errorf("maze without a seed should pick one")
+	}
+}
+
+func TestCheckCreateConfigGrids(t *testing.T) {
+	// every grid the config check lets through must generate, the rest must be rejected up front
+	for _, algo := range seededAlgorithms() {
+		for _, grid := range []string{maze.GridSquare, maze.GridHex, maze.GridPolar, maze.GridDelta} {
+			config := &pb.MazeConfig{Rows: 8, Columns: 8, GridType: grid, CreateAlgo: algo}
+			if err := CheckCreateConfig(config); err != nil {
+				continue
+			}
+
+			m, err := maze.NewMaze(config, nil)
+			if err != nil {
+				t.Fatalf("%v on %v: invalid config: %v", algo, grid, err)
+			}
+			if err := NewGenerator(algo).Apply(m, 0, abool.NewBool(true)); err != nil {
+				t.Errorf("%v on %v: passed CheckCreateConfig, but apply failed: %v", algo, grid, err)
+			}
+		}
+	}
+
+	for _, algo := range []string{"bintree", "ellers", "recursive-division", "sidewinder"} {
+		if err := CheckCreateConfig(&pb.MazeConfig{CreateAlgo: algo, GridType: maze.GridHex}); err == nil {
+			t.Errorf("%v on %v grid should be rejected", algo, maze.GridHex)
+		}
+	}
+}
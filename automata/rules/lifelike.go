@@ -0,0 +1,133 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// MazeRule grows maze like corridors out of a random patch
+	MazeRule = mustParseLifeLike("B3/S12345")
+	// MazectricRule is like MazeRule, with longer and straighter corridors
+	MazectricRule = mustParseLifeLike("B3/S1234")
+)
+
+// LifeLike is a rule of a life like automaton, on a grid of pixels with 8 neighbors each
+type LifeLike struct {
+	born    [9]bool // a dead pixel with this many live neighbors comes alive
+	survive [9]bool // a live pixel with this many live neighbors stays alive
+}
+
+// ParseLifeLike parses a rule in B/S notation, e.g. B3/S23 for the game of life
+func ParseLifeLike(s string) (LifeLike, error) {
+	var r LifeLike
+
+	parts := strings.Split(strings.ToUpper(strings.TrimSpace(s)), "/")
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], "B") || !strings.HasPrefix(parts[1], "S") {
+		return r, fmt.Errorf("invalid rule %q, want B<digits>/S<digits>", s)
+	}
+
+	for i, counts := range []*[9]bool{&r.born, &r.survive} {
+		for _, d := range parts[i][1:] {
+			n, err := strconv.Atoi(string(d))
+			if err != nil || n > 8 {
+				return r, fmt.Errorf("invalid neighbor count %q in rule %q", d, s)
+			}
+			counts[n] = true
+		}
+	}
+	return r, nil
+}
+
+func mustParseLifeLike(s string) LifeLike {
+	r, err := ParseLifeLike(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// String returns the rule in B/S notation
+func (r LifeLike) String() string {
+	s := "B"
+	for n, ok := range r.born {
+		if ok {
+			s += strconv.Itoa(n)
+		}
+	}
+	s += "/S"
+	for n, ok := range r.survive {
+		if ok {
+			s += strconv.Itoa(n)
+		}
+	}
+	return s
+}
+
+// Pixels is a grid of live (true) and dead pixels, [x][y]; pixels off the edge are dead
+type Pixels [][]bool
+
+// NewPixels returns a grid of dead pixels
+func NewPixels(width, height int) Pixels {
+	p := make(Pixels, width)
+	for x := range p {
+		p[x] = make([]bool, height)
+	}
+	return p
+}
+
+// Width returns the width of the grid
+func (p Pixels) Width() int {
+	return len(p)
+}
+
+// Height returns the height of the grid
+func (p Pixels) Height() int {
+	if len(p) == 0 {
+		return 0
+	}
+	return len(p[0])
+}
+
+// Alive returns true if the pixel at x, y is alive
+func (p Pixels) Alive(x, y int) bool {
+	if x < 0 || x >= p.Width() || y < 0 || y >= p.Height() {
+		return false
+	}
+	return p[x][y]
+}
+
+// liveNeighbors returns the number of live pixels around x, y
+func (p Pixels) liveNeighbors(x, y int) int {
+	n := 0
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			if (dx != 0 || dy != 0) && p.Alive(x+dx, y+dy) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Step returns the next generation of p under rule r, and true if it is not the same as p
+func (p Pixels) Step(r LifeLike) (Pixels, bool) {
+	next := NewPixels(p.Width(), p.Height())
+	changed := false
+
+	for x := range p {
+		for y := range p[x] {
+			n := p.liveNeighbors(x, y)
+			if p[x][y] {
+				next[x][y] = r.survive[n]
+			} else {
+				next[x][y] = r.born[n]
+			}
+			if next[x][y] != p[x][y] {
+				changed = true
+			}
+		}
+	}
+	return next, changed
+}
@@ -0,0 +1,54 @@
+package rules
+
+import "testing"
+
+func TestParseLifeLike(t *testing.T) {
+	for _, tt := range []struct {
+		rule    string
+		wantErr bool
+	}{
+		{rule: "B3/S12345"},
+		{rule: "B3/S1234"},
+		{rule: "b3/s23"},
+		{rule: "B36/S"},
+		{rule: "B3S23", wantErr: true},
+		{rule: "B9/S23", wantErr: true},
+		{rule: "S23/B3", wantErr: true},
+	} {
+		r, err := ParseLifeLike(tt.rule)
+		if err != nil {
+			if !tt.wantErr {
+				t.Errorf("ParseLifeLike(%q) failed: %v", tt.rule, err)
+			}
+			continue
+		}
+		if tt.wantErr {
+			t.Errorf("ParseLifeLike(%q) should have failed", tt.rule)
+		}
+		if r2, _ := ParseLifeLike(r.String()); r2 != r {
+			t.Errorf("ParseLifeLike(%q).String() = %q does not parse back", tt.rule, r)
+		}
+	}
+}
+
+func TestStep(t *testing.T) {
+	// a blinker: three in a row turns on its side under the game of life, and stops changing under Maze
+	p := NewPixels(5, 5)
+	p[1][2], p[2][2], p[3][2] = true, true, true
+
+	life, _ := ParseLifeLike("B3/S23")
+	next, changed := p.Step(life)
+	if !changed || !next[2][1] || !next[2][2] || !next[2][3] || next[1][2] || next[3][2] {
+		t.Errorf("blinker did not turn under %v", life)
+	}
+
+	next, changed = p.Step(MazeRule)
+	if !changed {
+		t.Errorf("blinker did not grow under %v", MazeRule)
+	}
+	for x := 1; x <= 3; x++ {
+		if !next[x][2] {
+			t.Errorf("pixel %v,2 died under %v, it has 1 or 2 live neighbors", x, MazeRule)
+		}
+	}
+}
@@ -113,8 +113,8 @@ func showMazeStats(m *maze.Maze) {
 
 func createMaze(config *pb.MazeConfig) (m *maze.Maze, r *sdl.Renderer, w *sdl.Window, err error) {
 	if config.GetCreateAlgo() == "fromfile" {
-		if c, r, err := fromfile.MazeSizeFromFile(config); err == nil {
-			config.Columns, config.Rows = int64(c), int64(r)
+		if c, r, l, err := fromfile.MazeSizeFromFile(config); err == nil {
+			config.Columns, config.Rows, config.Levels = int64(c), int64(r), int64(l)
 		} else {
 			return nil, nil, nil, err
 		}
@@ -144,7 +144,7 @@ func createMaze(config *pb.MazeConfig) (m *maze.Maze, r *sdl.Renderer, w *sdl.Wi
 	// If the mask image is provided, use that as the dimensions of the grid
 	if *maskImage != "" {
 		log.Printf("Using %v as grid mask", *maskImage)
-		m, err = maze.NewMazeFromImage(config, *maskImage, r)
+		m, err = maze.NewMazeFromImage(config, *maskImage, lsdl.NewRenderer(r))
 		if err != nil {
 			log.Printf("invalid config: %v", err)
 			os.Exit(1)
@@ -152,7 +152,7 @@ func createMaze(config *pb.MazeConfig) (m *maze.Maze, r *sdl.Renderer, w *sdl.Wi
 		// Set these for correct window size
 		config.Columns, config.Rows = m.Dimensions()
 	} else {
-		m, err = maze.NewMaze(config, r)
+		m, err = maze.NewMaze(config, lsdl.NewRenderer(r))
 		if err != nil {
 			log.Printf("invalid config: %v", err)
 			os.Exit(1)
@@ -225,10 +225,10 @@ func createMaze(config *pb.MazeConfig) (m *maze.Maze, r *sdl.Renderer, w *sdl.Wi
 			// Displays the main maze while generating it
 			sdl.Do(func() {
 				// reset the clear color back to white
-				colors.SetDrawColor(colors.GetColor("white"), r)
+				lsdl.SetDrawColor(colors.GetColor("white"), r)
 
 				r.Clear()
-				m.DrawMazeBackground(r)
+				m.DrawMazeBackground(lsdl.NewRenderer(r))
 				r.Present()
 				sdl.Delay(uint32(1000 / *frameRate))
 				ResetFontCache()
@@ -358,7 +358,7 @@ func displaymaze(m *maze.Maze, r *sdl.Renderer) {
 			os.Exit(1)
 		}
 
-		m.DrawMaze(r, m.BGTexture())
+		m.DrawMaze(lsdl.NewRenderer(r), m.BGTexture())
 
 		r.Present()
 		sdl.Delay(uint32(1000 / *frameRate))
@@ -50,3 +50,26 @@ func Concrete(m *maze.Maze) *maze.Maze {
 	}
 	return m
 }
+
+// RandomPatch brings the pixels in the middle fifth of p (at least 3x3) to life at random, drawing from r
+func RandomPatch(p rules.Pixels, r *utils.Rand) rules.Pixels {
+	patch := func(size int) (int, int) {
+		n := size / 5
+		if n < 3 {
+			n = 3
+		}
+		if n > size {
+			n = size
+		}
+		return (size - n) / 2, (size + n) / 2
+	}
+
+	fromX, toX := patch(p.Width())
+	fromY, toY := patch(p.Height())
+	for x := fromX; x < toX; x++ {
+		for y := fromY; y < toY; y++ {
+			p[x][y] = r.Random(0, 2) == 0
+		}
+	}
+	return p
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -39,17 +43,26 @@ var (
 
 	// maze
 	maskImage          = flag.String("mask_image", "", "file name of mask image")
+	importFiles        = flag.String("import_files", "", "encoded mazes to send to the server with --op=import, a glob; e.g. written by genmazes or ExportMaze")
+	mazeCount          = flag.Int64("maze_count", 10, "number of mazes to create with --op=create_many")
+	seed               = flag.Int64("seed", 0, "seed of the maze, the same seed and options make the same maze; 0 picks one")
+	seedStart          = flag.Int64("seed_start", 0, "with --op=create_many maze i is generated from seed seed_start+i, 0 for --seed or one seed per maze")
+	exportDir          = flag.String("export_dir", "", "with --op=create_many also write the encoded mazes to this directory, one file per maze")
 	allowWeaving       = flag.Bool("weaving", false, "allow weaving")
 	weavingProbability = flag.Float64("weaving_probability", 1, "controls the amount of weaving that happens, with 1 being the max")
 	braidProbability   = flag.Float64("braid_probability", 0, "braid the maze with this probabily, 0 results in a perfect maze, 1 results in no deadends at all")
 	randomFromTo       = flag.Bool("random_path", false, "show a random path through the maze")
 	showGUI            = flag.Bool("gui", true, "show gui maze")
-	showLocalGUI       = flag.Bool("local_gui", false, "show client's view of the maze")
+	showLocalGUI       = guiFlagVar("local_gui", "show client's view of the maze: sdl (same as true) for a window, term to draw it in the terminal")
 	title              = flag.String("title", "", "maze title")
 
 	// dimensions
-	rows    = flag.Int64("r", 15, "number of rows in the maze")
-	columns = flag.Int64("c", 15, "number of rows in the maze")
+	rows      = flag.Int64("r", 15, "number of rows in the maze")
+	columns   = flag.Int64("c", 15, "number of rows in the maze")
+	gridType  = flag.String("grid_type", "square", "shape of the grid: square, hex, delta or polar (rows are rings, columns are ignored)")
+	levels    = flag.Int64("levels", 1, "number of levels in the maze, connected by stairs (square grid only)")
+	levelView = flag.String("level_view", "side-by-side", "how to show multiple levels: side-by-side or follow (only the level of the first client)")
+	wrap      = flag.String("wrap", "", "link edges of the grid to the opposite edge: horizontal, vertical or both (torus)")
 
 	// colors
 	bgColor              = flag.String("bgcolor", "white", "background color")
@@ -81,9 +94,16 @@ var (
 	frameRate              = flag.Uint("frame_rate", 120, "frame rate for animation")
 
 	// algo
-	createAlgo    = flag.String("create_algo", "recursive-backtracker", "algorithm used to create the maze")
-	solveAlgo     = flag.String("solve_algo", "recursive-backtracker", "algorithm to solve the maze")
-	skipGridCheck = flag.Bool("skip_grid_check", true, "set to true to skip grid check (disable spanning tree check)")
+	createAlgo       = flag.String("create_algo", "recursive-backtracker", "algorithm used to create the maze")
+	growingTree      = flag.String("growing_tree", "newest", "growing-tree cell selection: newest, oldest, random, middle or a weighted mix (e.g. newest:75,random:25)")
+	originShiftSteps = flag.Int64("origin_shift_steps", 0, "origin-shift: number of times the root moves, 0 for 10 times the number of cells")
+	originShiftRate  = flag.Float64("origin_shift_rate", 0, "keep changing the maze on the server, this many origin shifts per second, while it is solved; needs a perfect maze")
+	dynamicsPolicy   = flag.String("dynamics", "", "keep changing the passages of the maze on the server while it is solved: toggle, doors or closures")
+	dynamicsRate     = flag.Float64("dynamics_rate", 1, "with --dynamics, changes per second")
+	dynamicsDoors    = flag.Int64("dynamics_doors", 0, "with --dynamics=doors, number of rotating doors, 0 for one per 50 cells")
+	dynamicsClosure  = flag.String("dynamics_closure", "10s", "with --dynamics=closures, how long a passage stays closed")
+	solveAlgo        = flag.String("solve_algo", "recursive-backtracker", "algorithm to solve the maze")
+	skipGridCheck    = flag.Bool("skip_grid_check", true, "set to true to skip grid check (disable spanning tree check)")
 
 	// solver
 	mazeID        = flag.String("maze_id", "", "maze id")
@@ -91,6 +111,7 @@ var (
 	fromCellStr   = flag.String("from_cell", "", "path from cell ('min' = minX, minY)")
 	toCellStr     = flag.String("to_cell", "", "path to cell ('max' = maxX, maxY)")
 	returnMaze    = flag.Bool("return_maze", false, "return the encoded maze in the create reply, used for ML DP algorithms and dijkstra")
+	solverRuns    = flag.Int("solver_runs", 1, "number of times to solve the maze with --op=run_solver, each with a new client")
 
 	// ml params
 	df                 = flag.Float64("df", 1, "discount factor [0-1], at one treats all steps equally")
@@ -103,6 +124,18 @@ var (
 	numEpisodes        = flag.Int64("num_episodes", 10000, "for episodic algorithms, run this many episodes")
 	maxSteps           = flag.Int64("max_steps", 0, "run only this many steps per episode, 0 means set automatically")
 
+	// recording
+	recordGenFile    = flag.String("record_gen_file", "", "record the generator as an animation to this file on the server")
+	recordSolveFile  = flag.String("record_solve_file", "", "record the solver as an animation to this file on the server")
+	recordFormat     = flag.String("record_format", "", "animation format: gif or apng, based on the file extension if empty")
+	recordFrameSkip  = flag.Int64("record_frame_skip", 0, "number of steps skipped between recorded frames")
+	recordMaxFrames  = flag.Int64("record_max_frames", 0, "stop recording after this many frames, 0 for the default")
+	recordFrameDelay = flag.String("record_frame_delay", "50ms", "how long each recorded frame is shown")
+
+	// render
+	clientID   = flag.String("client_id", "", "client whose path is drawn when rendering the maze, none if empty")
+	renderFile = flag.String("render_file", "maze.png", "file to write the rendered maze to, the extension (.png or .svg) picks the format")
+
 	// misc
 	exportMaze       = flag.Bool("export_maze", false, "save maze to a file on the server")
 	bgMusic          = flag.String("bg_music", "", "file name of background music to play")
@@ -115,6 +148,68 @@ var (
 	wd sync.WaitGroup
 )
 
+const (
+	guiNone = ""
+	guiSDL  = "sdl"
+	guiTerm = "term"
+)
+
+// guiFlag is the kind of local gui to show, it also works as a boolean flag: --local_gui alone shows the sdl window
+type guiFlag string
+
+func (g *guiFlag) String() string {
+	return string(*g)
+}
+
+func (g *guiFlag) Set(s string) error {
+	switch s {
+	case "false", guiNone:
+		*g = guiNone
+	case "true", guiSDL:
+		*g = guiSDL
+	case guiTerm:
+		*g = guiTerm
+	default:
+		return fmt.Errorf("invalid local gui: %v (want sdl or term)", s)
+	}
+	return nil
+}
+
+func (g *guiFlag) IsBoolFlag() bool {
+	return true
+}
+
+// guiFlagVar defines a guiFlag, off by default
+func guiFlagVar(name, usage string) *guiFlag {
+	g := new(guiFlag)
+	flag.Var(g, name, usage)
+	return g
+}
+
+// newRecordConfig returns the recording config for file, recording is off if file is empty
+func newRecordConfig(file string) *pb.RecordConfig {
+	return &pb.RecordConfig{
+		File:       file,
+		Format:     *recordFormat,
+		FrameSkip:  *recordFrameSkip,
+		MaxFrames:  *recordMaxFrames,
+		FrameDelay: *recordFrameDelay,
+	}
+}
+
+// newDynamicsConfig returns the dynamics set by the flags, nil if the maze does not change
+func newDynamicsConfig() *pb.DynamicsConfig {
+	if *dynamicsPolicy == "" {
+		return nil
+	}
+	return &pb.DynamicsConfig{
+		Policy:          *dynamicsPolicy,
+		Rate:            *dynamicsRate,
+		Doors:           *dynamicsDoors,
+		ClosureDuration: *dynamicsClosure,
+	}
+}
+
 func newMazeConfig(createAlgo, currentLocationColor string) *pb.MazeConfig {
 	config := &pb.MazeConfig{
 		Rows:                 *rows,
@@ -136,10 +231,20 @@ func newMazeConfig(createAlgo, currentLocationColor string) *pb.MazeConfig {
 		BorderColor:          *borderColor,
 		CreateAlgo:           createAlgo,
 		BraidProbability:     *braidProbability,
+		GrowingTree:          *growingTree,
+		OriginShiftSteps:     *originShiftSteps,
+		OriginShiftRate:      *originShiftRate,
+		Dynamics:             newDynamicsConfig(),
+		Seed:                 *seed,
 		Gui:                  *showGUI,
 		FromFile:             *mazeID,
 		ReturnMaze:           *returnMaze,
 		Title:                *title,
+		GridType:             *gridType,
+		Levels:               *levels,
+		LevelView:            *levelView,
+		Wrap:                 *wrap,
+		Record:               newRecordConfig(*recordGenFile),
 	}
 
 	if createAlgo == "dijkstra" && *allowWeaving {
@@ -397,6 +502,7 @@ func opCreateSolve() error {
 		DrawPathLength:         *drawPathLength,
 		MarkVisitedCells:       *markVisitedCells,
 		NumberMarkVisitedCells: *numberMarkVisitedCells,
+		Record:                 newRecordConfig(*recordSolveFile),
 	}, m, nil)
 }
 
@@ -1081,8 +1187,8 @@ func opCreate() (*pb.CreateMazeReply, *maze.Maze, error) {
 	var w *sdl.Window
 
 	// create local maze for DP algorithms or local gui
-	if *showLocalGUI || *solveAlgo == "follow-policy" || *solveAlgo == "ml-td-one-step-sarsa" || *solveAlgo == "ml-td-sarsa-lambda" || *solveAlgo == "dijkstra" {
-		if *showLocalGUI {
+	if *showLocalGUI != guiNone || *solveAlgo == "follow-policy" || *solveAlgo == "ml-td-one-step-sarsa" || *solveAlgo == "ml-td-sarsa-lambda" || *solveAlgo == "dijkstra" {
+		if *showLocalGUI == guiSDL {
 			// if server gui is off, enable this so the client gui works
 			config.Gui = true
 		}
@@ -1091,9 +1197,13 @@ func opCreate() (*pb.CreateMazeReply, *maze.Maze, error) {
 		if m, r, w, err = createMaze(config, encodedMaze); err != nil {
 			log.Fatalf("could not create local client view of maze: %v", err)
 		}
-		if *showLocalGUI {
+		switch *showLocalGUI {
+		case guiSDL:
 			wd.Add(1)
 			go showMaze(m, r, w)
+		case guiTerm:
+			wd.Add(1)
+			go showMazeTerm(m)
 		}
 
 	}
@@ -1119,6 +1229,233 @@ func opList() (*pb.ListMazeReply, error) {
 	return r, nil
 }
 
+// opRender saves a snapshot of the maze with mazeID to file
+func opRender(mazeID, clientID, file string) error {
+	_, c := solvealgos.NewClient()
+
+	format := maze.RenderFormatPNG
+	if strings.ToLower(filepath.Ext(file)) == ".svg" {
+		format = maze.RenderFormatSVG
+	}
+
+	r, err := c.RenderMaze(context.Background(), &pb.RenderMazeRequest{
+		MazeId:             mazeID,
+		ClientId:           clientID,
+		Format:             format,
+		ShowDistanceColors: *showDistanceColors,
+	})
+	if err != nil {
+		return err
+	}
+	if !r.GetSuccess() {
+		return fmt.Errorf("could not render maze: %v", r.GetMessage())
+	}
+
+	log.Printf("writing %v maze to %v", format, file)
+	return ioutil.WriteFile(file, r.GetImage(), 0644)
+}
+
+// opDelete deletes the maze with mazeID on the server
+func opDelete(mazeID string) error {
+	_, c := solvealgos.NewClient()
+
+	r, err := c.DeleteMaze(context.Background(), &pb.DeleteMazeRequest{MazeId: mazeID})
+	if err != nil {
+		return err
+	}
+	if !r.GetSuccess() {
+		return fmt.Errorf("could not delete maze: %v", r.GetMessage())
+	}
+	return nil
+}
+
+// opImport sends the encoded maze in file to the server, which creates it, and returns its id
+func opImport(file string) (string, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	// the size comes from the encoded maze
+	config := newMazeConfig("", *currentLocationColor)
+	config.Rows, config.Columns, config.Levels = 0, 0, 0
+	config.FromFile = ""
+
+	_, c := solvealgos.NewClient()
+	r, err := c.ImportMaze(context.Background(), &pb.ImportMazeRequest{
+		Config:            config,
+		EncodedMaze:       string(data),
+		CheckSpanningTree: !*skipGridCheck,
+	})
+	if err != nil {
+		return "", err
+	}
+	if !r.GetSuccess() {
+		return "", fmt.Errorf("could not import maze: %v", r.GetMessage())
+	}
+	return r.GetMazeId(), nil
+}
+
+// opWatch prints the events of the maze with mazeID until it is deleted, or of all mazes if mazeID is empty
+func opWatch(mazeID string) error {
+	_, c := solvealgos.NewClient()
+
+	stream, err := c.WatchMaze(context.Background(), &pb.WatchMazeRequest{MazeId: mazeID})
+	if err != nil {
+		return err
+	}
+
+	for {
+		e, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if e.GetDropped() > 0 {
+			log.Printf("(%v events dropped)", e.GetDropped())
+		}
+		event := e.GetType()
+		if mazeID == "" {
+			event = fmt.Sprintf("[%v] %v", e.GetMazeId(), event)
+		}
+		switch e.GetType() {
+		case "client-moved":
+			log.Printf("%v: client %v moved %v to %v (reward: %v)", event, e.GetClientId(), e.GetDirection(), e.GetLocation(), e.GetReward())
+		case "client-registered":
+			log.Printf("%v: client %v at %v going to %v", event, e.GetClientId(), e.GetLocation(), e.GetToCell())
+		case "generator-step":
+			log.Printf("%v: at %v", event, e.GetLocation())
+		case "maze-changed":
+			log.Printf("%v: passages of %v", event, e.GetCells())
+		case "maze-deleted":
+			log.Printf("%v", event)
+		default:
+			log.Printf("%v: client %v at %v", event, e.GetClientId(), e.GetLocation())
+		}
+	}
+}
+
+// opCreateMany creates count mazes on the server with one call, and writes them to dir if not empty
+func opCreateMany(count, seed int64, dir string) (*pb.CreateMazesReply, error) {
+	config := newMazeConfig(*createAlgo, *currentLocationColor)
+
+	_, c := solvealgos.NewClient()
+	r, err := c.CreateMazes(context.Background(), &pb.CreateMazesRequest{
+		Config:      config,
+		Count:       count,
+		SeedStart:   seed,
+		ReturnMazes: dir != "",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !r.GetSuccess() {
+		return r, fmt.Errorf("could not create mazes: %v", r.GetMessage())
+	}
+
+	for i, encoded := range r.GetEncodedMazes() {
+		file := filepath.Join(dir, fmt.Sprintf("%v-seed-%v", config.GetCreateAlgo(), r.GetSeeds()[i]))
+		if err := ioutil.WriteFile(file, []byte(encoded), 0644); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+// opRunSolver solves the maze with mazeID on the server, runs times, and logs how each run went
+func opRunSolver(mazeID string, runs int) error {
+	_, c := solvealgos.NewClient()
+
+	var steps int64
+	var solveTime time.Duration
+	for i := 0; i < runs; i++ {
+		r, err := c.RunSolver(context.Background(), &pb.RunSolverRequest{
+			MazeId: mazeID,
+			ClientConfig: &pb.ClientConfig{
+				SolveAlgo:      *solveAlgo,
+				FromCell:       *fromCellStr,
+				ToCell:         *toCellStr,
+				PathColor:      *pathColor,
+				DrawPathLength: *drawPathLength,
+			},
+			DrawDelay: *solveDrawDelay,
+		})
+		if err != nil {
+			return err
+		}
+		if !r.GetSuccess() {
+			return fmt.Errorf("could not run solver: %v", r.GetMessage())
+		}
+
+		steps += r.GetSteps()
+		solveTime += time.Duration(r.GetSolveTime())
+		log.Printf("client %v: %v -> %v solved: %v in %v steps (solution: %v, traveled: %v) in %v", r.GetClientId(),
+			r.GetFromCell(), r.GetToCell(), r.GetSolved(), r.GetSteps(), len(r.GetSolvePath()), len(r.GetTravelPath()),
+			time.Duration(r.GetSolveTime()))
+	}
+
+	if runs > 1 {
+		log.Printf("%v runs of %v: %v steps and %v on average", runs, *solveAlgo, steps/int64(runs), solveTime/time.Duration(runs))
+	}
+	return nil
+}
+
+// opGet returns the full structure of the maze with mazeID
+func opGet(mazeID string) (*pb.Maze, error) {
+	_, c := solvealgos.NewClient()
+
+	r, err := c.GetMaze(context.Background(), &pb.GetMazeRequest{MazeId: mazeID})
+	if err != nil {
+		return nil, err
+	}
+	if !r.GetSuccess() {
+		return nil, fmt.Errorf("could not get maze: %v", r.GetMessage())
+	}
+	return r.GetMaze(), nil
+}
+
+// joinMaze creates the local copy of an existing maze, and shows it if the local gui is enabled
+func joinMaze(mazeID string) (*maze.Maze, error) {
+	pm, err := opGet(mazeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var w *sdl.Window
+	var r *sdl.Renderer
+	config := pm.GetConfig()
+	// the server records the maze, the local view is a copy
+	config.Record = nil
+	config.Gui = *showLocalGUI == guiSDL
+	if *showLocalGUI == guiSDL {
+		w, r = lsdl.SetupSDL(config, "Client View", 1, 0)
+	}
+
+	m, err := maze.NewMazeFromProto(pm, lsdl.NewRenderer(r))
+	if err != nil {
+		return nil, err
+	}
+
+	switch *showLocalGUI {
+	case guiSDL:
+		mTexture, err := m.MakeBGTexture()
+		if err != nil {
+			return nil, err
+		}
+		m.SetBGTexture(mTexture)
+
+		wd.Add(1)
+		go showMaze(m, r, w)
+	case guiTerm:
+		wd.Add(1)
+		go showMazeTerm(m)
+	}
+	return m, nil
+}
+
 // opSolve solves the maze with mazeID, m is the *local* maze for display only
 func opSolve(mazeID, clientID, solveAlgo string, m *maze.Maze, p *ml.Policy) error {
 	log.Printf("in opSolve, client: %v", clientID)
@@ -1186,7 +1523,7 @@ func setFlags() {
 }
 
 func newMaze(config *pb.MazeConfig, r *sdl.Renderer, encodedMaze string) (*maze.Maze, error) {
-	m, err := maze.NewMaze(config, r)
+	m, err := maze.NewMaze(config, lsdl.NewRenderer(r))
 	if err != nil {
 		log.Printf("invalid maze config: %v", err)
 		os.Exit(1)
@@ -1207,7 +1544,7 @@ func newMaze(config *pb.MazeConfig, r *sdl.Renderer, encodedMaze string) (*maze.
 		return nil, err
 	}
 
-	if *showLocalGUI {
+	if *showLocalGUI == guiSDL {
 		// create background texture, it is saved and re-rendered as a picture
 		mTexture, err := m.MakeBGTexture()
 		if err != nil {
@@ -1223,6 +1560,9 @@ func newMaze(config *pb.MazeConfig, r *sdl.Renderer, encodedMaze string) (*maze.
 func createMaze(config *pb.MazeConfig, encodedMaze string) (*maze.Maze, *sdl.Renderer, *sdl.Window, error) {
 	log.Print("showing client's view of the maze...")
 
+	// the server records the maze, the local view is a copy
+	config.Record = nil
+
 	if encodedMaze != "" {
 		config.CreateAlgo = "from-encoded-string"
 	} else {
@@ -1239,7 +1579,7 @@ func createMaze(config *pb.MazeConfig, encodedMaze string) (*maze.Maze, *sdl.Ren
 	//////////////////////////////////////////////////////////////////////////////////////////////
 	// Setup SDL
 	//////////////////////////////////////////////////////////////////////////////////////////////
-	if *showLocalGUI {
+	if *showLocalGUI == guiSDL {
 		// offset this window one to the right so it shows up next to the server one
 		w, r = lsdl.SetupSDL(config, "Client View", 1, 0)
 	}
@@ -1278,16 +1618,17 @@ func showMaze(m *maze.Maze, r *sdl.Renderer, w *sdl.Window) {
 		lsdl.CheckQuit(running, winID)
 
 		sdl.Do(func() {
-			colors.SetDrawColor(colors.GetColor("gray"), r)
+			lsdl.SetDrawColor(colors.GetColor("gray"), r)
 			r.Clear()
-			m.DrawMaze(r, nil)
+			mr := lsdl.NewRenderer(r)
+			m.DrawMaze(mr, nil)
 
 			for _, c := range m.ClientsSorted() {
 				cell := c.CurrentLocation()
 				if cell == nil {
 					continue
 				}
-				cell.DrawCurrentLocation(r, c, nil, "")
+				cell.DrawCurrentLocation(mr, c, nil, "")
 			}
 
 			r.Present()
@@ -1297,6 +1638,63 @@ func showMaze(m *maze.Maze, r *sdl.Renderer, w *sdl.Window) {
 	wd.Done()
 }
 
+// showMazeTerm draws the client's view of the maze in the terminal, with the path of the first client
+// It stops on esc or ctrl-c, or once all clients are solved when the (manual) solver reads the keyboard.
+func showMazeTerm(m *maze.Maze) {
+	defer wd.Done()
+	defer termbox.Close()
+
+	running := abool.New()
+	running.Set()
+
+	if *solveAlgo != "manual" {
+		go func() {
+			for running.IsSet() {
+				ev := termbox.PollEvent()
+				if ev.Type == termbox.EventKey && (ev.Key == termbox.KeyEsc || ev.Key == termbox.KeyCtrlC) {
+					running.UnSet()
+				}
+			}
+		}()
+	}
+
+	for running.IsSet() {
+		opts := maze.TextOptions{Clients: true}
+		solved := true
+		for i, c := range m.ClientsSorted() {
+			if i == 0 {
+				opts.ClientID, opts.Path = c.ID(), true
+			}
+			if c.CurrentLocation() != c.ToCell() {
+				solved = false
+			}
+		}
+
+		text, err := m.Text(opts)
+		if err != nil {
+			log.Printf("error drawing maze as text: %v", err)
+			return
+		}
+
+		termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+		for y, line := range strings.Split(text, "\n") {
+			for x, ch := range []rune(line) {
+				fg := termbox.ColorDefault
+				if ch == '@' {
+					fg = termbox.ColorRed | termbox.AttrBold
+				}
+				termbox.SetCell(x, y, ch, fg, termbox.ColorDefault)
+			}
+		}
+		termbox.Flush()
+
+		if solved && *solveAlgo == "manual" {
+			running.UnSet()
+		}
+		time.Sleep(time.Second / time.Duration(*frameRate))
+	}
+}
+
 func run() {
 	setFlags()
 
@@ -1309,6 +1707,14 @@ func run() {
 		} else {
 			log.Printf("%#v", r)
 		}
+	case "create_many":
+		r, err := opCreateMany(*mazeCount, *seedStart, *exportDir)
+		for i, id := range r.GetMazeIds() {
+			log.Printf("maze: %v (seed: %v)", id, r.GetSeeds()[i])
+		}
+		if err != nil {
+			log.Fatalf(err.Error())
+		}
 	case "list":
 		if r, err := opList(); err != nil {
 			log.Fatalf(err.Error())
@@ -1320,12 +1726,63 @@ func run() {
 				}
 			}
 		}
+	case "delete":
+		if err := opDelete(*mazeID); err != nil {
+			log.Fatalf(err.Error())
+		}
+		log.Printf("deleted maze %v", *mazeID)
+	case "import":
+		files, err := filepath.Glob(*importFiles)
+		if err != nil {
+			log.Fatalf(err.Error())
+		}
+		if len(files) == 0 {
+			log.Fatalf("no files match --import_files=%q", *importFiles)
+		}
+		for _, f := range files {
+			id, err := opImport(f)
+			if err != nil {
+				log.Printf("%v: %v", f, err)
+				continue
+			}
+			log.Printf("imported %v as maze %v", f, id)
+		}
+	case "watch":
+		if err := opWatch(*mazeID); err != nil {
+			log.Fatalf(err.Error())
+		}
+	case "get":
+		pm, err := opGet(*mazeID)
+		if err != nil {
+			log.Fatalf(err.Error())
+		}
+		pm.GetConfig().Gui = false
+		m, err := maze.NewMazeFromProto(pm, nil)
+		if err != nil {
+			log.Fatalf(err.Error())
+		}
+		log.Printf("maze: %v (%v cells)", pm.GetMazeId(), len(pm.GetCells()))
+		for _, c := range pm.GetClients() {
+			log.Printf("  client: %v (%v -> %v)", c.GetClientId(), c.GetFromCell(), c.GetToCell())
+		}
+		if text, err := m.Text(maze.TextOptions{}); err == nil {
+			fmt.Print(text)
+		}
 	case "solve":
 		if *randomFromTo {
 			*fromCellStr = "random"
 			*toCellStr = "random"
 		}
 
+		// planning solvers need the whole maze
+		var m *maze.Maze
+		if *showLocalGUI != guiNone || *solveAlgo == "dijkstra" {
+			var err error
+			if m, err = joinMaze(*mazeID); err != nil {
+				log.Fatalf(err.Error())
+			}
+		}
+
 		if err := addClient(context.Background(), *mazeID, &pb.ClientConfig{
 			SolveAlgo:              *solveAlgo,
 			PathColor:              *pathColor,
@@ -1339,7 +1796,20 @@ func run() {
 			DrawPathLength:         *drawPathLength,
 			MarkVisitedCells:       *markVisitedCells,
 			NumberMarkVisitedCells: *numberMarkVisitedCells,
-		}, nil, nil); err != nil {
+			Record:                 newRecordConfig(*recordSolveFile),
+		}, m, nil); err != nil {
+			log.Fatalf(err.Error())
+		}
+	case "run_solver":
+		if *randomFromTo {
+			*fromCellStr = "random"
+			*toCellStr = "random"
+		}
+		if err := opRunSolver(*mazeID, *solverRuns); err != nil {
+			log.Fatalf(err.Error())
+		}
+	case "render":
+		if err := opRender(*mazeID, *clientID, *renderFile); err != nil {
 			log.Fatalf(err.Error())
 		}
 	case "create_solve":
@@ -1388,7 +1858,7 @@ func main() {
 	flag.Parse()
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
-	if *solveAlgo == "manual" {
+	if *solveAlgo == "manual" || *showLocalGUI == guiTerm {
 		err := termbox.Init()
 		if err != nil {
 			panic(err)
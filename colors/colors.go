@@ -1,11 +1,8 @@
 package colors
 
 import (
-	"log"
-
 	"github.com/DanTulovsky/mazes/utils"
 
-	"github.com/veandco/go-sdl2/sdl"
 	pcolors "gopkg.in/go-playground/colors.v1"
 )
 
@@ -122,14 +119,6 @@ func GetRandomColor() Color {
 	return ColorMap["white"]
 }
 
-// SetDrawColor Sets the drawing color on the renderer
-func SetDrawColor(c Color, r *sdl.Renderer) *sdl.Renderer {
-	if err := r.SetDrawColor(c.R, c.G, c.B, c.A); err != nil {
-		log.Printf("error setting color [%#v]: %v", c, err)
-	}
-	return r
-}
-
 // Same return true if the two colors are the same, ignores color mask
 func Same(a, b Color) bool {
 	if a.R == b.R && a.G == b.G && a.B == b.B {
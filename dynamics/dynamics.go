@@ -0,0 +1,290 @@
+// Package dynamics changes the passages of a live maze while it is being solved, see MazeConfig.Dynamics
+//
+// A change never disconnects the maze: a passage is only closed if its two cells are still connected some
+// other way, so every client can always reach its target. That is, only passages on loops close; on a perfect
+// maze toggles only open passages (until there are loops) and closures do nothing, braid it first.
+package dynamics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DanTulovsky/mazes/maze"
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+// Policies, see DynamicsConfig.Policy
+const (
+	// PolicyToggle opens or closes the passage between a random cell and a random neighbor
+	PolicyToggle = "toggle"
+	// PolicyDoors turns every door, a random cell picked at the start, to the next of its directions
+	PolicyDoors = "doors"
+	// PolicyClosures closes a random passage for DynamicsConfig.ClosureDuration, then opens it again
+	PolicyClosures = "closures"
+)
+
+const (
+	// cellsPerDoor is the number of cells per door when DynamicsConfig.Doors is not set
+	cellsPerDoor = 50
+	// defaultClosureDuration is how long passages stay closed when DynamicsConfig.ClosureDuration is not set
+	defaultClosureDuration = 10 * time.Second
+	// minInterval is the shortest time between changes, for very high rates
+	minInterval = time.Millisecond
+)
+
+// CheckConfig returns an error if config is not valid, a nil config (or no policy) is valid: the maze never changes
+func CheckConfig(config *pb.DynamicsConfig) error {
+	if config.GetPolicy() == "" {
+		return nil
+	}
+
+	switch config.GetPolicy() {
+	case PolicyToggle, PolicyDoors, PolicyClosures:
+	default:
+		return fmt.Errorf("invalid dynamics policy %q, want %v, %v or %v", config.GetPolicy(), PolicyToggle, PolicyDoors, PolicyClosures)
+	}
+
+	if config.GetRate() <= 0 {
+		return fmt.Errorf("dynamics rate must be positive, got %v", config.GetRate())
+	}
+	if config.GetDoors() < 0 {
+		return fmt.Errorf("dynamics doors cannot be negative, got %v", config.GetDoors())
+	}
+	if config.GetClosureDuration() != "" {
+		if _, err := time.ParseDuration(config.GetClosureDuration()); err != nil {
+			return fmt.Errorf("invalid dynamics closure duration: %v", err)
+		}
+	}
+	return nil
+}
+
+// closure is a passage closed until a time
+type closure struct {
+	from, to *maze.Cell
+	until    time.Time
+}
+
+// Dynamics changes the passages of one maze, one step at a time (see Step)
+type Dynamics struct {
+	maze   *maze.Maze
+	config *pb.DynamicsConfig
+
+	doors           []*maze.Cell
+	closures        []closure
+	closureDuration time.Duration
+}
+
+// New returns the dynamics of m, as set by config
+func New(m *maze.Maze, config *pb.DynamicsConfig) (*Dynamics, error) {
+	if err := CheckConfig(config); err != nil {
+		return nil, err
+	}
+	if config.GetPolicy() == "" {
+		return nil, fmt.Errorf("dynamics policy not set")
+	}
+	if m.Config().GetAllowWeaving() {
+		return nil, fmt.Errorf("dynamics does not support weaving")
+	}
+
+	d := &Dynamics{maze: m, config: config, closureDuration: defaultClosureDuration}
+
+	if config.GetClosureDuration() != "" {
+		d.closureDuration, _ = time.ParseDuration(config.GetClosureDuration())
+	}
+
+	if config.GetPolicy() == PolicyDoors {
+		doors := int(config.GetDoors())
+		if doors == 0 {
+			doors = len(m.Cells())/cellsPerDoor + 1
+		}
+		if doors > len(m.Cells()) {
+			doors = len(m.Cells())
+		}
+
+		picked := make(map[*maze.Cell]bool)
+		for len(d.doors) < doors {
+			c := m.RandomCell()
+			if !picked[c] {
+				picked[c] = true
+				d.doors = append(d.doors, c)
+			}
+		}
+	}
+	return d, nil
+}
+
+// Interval returns the time between steps
+func (d *Dynamics) Interval() time.Duration {
+	interval := time.Duration(float64(time.Second) / d.config.GetRate())
+	if interval < minInterval {
+		interval = minInterval
+	}
+	return interval
+}
+
+// Doors returns the doors of the maze, if the policy is doors
+func (d *Dynamics) Doors() []*maze.Cell {
+	return d.doors
+}
+
+// Step changes the maze once, at time now, and returns the cells whose passages changed; none if the change
+// it picked would have disconnected the maze
+func (d *Dynamics) Step(now time.Time) []*maze.Cell {
+	switch d.config.GetPolicy() {
+	case PolicyToggle:
+		return d.toggle()
+	case PolicyDoors:
+		return d.turnDoors()
+	case PolicyClosures:
+		return d.close(now)
+	}
+	return nil
+}
+
+// toggle opens or closes the passage between a random cell and a random neighbor
+func (d *Dynamics) toggle() []*maze.Cell {
+	cell := d.maze.RandomCell()
+	neighbors := cell.Neighbors()
+	if len(neighbors) == 0 {
+		return nil
+	}
+	n := d.maze.RandomCellFromList(neighbors)
+
+	if !cell.Linked(n) {
+		d.maze.Link(cell, n)
+		return []*maze.Cell{cell, n}
+	}
+
+	if !d.unlink(cell, n) {
+		return nil
+	}
+	return []*maze.Cell{cell, n}
+}
+
+// turnDoors turns every door to the next of its directions: each passage out of the door moves to the
+// next neighbor, in the order of the directions of the cell
+func (d *Dynamics) turnDoors() []*maze.Cell {
+	var changed []*maze.Cell
+
+	for _, door := range d.doors {
+		var neighbors []*maze.Cell
+		for _, dir := range door.Directions() {
+			if n := door.Neighbor(dir); n != nil {
+				neighbors = append(neighbors, n)
+			}
+		}
+		if len(neighbors) < 2 {
+			continue
+		}
+
+		open := make([]bool, len(neighbors))
+		for i, n := range neighbors {
+			if door.Linked(n) {
+				open[(i+1)%len(neighbors)] = true
+			}
+		}
+
+		var linked, unlinked []*maze.Cell
+		for i, n := range neighbors {
+			switch {
+			case open[i] && !door.Linked(n):
+				linked = append(linked, n)
+			case !open[i] && door.Linked(n):
+				unlinked = append(unlinked, n)
+			}
+		}
+
+		for _, n := range linked {
+			d.maze.Link(door, n)
+		}
+		for _, n := range unlinked {
+			door.UnLink(n)
+		}
+
+		// all of them or none of them, so a door does not get stuck half way
+		if !d.stillConnected(door, unlinked) {
+			for _, n := range unlinked {
+				d.maze.Link(door, n)
+			}
+			for _, n := range linked {
+				door.UnLink(n)
+			}
+			continue
+		}
+
+		changed = append(changed, door)
+		changed = append(changed, linked...)
+		changed = append(changed, unlinked...)
+	}
+	return changed
+}
+
+// close opens the passages closed until now, and closes a random passage
+func (d *Dynamics) close(now time.Time) []*maze.Cell {
+	var changed []*maze.Cell
+
+	closures := d.closures[:0]
+	for _, c := range d.closures {
+		if now.Before(c.until) {
+			closures = append(closures, c)
+			continue
+		}
+		d.maze.Link(c.from, c.to)
+		changed = append(changed, c.from, c.to)
+	}
+	d.closures = closures
+
+	cell := d.maze.RandomCell()
+	links := cell.Links()
+	if len(links) == 0 {
+		return changed
+	}
+	n := d.maze.RandomCellFromList(links)
+
+	if d.unlink(cell, n) {
+		d.closures = append(d.closures, closure{from: cell, to: n, until: now.Add(d.closureDuration)})
+		changed = append(changed, cell, n)
+	}
+	return changed
+}
+
+// unlink removes the passage between from and to, unless that disconnects them; it returns true if it did
+func (d *Dynamics) unlink(from, to *maze.Cell) bool {
+	from.UnLink(to)
+	if !Connected(from, to) {
+		d.maze.Link(from, to)
+		return false
+	}
+	return true
+}
+
+// stillConnected returns true if cell is connected to all of unlinked, some other way
+func (d *Dynamics) stillConnected(cell *maze.Cell, unlinked []*maze.Cell) bool {
+	for _, n := range unlinked {
+		if !Connected(cell, n) {
+			return false
+		}
+	}
+	return true
+}
+
+// Connected returns true if there is a path from one cell to the other
+func Connected(from, to *maze.Cell) bool {
+	found := map[*maze.Cell]bool{from: true}
+	queue := []*maze.Cell{from}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		if cell == to {
+			return true
+		}
+
+		for _, l := range cell.Links() {
+			if !found[l] {
+				found[l] = true
+				queue = append(queue, l)
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package dynamics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DanTulovsky/mazes/genalgos/recursive_backtracker"
+	"github.com/DanTulovsky/mazes/maze"
+	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/tevino/abool"
+)
+
+var checktests = []struct {
+	config  *pb.DynamicsConfig
+	wantErr bool
+}{
+	{
+		config:  nil,
+		wantErr: false,
+	}, {
+		config:  &pb.DynamicsConfig{Policy: PolicyToggle, Rate: 10},
+		wantErr: false,
+	}, {
+		config:  &pb.DynamicsConfig{Policy: PolicyDoors, Rate: 0.5, Doors: 3},
+		wantErr: false,
+	}, {
+		config:  &pb.DynamicsConfig{Policy: PolicyClosures, Rate: 1, ClosureDuration: "5s"},
+		wantErr: false,
+	}, {
+		config:  &pb.DynamicsConfig{Policy: "earthquake", Rate: 1},
+		wantErr: true,
+	}, {
+		config:  &pb.DynamicsConfig{Policy: PolicyToggle},
+		wantErr: true,
+	}, {
+		config:  &pb.DynamicsConfig{Policy: PolicyDoors, Rate: 1, Doors: -1},
+		wantErr: true,
+	}, {
+		config:  &pb.DynamicsConfig{Policy: PolicyClosures, Rate: 1, ClosureDuration: "soon"},
+		wantErr: true,
+	},
+}
+
+func TestCheckConfig(t *testing.T) {
+	for _, tt := range checktests {
+		err := CheckConfig(tt.config)
+		if err != nil && !tt.wantErr {
+			t.Errorf("CheckConfig(%v) failed: %v", tt.config, err)
+		}
+		if err == nil && tt.wantErr {
+			t.Errorf("CheckConfig(%v) should have failed", tt.config)
+		}
+	}
+}
+
+// newMaze returns a perfect maze
+func newMaze(t *testing.T, config *pb.MazeConfig) *maze.Maze {
+	m, err := maze.NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	if err := (&recursive_backtracker.RecursiveBacktracker{}).Apply(m, 0, abool.NewBool(true)); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	return m
+}
+
+// checkConnected fails t if not all cells of m are connected
+func checkConnected(t *testing.T, m *maze.Maze, step int) {
+	cells := m.OrderedCells()
+	found := map[*maze.Cell]bool{cells[0]: true}
+	queue := []*maze.Cell{cells[0]}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		for _, l := range cell.Links() {
+			if !found[l] {
+				found[l] = true
+				queue = append(queue, l)
+			}
+		}
+	}
+
+	if len(found) != len(cells) {
+		t.Fatalf("step %v: %v of %v cells can be reached", step, len(found), len(cells))
+	}
+}
+
+var steptests = []struct {
+	config *pb.MazeConfig
+}{
+	{
+		config: &pb.MazeConfig{
+			Rows: 8, Columns: 8,
+			Dynamics: &pb.DynamicsConfig{Policy: PolicyToggle, Rate: 1},
+		},
+	}, {
+		config: &pb.MazeConfig{
+			Rows: 8, Columns: 8,
+			Dynamics: &pb.DynamicsConfig{Policy: PolicyDoors, Rate: 1, Doors: 4},
+		},
+	}, {
+		config: &pb.MazeConfig{
+			Rows: 8, Columns: 8,
+			Dynamics: &pb.DynamicsConfig{Policy: PolicyClosures, Rate: 1, ClosureDuration: "3s"},
+		},
+	}, {
+		config: &pb.MazeConfig{
+			Rows: 8, Columns: 8, GridType: maze.GridHex,
+			Dynamics: &pb.DynamicsConfig{Policy: PolicyDoors, Rate: 1},
+		},
+	},
+}
+
+func TestStep(t *testing.T) {
+	for _, tt := range steptests {
+		m := newMaze(t, tt.config)
+		m.Braid(0.5) // toggles and closures only close passages on loops
+		d, err := New(m, tt.config.GetDynamics())
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+
+		now := time.Now()
+		changes := 0
+		for i := 0; i < 200; i++ {
+			now = now.Add(d.Interval())
+			if len(d.Step(now)) > 0 {
+				changes++
+			}
+			checkConnected(t, m, i)
+		}
+		if changes == 0 {
+			t.Errorf("%v: the maze never changed", tt.config.GetDynamics())
+		}
+	}
+}
+
+func TestClosures(t *testing.T) {
+	config := &pb.MazeConfig{
+		Rows: 8, Columns: 8,
+		Dynamics: &pb.DynamicsConfig{Policy: PolicyClosures, Rate: 1, ClosureDuration: "1h"},
+	}
+	m := newMaze(t, config)
+	m.Braid(1) // passages only close on loops, a perfect maze has none
+	d, err := New(m, config.GetDynamics())
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		d.Step(now)
+	}
+	if len(d.closures) == 0 {
+		t.Fatalf("no passage closed")
+	}
+	closed := d.closures[0]
+	if closed.from.Linked(closed.to) {
+		t.Errorf("%v and %v are still linked", closed.from, closed.to)
+	}
+
+	// all open again, the step may close one new passage
+	d.Step(now.Add(2 * time.Hour))
+	if len(d.closures) > 1 {
+		t.Fatalf("%v passages still closed", len(d.closures))
+	}
+	reclosed := len(d.closures) == 1 && !d.closures[0].from.Linked(d.closures[0].to) &&
+		(d.closures[0].from == closed.from || d.closures[0].from == closed.to) &&
+		(d.closures[0].to == closed.from || d.closures[0].to == closed.to)
+	if !closed.from.Linked(closed.to) && !reclosed {
+		t.Errorf("%v and %v did not open again", closed.from, closed.to)
+	}
+}
+
+func TestNewWeaving(t *testing.T) {
+	m, err := maze.NewMaze(&pb.MazeConfig{Rows: 5, Columns: 5, AllowWeaving: true}, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	if _, err := New(m, &pb.DynamicsConfig{Policy: PolicyToggle, Rate: 1}); err == nil {
+		t.Errorf("New should fail on a woven maze")
+	}
+}
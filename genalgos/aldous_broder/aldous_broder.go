@@ -26,7 +26,10 @@ func (a *AldousBroder) Apply(m *maze.Maze, delay time.Duration, generating *aboo
 	currentCell.SetVisited(maze.VisitedGenerator)
 	visitedCells++
 
-	for visitedCells < len(m.Cells()) {
+	// the cells in the maze do not change while generating
+	numCells := len(m.Cells())
+
+	for visitedCells < numCells {
 		if !generating.IsSet() {
 			return fmt.Errorf("stop requested")
 		}
@@ -8,8 +8,8 @@ import (
 	"time"
 
 	"github.com/DanTulovsky/mazes/maze"
+	pb "github.com/DanTulovsky/mazes/proto"
 	"github.com/DanTulovsky/mazes/tree"
-	"github.com/DanTulovsky/mazes/utils"
 
 	"github.com/tevino/abool"
 )
@@ -27,7 +27,8 @@ func (a *Common) Apply(m *maze.Maze, duration time.Duration, bool *abool.AtomicB
 	return nil, errors.New("Apply() not implemented")
 }
 
-func Step(m *maze.Maze, t *tree.Tree, currentCell, parentCell *maze.Cell) bool {
+// Step walks the maze depth first from currentCell, adding the cells to t, it returns an error if there is a cycle
+func Step(m *maze.Maze, t *tree.Tree, currentCell, parentCell *maze.Cell) error {
 
 	var nextCell *maze.Cell
 	currentCell.SetVisited(maze.VisitedGenerator)
@@ -47,12 +48,12 @@ func Step(m *maze.Maze, t *tree.Tree, currentCell, parentCell *maze.Cell) bool {
 
 			if nextNode == nil {
 				// something is really wrong and should never happen
-				maze.Fail(fmt.Errorf("unable to find %v in tree", nextCell))
+				return fmt.Errorf("unable to find %v in tree", nextCell)
 			}
 
 			if currentNode.Parent() != nextNode {
-				maze.Fail(fmt.Errorf("found a cycle in the graph, %v is connected to %v, but %v is not the parent;\n%v", currentNode,
-					nextNode, nextNode, t))
+				return fmt.Errorf("found a cycle in the graph, %v is connected to %v, but %v is not the parent", currentNode,
+					nextNode, nextNode)
 			}
 		}
 
@@ -60,15 +61,15 @@ func Step(m *maze.Maze, t *tree.Tree, currentCell, parentCell *maze.Cell) bool {
 
 	for _, nextCell = range currentCell.Links() {
 		if !nextCell.Visited(maze.VisitedGenerator) {
-			if Step(m, t, nextCell, currentCell) {
-				return true
+			if err := Step(m, t, nextCell, currentCell); err != nil {
+				return err
 			}
 		}
 
 		currentCell.SetVisited(maze.VisitedGenerator)
 	}
 
-	return false
+	return nil
 }
 
 // CheckGrid checks that the generated grid is valid
@@ -90,7 +91,9 @@ func (a *Common) CheckGrid(m *maze.Maze) error {
 		return err
 	}
 
-	Step(m, t, start, start)
+	if err := Step(m, t, start, start); err != nil {
+		return err
+	}
 
 	// verify t has the same number of nodes as g
 	if !m.Config().AllowWeaving {
@@ -103,6 +106,24 @@ func (a *Common) CheckGrid(m *maze.Maze) error {
 	return nil
 }
 
+// CheckSquareGrid returns an error if the maze is not a square grid
+// Used by algorithms that only know how to work with rows and columns of square cells.
+func CheckSquareGrid(m *maze.Maze) error {
+	if m.GridType() != maze.GridSquare {
+		return fmt.Errorf("algorithm only supports %v grids, not %v", maze.GridSquare, m.GridType())
+	}
+	return nil
+}
+
+// CheckSquareGridConfig returns an error if config is not for a square grid, see CheckSquareGrid
+// Use it to reject a config before the maze is created.
+func CheckSquareGridConfig(config *pb.MazeConfig) error {
+	if t := config.GetGridType(); t != "" && t != maze.GridSquare {
+		return fmt.Errorf("algorithm only supports %v grids, not %v", maze.GridSquare, t)
+	}
+	return nil
+}
+
 // Cleanup cleans up after generator is done
 func (a *Common) Cleanup(m *maze.Maze) {
 	m.SetGenCurrentLocation(nil)
@@ -112,8 +133,8 @@ func TimeTrack(m *maze.Maze, start time.Time) {
 	m.SetCreateTime(time.Since(start))
 }
 
-// RandomUnvisitedCellFromList returns a random cell from n that has not been visited
-func RandomUnvisitedCellFromList(neighbors []*maze.Cell) *maze.Cell {
+// RandomUnvisitedCellFromList returns a random cell from n that has not been visited, picked with the random numbers of m
+func RandomUnvisitedCellFromList(m *maze.Maze, neighbors []*maze.Cell) *maze.Cell {
 	var allowed []*maze.Cell
 	for _, n := range neighbors {
 		if !n.Visited(maze.VisitedGenerator) {
@@ -124,5 +145,5 @@ func RandomUnvisitedCellFromList(neighbors []*maze.Cell) *maze.Cell {
 	if len(allowed) == 0 {
 		return nil
 	}
-	return allowed[utils.Random(0, len(allowed))]
+	return allowed[m.Rand().Random(0, len(allowed))]
 }
@@ -0,0 +1,288 @@
+// Package automaton generates mazes with life like cellular automata
+
+// A random patch of pixels in the middle of the grid, one pixel per cell, grows under a rule such as
+// B3/S12345 ("Maze") or B3/S1234 ("Mazectric") until it settles. The live pixels are the walls: their cells
+// are orphaned. The dead pixels are the corridors: their cells are linked to their dead neighbors. The
+// corridors come out as many pockets, walls one pixel thick between two pockets are knocked down (in random
+// order) to join them; pockets that cannot be joined that way are orphaned as well.
+// The maze has loops wherever the corridors are wider than one cell, it is not a perfect maze.
+package automaton
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tevino/abool"
+	"github.com/DanTulovsky/mazes/automata/rules"
+	"github.com/DanTulovsky/mazes/automata/states"
+	"github.com/DanTulovsky/mazes/genalgos"
+	"github.com/DanTulovsky/mazes/maze"
+	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/DanTulovsky/mazes/utils"
+)
+
+const (
+	// generationsPerSide caps the generations at this many per row and column, in case the pattern never settles
+	generationsPerSide = 10
+	// minSide is the fewest rows and columns; on smaller grids the pattern often dies out or fills everything
+	minSide = 4
+	// maxAttempts is how many random patches are grown before giving up, when they leave no corridors
+	maxAttempts = 10
+)
+
+type Automaton struct {
+	genalgos.Common
+	Rule rules.LifeLike
+}
+
+// CheckConfig returns an error if the automaton cannot generate the maze of config
+func CheckConfig(config *pb.MazeConfig) error {
+	switch {
+	case config.GetGridType() != "" && config.GetGridType() != maze.GridSquare:
+		return fmt.Errorf("automaton only supports %v grids, not %v", maze.GridSquare, config.GetGridType())
+	case config.GetLevels() > 1:
+		return fmt.Errorf("automaton only supports one level, not %v", config.GetLevels())
+	case config.GetAllowWeaving():
+		return fmt.Errorf("automaton does not support weaving")
+	case config.GetRows() < minSide || config.GetColumns() < minSide:
+		return fmt.Errorf("automaton needs at least %v rows and columns, not %vx%v", minSide, config.GetColumns(), config.GetRows())
+	}
+	return nil
+}
+
+// Apply runs the automaton and carves its pattern into the maze
+func (a *Automaton) Apply(m *maze.Maze, delay time.Duration, generating *abool.AtomicBool) error {
+	defer genalgos.TimeTrack(m, time.Now())
+
+	if err := CheckConfig(m.Config()); err != nil {
+		return err
+	}
+
+	// a patch can die out or fill the grid, grow another one then
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		p, err := a.grow(m, delay, generating)
+		if err != nil {
+			return err
+		}
+
+		pocket := joinCorridors(p, m.Rand())
+		if largest, ok := largestPocket(p, pocket); ok {
+			if err := carve(m, p, pocket, largest); err != nil {
+				return err
+			}
+			a.Cleanup(m)
+			return nil
+		}
+	}
+	return fmt.Errorf("automaton left no corridors after %v attempts", maxAttempts)
+}
+
+// grow runs the rule on a random patch until it settles, and returns the pattern
+func (a *Automaton) grow(m *maze.Maze, delay time.Duration, generating *abool.AtomicBool) (rules.Pixels, error) {
+	columns, rows := m.Dimensions()
+	p := states.RandomPatch(rules.NewPixels(int(columns), int(rows)), m.Rand())
+
+	maxGenerations := generationsPerSide * int(columns+rows)
+	for gen, changed := 0, true; changed && gen < maxGenerations; gen++ {
+		if !generating.IsSet() {
+			return nil, fmt.Errorf("stop requested")
+		}
+
+		time.Sleep(delay) // animation delay
+
+		p, changed = p.Step(a.Rule)
+	}
+	return p, nil
+}
+
+// pixel is the location of a pixel
+type pixel struct {
+	x, y int
+}
+
+// neighbors returns the pixels next to px (not diagonally), inside p
+func (px pixel) neighbors(p rules.Pixels) []pixel {
+	var n []pixel
+	for _, d := range []pixel{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+		x, y := px.x+d.x, px.y+d.y
+		if x >= 0 && x < p.Width() && y >= 0 && y < p.Height() {
+			n = append(n, pixel{x, y})
+		}
+	}
+	return n
+}
+
+// joinCorridors kills the live pixels between two pockets of dead pixels, in random order, until no live
+// pixel joins two pockets; it returns the pocket of each dead pixel
+func joinCorridors(p rules.Pixels, r *utils.Rand) map[pixel]int {
+	pocket := make(map[pixel]int)
+	pockets := 0
+
+	for x := 0; x < p.Width(); x++ {
+		for y := 0; y < p.Height(); y++ {
+			start := pixel{x, y}
+			if p[x][y] {
+				continue
+			}
+			if _, ok := pocket[start]; ok {
+				continue
+			}
+
+			id := pockets
+			pockets++
+			pocket[start] = id
+			queue := []pixel{start}
+			for len(queue) > 0 {
+				px := queue[0]
+				queue = queue[1:]
+
+				for _, n := range px.neighbors(p) {
+					if _, ok := pocket[n]; !ok && !p[n.x][n.y] {
+						pocket[n] = id
+						queue = append(queue, n)
+					}
+				}
+			}
+		}
+	}
+
+	// pockets are joined as sets (see kruskal)
+	parent := make([]int, pockets)
+	for i := range parent {
+		parent[i] = i
+	}
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+
+	var walls []pixel
+	for x := 0; x < p.Width(); x++ {
+		for y := 0; y < p.Height(); y++ {
+			if p[x][y] {
+				walls = append(walls, pixel{x, y})
+			}
+		}
+	}
+	for i := len(walls) - 1; i > 0; i-- {
+		j := r.Random(0, i+1)
+		walls[i], walls[j] = walls[j], walls[i]
+	}
+
+	for _, w := range walls {
+		var joined []int
+		for _, n := range w.neighbors(p) {
+			if id, ok := pocket[n]; ok && !p[n.x][n.y] {
+				joined = append(joined, find(id))
+			}
+		}
+
+		joins := false
+		for _, id := range joined {
+			if id != joined[0] {
+				joins = true
+			}
+		}
+		if !joins {
+			continue
+		}
+
+		p[w.x][w.y] = false
+		for _, id := range joined {
+			parent[find(id)] = joined[0]
+		}
+		pocket[w] = joined[0]
+	}
+
+	for px, id := range pocket {
+		pocket[px] = find(id)
+	}
+	return pocket
+}
+
+// largestPocket returns the largest pocket of corridors, false if there is none of at least two pixels
+func largestPocket(p rules.Pixels, pocket map[pixel]int) (int, bool) {
+	sizes := make(map[int]int)
+	largest, largestSize := -1, 0
+	for x := 0; x < p.Width(); x++ {
+		for y := 0; y < p.Height(); y++ {
+			id, ok := pocket[pixel{x, y}]
+			if !ok {
+				continue
+			}
+			sizes[id]++
+			if sizes[id] > largestSize {
+				largest, largestSize = id, sizes[id]
+			}
+		}
+	}
+	return largest, largestSize >= 2
+}
+
+// carve orphans the cells of the live pixels of p and of all but the largest pocket of corridors, and links
+// the rest to their neighbors
+func carve(m *maze.Maze, p rules.Pixels, pocket map[pixel]int, largest int) error {
+	corridor := func(px pixel) bool {
+		id, ok := pocket[px]
+		return ok && id == largest
+	}
+
+	// the config may be shared with other mazes (e.g. the client's copy), the mask only applies to this one
+	var mask []*pb.MazeLocation
+	for x := 0; x < p.Width(); x++ {
+		for y := 0; y < p.Height(); y++ {
+			if !corridor(pixel{x, y}) {
+				mask = append(mask, &pb.MazeLocation{X: int64(x), Y: int64(y), Z: 0})
+			}
+		}
+	}
+	if err := m.ApplyOrphanMask(mask); err != nil {
+		return err
+	}
+
+	for x := 0; x < p.Width(); x++ {
+		for y := 0; y < p.Height(); y++ {
+			px := pixel{x, y}
+			if !corridor(px) {
+				continue
+			}
+			cell := m.CellBeSure(int64(x), int64(y), 0)
+			m.SetGenCurrentLocation(cell)
+
+			// east and south, west and north are linked from the other side
+			for _, n := range []pixel{{x + 1, y}, {x, y + 1}} {
+				if n.x < p.Width() && n.y < p.Height() && corridor(n) {
+					m.Link(cell, m.CellBeSure(int64(n.x), int64(n.y), 0))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// CheckGrid checks that all cells of the maze are connected, there are loops
+func (a *Automaton) CheckGrid(m *maze.Maze) error {
+	m.Reset()
+
+	start := m.RandomCell()
+	found := map[*maze.Cell]bool{start: true}
+	queue := []*maze.Cell{start}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		for _, l := range cell.Links() {
+			if !found[l] {
+				found[l] = true
+				queue = append(queue, l)
+			}
+		}
+	}
+
+	if len(found) != len(m.Cells()) {
+		return fmt.Errorf("%v of %v cells are connected", len(found), len(m.Cells()))
+	}
+	return nil
+}
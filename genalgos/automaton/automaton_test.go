@@ -0,0 +1,179 @@
+package automaton
+
+import (
+	"github.com/DanTulovsky/mazes/automata/rules"
+	"github.com/DanTulovsky/mazes/maze"
+	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/DanTulovsky/mazes/utils"
+	"github.com/tevino/abool"
+	"testing"
+)
+
+var applytests = []struct {
+	config  *pb.MazeConfig
+	rule    rules.LifeLike
+	wantErr bool
+}{
+	{
+		config: &pb.MazeConfig{
+			Rows:    utils.Random64(10, 40),
+			Columns: utils.Random64(10, 40),
+		},
+		rule:    rules.MazeRule,
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:    utils.Random64(10, 40),
+			Columns: utils.Random64(10, 40),
+		},
+		rule:    rules.MazectricRule,
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:    40,
+			Columns: 60,
+		},
+		rule:    rules.MazectricRule,
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:    5,
+			Columns: 5,
+		},
+		rule:    rules.MazeRule,
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:    3,
+			Columns: 3,
+		},
+		rule:    rules.MazeRule,
+		wantErr: true,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:    20,
+			Columns: 3,
+		},
+		rule:    rules.MazectricRule,
+		wantErr: true,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:     10,
+			Columns:  15,
+			GridType: maze.GridHex,
+		},
+		rule:    rules.MazeRule,
+		wantErr: true,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:    10,
+			Columns: 15,
+			Levels:  2,
+		},
+		rule:    rules.MazeRule,
+		wantErr: true,
+	},
+}
+
+func TestApply(t *testing.T) {
+
+	for _, tt := range applytests {
+		g, err := maze.NewMaze(tt.config, nil)
+		a := &Automaton{Rule: tt.rule}
+
+		if err != nil {
+			t.Errorf("invalid config: %v", err)
+			continue
+		}
+
+		if err := a.Apply(g, 0, abool.NewBool(true)); err != nil {
+			if !tt.wantErr {
+				t.Errorf("apply failed: %v", err)
+			}
+			continue // skip the rest of the tests
+		}
+		if tt.wantErr {
+			t.Errorf("apply should have failed with config %v", tt.config)
+		}
+
+		if err := a.CheckGrid(g); err != nil {
+			t.Errorf("grid is not valid: %v", err)
+		}
+
+		// the orphans are the walls, the rest are corridors
+		if orphans := len(g.OrphanCells()); orphans == 0 || len(g.Cells()) == 0 {
+			t.Errorf("%v orphans and %v cells, want both", orphans, len(g.Cells()))
+		}
+		for c := range g.OrphanCells() {
+			if len(c.Links()) > 0 {
+				t.Errorf("orphan %v has passages", c)
+			}
+		}
+		// the config is shared, e.g. with the client's copy of the maze, the walls are not added to it
+		if mask := tt.config.GetOrphanMask(); len(mask) > 0 {
+			t.Errorf("config has an orphan mask of %v cells after apply, want none", len(mask))
+		}
+	}
+}
+
+func TestApplySmall(t *testing.T) {
+	// the smallest grids are where a patch is most likely to die out, every seed must still make a maze
+	for _, rule := range []rules.LifeLike{rules.MazeRule, rules.MazectricRule} {
+		for _, size := range [][2]int64{{minSide, minSide}, {minSide, 12}, {12, minSide}} {
+			for seed := int64(1); seed <= 50; seed++ {
+				config := &pb.MazeConfig{Columns: size[0], Rows: size[1], Seed: seed}
+				g, err := maze.NewMaze(config, nil)
+				if err != nil {
+					t.Fatalf("invalid config: %v", err)
+				}
+
+				a := &Automaton{Rule: rule}
+				if err := a.Apply(g, 0, abool.NewBool(true)); err != nil {
+					t.Errorf("%v on %vx%v with seed %v: apply failed: %v", rule, size[0], size[1], seed, err)
+					continue
+				}
+				if err := a.CheckGrid(g); err != nil {
+					t.Errorf("%v on %vx%v with seed %v: grid is not valid: %v", rule, size[0], size[1], seed, err)
+				}
+			}
+		}
+	}
+}
+
+func TestJoinCorridors(t *testing.T) {
+	// two corridors, one wall pixel thick apart, and a pocket behind a thick wall
+	//   . # . # # .
+	//   . # . # # .
+	p := rules.NewPixels(6, 2)
+	for _, x := range []int{1, 3, 4} {
+		p[x][0], p[x][1] = true, true
+	}
+
+	pocket := joinCorridors(p, utils.NewRand(1))
+	if pocket[pixel{0, 0}] != pocket[pixel{2, 1}] {
+		t.Errorf("corridors at x=0 and x=2 are not joined")
+	}
+	if pocket[pixel{0, 0}] == pocket[pixel{5, 0}] {
+		t.Errorf("pocket behind the thick wall should not be joined")
+	}
+	if !p[3][0] || !p[3][1] || !p[4][0] || !p[4][1] {
+		t.Errorf("thick wall was knocked down")
+	}
+}
+
+func BenchmarkApply(b *testing.B) {
+	config := &pb.MazeConfig{
+		Rows:    10,
+		Columns: 10,
+	}
+
+	for i := 0; i < b.N; i++ {
+		g, err := maze.NewMaze(config, nil)
+		if err != nil {
+			b.Errorf("invalid config: %v", err)
+		}
+		a := &Automaton{Rule: rules.MazeRule}
+		a.Apply(g, 0, abool.NewBool(true))
+	}
+
+}
@@ -1,6 +1,7 @@
 // Package bintree implements the binary tree algorithm for maze generation
 
-// For each cell in the grid, you decide whether to carve a passage north or east.
+// For each cell in the grid, you decide whether to carve a passage north or east (or up, if there are levels).
+// Passages are never carved around the edges of grids that wrap.
 package bintree
 
 import (
@@ -10,7 +11,6 @@ import (
 	"github.com/tevino/abool"
 	"github.com/DanTulovsky/mazes/genalgos"
 	"github.com/DanTulovsky/mazes/maze"
-	"github.com/DanTulovsky/mazes/utils"
 )
 
 type Bintree struct {
@@ -21,6 +21,10 @@ type Bintree struct {
 func (a *Bintree) Apply(m *maze.Maze, delay time.Duration, generating *abool.AtomicBool) error {
 	defer genalgos.TimeTrack(m, time.Now())
 
+	if err := genalgos.CheckSquareGrid(m); err != nil {
+		return err
+	}
+
 	for _, currentCell := range m.OrderedCells() {
 		if !generating.IsSet() {
 			return fmt.Errorf("stop requested")
@@ -30,17 +34,20 @@ func (a *Bintree) Apply(m *maze.Maze, delay time.Duration, generating *abool.Ato
 		m.SetGenCurrentLocation(currentCell)
 
 		neighbors := []*maze.Cell{}
-		if currentCell.North() != nil {
-			neighbors = append(neighbors, currentCell.North())
+		if n := currentCell.NeighborNoWrap("north"); n != nil {
+			neighbors = append(neighbors, n)
+		}
+		if n := currentCell.NeighborNoWrap("east"); n != nil {
+			neighbors = append(neighbors, n)
 		}
-		if currentCell.East() != nil {
-			neighbors = append(neighbors, currentCell.East())
+		if currentCell.Up() != nil {
+			neighbors = append(neighbors, currentCell.Up())
 		}
 
 		if len(neighbors) == 0 {
 			continue
 		}
-		index := utils.Random(0, len(neighbors))
+		index := m.Rand().Random(0, len(neighbors))
 		neighbor := neighbors[index]
 		if neighbor != nil {
 			m.Link(currentCell, neighbor)
@@ -5,7 +5,7 @@ package ellers
 
 import (
 	"fmt"
-	"math/rand"
+	"sort"
 	"time"
 
 	"github.com/tevino/abool"
@@ -74,13 +74,23 @@ func (s *state) CellsInSet() map[int64][]*maze.Cell {
 	return s.cellsInSet
 }
 
+// Sets returns the sets in the row in order, so the maze only depends on the random numbers
+func (s *state) Sets() []int64 {
+	var sets []int64
+	for set := range s.cellsInSet {
+		sets = append(sets, set)
+	}
+	sort.Slice(sets, func(i, j int) bool { return sets[i] < sets[j] })
+	return sets
+}
+
 type Ellers struct {
 	genalgos.Common
 }
 
-func shuffleCells(cells []*maze.Cell) {
+func shuffleCells(r *utils.Rand, cells []*maze.Cell) {
 	for i := range cells {
-		j := rand.Intn(i + 1)
+		j := r.Random(0, i+1)
 		cells[i], cells[j] = cells[j], cells[i]
 	}
 }
@@ -90,6 +100,10 @@ func (a *Ellers) Apply(m *maze.Maze, delay time.Duration, generating *abool.Atom
 
 	defer genalgos.TimeTrack(m, time.Now())
 
+	if err := genalgos.CheckSquareGrid(m); err != nil {
+		return err
+	}
+
 	// initial state
 	s := newState(m, 0)
 
@@ -102,41 +116,49 @@ func (a *Ellers) Apply(m *maze.Maze, delay time.Duration, generating *abool.Atom
 		for _, c := range row {
 			time.Sleep(delay) // animation delay
 
-			if c.West() == nil {
+			if c.NeighborNoWrap("west") == nil {
 				continue
 			}
 			set := s.setFor(c)
-			prior_set := s.setFor(c.West())
+			prior_set := s.setFor(c.NeighborNoWrap("west"))
 
 			var shouldLink bool
 			// link if in different sets and if it's last row, or randomly
-			if set != prior_set && (c.North() == nil || utils.Random(0, 2) == 0) {
+			if set != prior_set && (c.NeighborNoWrap("north") == nil || m.Rand().Random(0, 2) == 0) {
 				shouldLink = true
 			}
 
 			if shouldLink {
-				m.Link(c, c.West())
+				m.Link(c, c.NeighborNoWrap("west"))
 				s.Merge(prior_set, set)
 			}
 		}
 
+		// last row of a level, connect the level to the one above and start over
+		if row[0].NeighborNoWrap("north") == nil && row[0].Up() != nil {
+			c := m.RandomCellFromList(row)
+			m.Link(c, c.Up())
+			s = s.Next()
+		}
+
 		// pick which cells to link north
-		if row[0].North() != nil {
+		if row[0].NeighborNoWrap("north") != nil {
 			// only do this if not the last row
 			nextRow := s.Next()
 
-			for _, cells := range s.CellsInSet() {
+			for _, set := range s.Sets() {
+				cells := s.CellsInSet()[set]
 				time.Sleep(delay) // animation delay
 
 				// shuffle list of cells
-				shuffleCells(cells)
+				shuffleCells(m.Rand(), cells)
 				for i, c := range cells {
 					// we require at least one cell to link north
 					// so pick index 0, the other cells have a 1/3 chances
 					// of being linked
-					if i == 0 || utils.Random(0, 3) == 0 {
-						m.Link(c, c.North())
-						nextRow.record(s.setFor(c), c.North())
+					if i == 0 || m.Rand().Random(0, 3) == 0 {
+						m.Link(c, c.NeighborNoWrap("north"))
+						nextRow.record(s.setFor(c), c.NeighborNoWrap("north"))
 					}
 				}
 			}
@@ -21,7 +21,7 @@ func (a *Empty) Apply(m *maze.Maze, delay time.Duration, generating *abool.Atomi
 
 	defer genalgos.TimeTrack(m, time.Now())
 
-	for currentCell := range m.Cells() {
+	for _, currentCell := range m.OrderedCells() {
 		if !generating.IsSet() {
 			return fmt.Errorf("stop requested")
 		}
@@ -15,9 +15,7 @@ import (
 
 	"github.com/tevino/abool"
 
-	"bufio"
 	pb "github.com/DanTulovsky/mazes/proto"
-	"os"
 )
 
 var (
@@ -28,24 +26,17 @@ type Fromfile struct {
 	genalgos.Common
 }
 
-// MazeSizeFromFile returns the number of columns and rows in the input file
-func MazeSizeFromFile(config *pb.MazeConfig) (c, r int, err error) {
+// MazeSizeFromFile returns the number of columns, rows and levels in the input file
+func MazeSizeFromFile(config *pb.MazeConfig) (c, r, l int, err error) {
 	filename := path.Join(*SavedMazePath, config.GetFromFile())
 
-	file, _ := os.Open(filename)
-	fileScanner := bufio.NewScanner(file)
-	lineCount := 0
-	colCount := 0
-
-	for fileScanner.Scan() {
-		if lineCount == 0 {
-			// grab row length
-			colCount = len(fileScanner.Text())
-		}
-		lineCount++
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return 0, 0, 0, err
 	}
 
-	return colCount, lineCount, nil
+	columns, rows, levels := maze.EncodedDimensions(config, string(data))
+	return int(columns), int(rows), int(levels), nil
 }
 
 // Apply reads in the provided file and sets up the passages
@@ -0,0 +1,144 @@
+// Package growing_tree implements the growing tree maze generation algorithm
+
+// The Growing Tree algorithm keeps a list of active cells. It picks a cell from the list, links it to
+// a random unvisited neighbor and adds the neighbor to the list; cells without unvisited neighbors are
+// removed. How the cell is picked sets the texture of the maze: always the newest cell makes it the
+// recursive backtracker (long corridors), a random cell makes it like Prim's (short branches), and a
+// weighted mix of the two falls in between.
+package growing_tree
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tevino/abool"
+	"github.com/DanTulovsky/mazes/genalgos"
+	"github.com/DanTulovsky/mazes/maze"
+	"github.com/DanTulovsky/mazes/utils"
+)
+
+// Cell selections, see MazeConfig.GrowingTree
+const (
+	SelectNewest = "newest"
+	SelectOldest = "oldest"
+	SelectRandom = "random"
+	SelectMiddle = "middle"
+)
+
+// weightedSelection is a selection picked weight times out of the total weight of a Selection
+type weightedSelection struct {
+	name   string
+	weight int
+}
+
+// Selection picks the cell to grow the tree from, out of the active cells
+type Selection []weightedSelection
+
+// ParseSelection parses "newest", "oldest", "random" or "middle", or a weighted mix of them
+// such as "newest:75,random:25"; empty is newest
+func ParseSelection(s string) (Selection, error) {
+	if strings.TrimSpace(s) == "" {
+		return Selection{{name: SelectNewest, weight: 1}}, nil
+	}
+
+	var selection Selection
+	for _, part := range strings.Split(s, ",") {
+		name, weight := strings.TrimSpace(part), 1
+		if i := strings.Index(name, ":"); i >= 0 {
+			w, err := strconv.Atoi(strings.TrimSpace(name[i+1:]))
+			if err != nil || w < 0 {
+				return nil, fmt.Errorf("invalid weight in growing tree selection %q", part)
+			}
+			name, weight = strings.TrimSpace(name[:i]), w
+		}
+
+		switch name {
+		case SelectNewest, SelectOldest, SelectRandom, SelectMiddle:
+		default:
+			return nil, fmt.Errorf("invalid growing tree selection %q, want newest, oldest, random or middle", name)
+		}
+		if weight > 0 {
+			selection = append(selection, weightedSelection{name: name, weight: weight})
+		}
+	}
+
+	if len(selection) == 0 {
+		return nil, fmt.Errorf("growing tree selection %q has no weight", s)
+	}
+	return selection, nil
+}
+
+// Index returns the index of the next cell out of n active cells, oldest first, drawing from r
+func (s Selection) Index(r *utils.Rand, n int) int {
+	total := 0
+	for _, ws := range s {
+		total += ws.weight
+	}
+
+	name := s[len(s)-1].name
+	pick := r.Random(0, total)
+	for _, ws := range s {
+		if pick < ws.weight {
+			name = ws.name
+			break
+		}
+		pick -= ws.weight
+	}
+
+	switch name {
+	case SelectOldest:
+		return 0
+	case SelectRandom:
+		return r.Random(0, n)
+	case SelectMiddle:
+		return n / 2
+	default:
+		return n - 1
+	}
+}
+
+type GrowingTree struct {
+	genalgos.Common
+}
+
+// Apply applies the growing tree algorithm to generate the maze, selecting cells as set by m.Config().GrowingTree
+func (a *GrowingTree) Apply(m *maze.Maze, delay time.Duration, generating *abool.AtomicBool) error {
+	defer genalgos.TimeTrack(m, time.Now())
+
+	selection, err := ParseSelection(m.Config().GetGrowingTree())
+	if err != nil {
+		return err
+	}
+
+	start := m.RandomCell()
+	start.SetVisited(maze.VisitedGenerator)
+	active := []*maze.Cell{start}
+
+	for len(active) > 0 {
+		if !generating.IsSet() {
+			return fmt.Errorf("stop requested")
+		}
+
+		time.Sleep(delay) // animation delay
+
+		i := selection.Index(m.Rand(), len(active))
+		currentCell := active[i]
+		m.SetGenCurrentLocation(currentCell)
+
+		randomNeighbor := genalgos.RandomUnvisitedCellFromList(m, currentCell.Neighbors())
+		if randomNeighbor == nil {
+			// no more unvisited neighbors, done with this cell
+			active = append(active[:i], active[i+1:]...)
+			continue
+		}
+
+		m.Link(currentCell, randomNeighbor)
+		randomNeighbor.SetVisited(maze.VisitedGenerator)
+		active = append(active, randomNeighbor)
+	}
+
+	a.Cleanup(m)
+	return nil
+}
@@ -0,0 +1,230 @@
+package growing_tree
+
+import (
+	"github.com/DanTulovsky/mazes/maze"
+	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/DanTulovsky/mazes/utils"
+	"github.com/tevino/abool"
+	"testing"
+)
+
+var applytests = []struct {
+	config  *pb.MazeConfig
+	wantErr bool
+}{
+	{
+		config: &pb.MazeConfig{
+			Rows:    utils.Random64(5, 40),
+			Columns: utils.Random64(5, 40),
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:        10,
+			Columns:     15,
+			GrowingTree: "random",
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:        10,
+			Columns:     15,
+			GrowingTree: "oldest",
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:        10,
+			Columns:     15,
+			GrowingTree: "newest:75,random:25",
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:        10,
+			Columns:     15,
+			GridType:    maze.GridHex,
+			GrowingTree: "middle",
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:     8,
+			GridType: maze.GridPolar,
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:     10,
+			Columns:  15,
+			GridType: maze.GridDelta,
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:    10,
+			Columns: 15,
+			Levels:  3,
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:    10,
+			Columns: 15,
+			Wrap:    maze.WrapBoth,
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:        10,
+			Columns:     15,
+			GrowingTree: "newest:75,shortest:25",
+		},
+		wantErr: true,
+	},
+}
+
+func setup() *GrowingTree {
+	return &GrowingTree{}
+}
+
+func TestApply(t *testing.T) {
+
+	for _, tt := range applytests {
+		g, err := maze.NewMaze(tt.config, nil)
+		a := setup()
+
+		if err != nil {
+			t.Errorf("invalid config: %v", err)
+			continue
+		}
+
+		if err := a.Apply(g, 0, abool.NewBool(true)); err != nil {
+			if !tt.wantErr {
+				t.Errorf("apply failed: %v", err)
+			}
+			continue // skip the rest of the tests
+		}
+		if tt.wantErr {
+			t.Errorf("apply should have failed with selection %q", tt.config.GrowingTree)
+		}
+
+		if err := a.CheckGrid(g); err != nil {
+			t.Errorf("grid is not valid: %v", err)
+		}
+	}
+}
+
+var selectiontests = []struct {
+	selection string
+	want      Selection
+	wantErr   bool
+}{
+	{
+		selection: "",
+		want:      Selection{{name: SelectNewest, weight: 1}},
+	}, {
+		selection: "random",
+		want:      Selection{{name: SelectRandom, weight: 1}},
+	}, {
+		selection: "newest:75, random:25",
+		want:      Selection{{name: SelectNewest, weight: 75}, {name: SelectRandom, weight: 25}},
+	}, {
+		selection: "oldest:0,middle:3",
+		want:      Selection{{name: SelectMiddle, weight: 3}},
+	}, {
+		selection: "newest:0",
+		wantErr:   true,
+	}, {
+		selection: "newest:-1",
+		wantErr:   true,
+	}, {
+		selection: "newest:a",
+		wantErr:   true,
+	}, {
+		selection: "shortest",
+		wantErr:   true,
+	},
+}
+
+func TestParseSelection(t *testing.T) {
+	for _, tt := range selectiontests {
+		got, err := ParseSelection(tt.selection)
+		if err != nil {
+			if !tt.wantErr {
+				t.Errorf("ParseSelection(%q) failed: %v", tt.selection, err)
+			}
+			continue
+		}
+		if tt.wantErr {
+			t.Errorf("ParseSelection(%q) should have failed", tt.selection)
+			continue
+		}
+
+		if len(got) != len(tt.want) {
+			t.Errorf("ParseSelection(%q) = %v; want %v", tt.selection, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("ParseSelection(%q) = %v; want %v", tt.selection, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestIndex(t *testing.T) {
+	for _, tt := range []struct {
+		selection string
+		n         int
+		want      int
+	}{
+		{selection: "newest", n: 5, want: 4},
+		{selection: "oldest", n: 5, want: 0},
+		{selection: "middle", n: 5, want: 2},
+		{selection: "random", n: 1, want: 0},
+	} {
+		s, err := ParseSelection(tt.selection)
+		if err != nil {
+			t.Fatalf("ParseSelection(%q) failed: %v", tt.selection, err)
+		}
+		if got := s.Index(utils.NewRand(1), tt.n); got != tt.want {
+			t.Errorf("%v.Index(%v) = %v; want %v", tt.selection, tt.n, got, tt.want)
+		}
+	}
+}
+
+// TestTexture checks that newest makes longer corridors (fewer dead ends) than random
+func TestTexture(t *testing.T) {
+	deadEnds := func(selection string) int {
+		g, err := maze.NewMaze(&pb.MazeConfig{Rows: 30, Columns: 30, GrowingTree: selection}, nil)
+		if err != nil {
+			t.Fatalf("invalid config: %v", err)
+		}
+		if err := setup().Apply(g, 0, abool.NewBool(true)); err != nil {
+			t.Fatalf("apply failed: %v", err)
+		}
+		return len(g.DeadEnds())
+	}
+
+	if newest, random := deadEnds("newest"), deadEnds("random"); newest >= random {
+		t.Errorf("newest has %v dead ends, random %v; want fewer", newest, random)
+	}
+}
+
+func BenchmarkApply(b *testing.B) {
+	config := &pb.MazeConfig{
+		Rows:    3,
+		Columns: 3,
+	}
+
+	for i := 0; i < b.N; i++ {
+		g, err := maze.NewMaze(config, nil)
+		if err != nil {
+			b.Errorf("invalid config: %v", err)
+		}
+		a := setup()
+		a.Apply(g, 0, abool.NewBool(true))
+	}
+
+}
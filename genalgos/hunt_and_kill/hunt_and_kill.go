@@ -4,12 +4,12 @@ package hunt_and_kill
 
 import (
 	"fmt"
-	"math/rand"
 	"time"
 
 	"github.com/tevino/abool"
 	"github.com/DanTulovsky/mazes/genalgos"
 	"github.com/DanTulovsky/mazes/maze"
+	"github.com/DanTulovsky/mazes/utils"
 )
 
 type HuntAndKill struct {
@@ -19,12 +19,12 @@ type HuntAndKill struct {
 // Hunt scans the grid from left to right and returns the first unvisited cell with at least one visited neighbor
 // Returns nil if there are no more
 func HuntAndLink(m *maze.Maze) *maze.Cell {
-	for cell := range m.Cells() {
+	for _, cell := range m.OrderedCells() {
 		if cell.Visited(maze.VisitedGenerator) {
 			continue
 		}
 		// shuffle the neighbors so we get a random one for linking
-		for _, n := range Shuffle(cell.Neighbors()) {
+		for _, n := range Shuffle(m.Rand(), cell.Neighbors()) {
 			if n.Visited(maze.VisitedGenerator) {
 				m.Link(cell, n) // link to random neighbor
 				return cell
@@ -34,9 +34,9 @@ func HuntAndLink(m *maze.Maze) *maze.Cell {
 	return nil
 }
 
-func Shuffle(cells []*maze.Cell) []*maze.Cell {
+func Shuffle(r *utils.Rand, cells []*maze.Cell) []*maze.Cell {
 	for i := range cells {
-		j := rand.Intn(i + 1)
+		j := r.Random(0, i+1)
 		cells[i], cells[j] = cells[j], cells[i]
 	}
 	return cells
@@ -60,7 +60,7 @@ func (a *HuntAndKill) Apply(m *maze.Maze, delay time.Duration, generating *abool
 		currentCell.SetVisited(maze.VisitedGenerator)
 		neighbors := currentCell.Neighbors()
 
-		randomNeighbor := genalgos.RandomUnvisitedCellFromList(neighbors)
+		randomNeighbor := genalgos.RandomUnvisitedCellFromList(m, neighbors)
 		if randomNeighbor == nil {
 			// no more unvisited neighbors
 			currentCell = HuntAndLink(m)
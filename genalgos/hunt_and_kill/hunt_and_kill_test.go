@@ -25,6 +25,34 @@ var applytests = []struct {
 			Columns: 15,
 		},
 		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:     10,
+			Columns:  15,
+			GridType: maze.GridHex,
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:     10,
+			Columns:  15,
+			GridType: maze.GridDelta,
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:    10,
+			Columns: 15,
+			Levels:  3,
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:    10,
+			Columns: 15,
+			Wrap:    maze.WrapBoth,
+		},
+		wantErr: false,
 	},
 }
 
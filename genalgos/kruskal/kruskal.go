@@ -8,7 +8,6 @@ import (
 	"github.com/tevino/abool"
 	"github.com/DanTulovsky/mazes/genalgos"
 	"github.com/DanTulovsky/mazes/maze"
-	"github.com/DanTulovsky/mazes/utils"
 )
 
 type state struct {
@@ -30,7 +29,7 @@ func newState(m *maze.Maze) *state {
 		cellsInSet: cellsInSet,
 	}
 
-	for c := range m.Cells() {
+	for _, c := range m.OrderedCells() {
 		set := len(setForCell)
 
 		// add cell into its own set
@@ -41,7 +40,7 @@ func newState(m *maze.Maze) *state {
 		// but this is required due to how drawing is implemented
 
 		// assign random cost to each pair, they pop out for the algorithm from lowest -> highest
-		randomCost := utils.Random(0, 100)
+		randomCost := m.Rand().Random(0, 100)
 		for _, n := range c.Neighbors() {
 			neighbors.Push(&neighborPair{left: c, right: n, cost: randomCost})
 		}
@@ -89,7 +88,7 @@ func (s *state) addCrossing(c *maze.Cell) bool {
 	s.neighbors.Delete(c)
 
 	// randomly pick the direction of passage
-	if utils.Random(0, 2) == 0 {
+	if s.maze.Rand().Random(0, 2) == 0 {
 		s.Merge(c, c.East())
 		s.Merge(c.West(), c)
 		s.Merge(c.North(), c.South())
@@ -111,15 +110,15 @@ func (a *Kruskal) Apply(m *maze.Maze, delay time.Duration, generating *abool.Ato
 	defer genalgos.TimeTrack(m, time.Now())
 
 	s := newState(m)
-	cells := maze.CellMapKeys(m.Cells())
+	cells := m.OrderedCells()
 
 	// add crossings (under-passages) as required
 	for x := int64(0); x < m.Size(); x++ {
-		if !m.Config().AllowWeaving || utils.Random(0, 100) >= int(m.Config().WeavingProbability*100) {
+		if !m.Config().AllowWeaving || m.Rand().Random(0, 100) >= int(m.Config().WeavingProbability*100) {
 			continue
 		}
 
-		cell := cells[utils.Random(0, len(cells))]
+		cell := cells[m.Rand().Random(0, len(cells))]
 		if cell.East() != nil && cell.West() != nil && cell.North() != nil && cell.South() != nil {
 			s.addCrossing(cell)
 		}
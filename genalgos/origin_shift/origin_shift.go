@@ -0,0 +1,152 @@
+// Package origin_shift implements the origin shift maze generation algorithm
+
+// The maze is kept as a directed spanning tree: every cell points to the next cell on its way to the root
+// (the origin). A step moves the root to a random neighbor: the old root points to the new one, and the
+// new root forgets where it pointed to. That adds the passage between the two, and removes the one the new
+// root used to point along, so the maze is a perfect maze after every step. Started from any perfect maze,
+// enough steps make a uniformly random one; the steps can also keep going on a maze that is being solved.
+package origin_shift
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tevino/abool"
+	"github.com/DanTulovsky/mazes/genalgos"
+	"github.com/DanTulovsky/mazes/maze"
+)
+
+// stepsPerCell is the number of steps per cell when MazeConfig.OriginShiftSteps is not set
+const stepsPerCell = 10
+
+// Tree is a perfect maze as a directed spanning tree, see Shift
+type Tree struct {
+	maze *maze.Maze
+	root *maze.Cell
+	next map[*maze.Cell]*maze.Cell // cell -> the next cell on the way to the root, the root has none
+}
+
+// NewTree returns the tree of the perfect maze m, with a random root
+// It returns an error if m is not a perfect maze (e.g. braided or woven).
+func NewTree(m *maze.Maze) (*Tree, error) {
+	if m.Config().GetAllowWeaving() {
+		return nil, fmt.Errorf("origin shift does not support weaving")
+	}
+
+	t := &Tree{maze: m, root: m.RandomCell(), next: make(map[*maze.Cell]*maze.Cell)}
+
+	// walk the passages breadth first, every cell points back the way it was found
+	found := map[*maze.Cell]bool{t.root: true}
+	queue := []*maze.Cell{t.root}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+
+		for _, l := range cell.Links() {
+			if l == t.next[cell] {
+				continue
+			}
+			if found[l] {
+				return nil, fmt.Errorf("maze is not a perfect maze, %v and %v are on a loop", cell, l)
+			}
+			found[l] = true
+			t.next[l] = cell
+			queue = append(queue, l)
+		}
+	}
+
+	if len(found) != len(m.Cells()) {
+		return nil, fmt.Errorf("maze is not a perfect maze, %v of %v cells are connected", len(found), len(m.Cells()))
+	}
+	return t, nil
+}
+
+// newGridTree links all cells of m, which has no passages, into a tree with a random root
+func newGridTree(m *maze.Maze) *Tree {
+	t := &Tree{maze: m, root: m.RandomCell(), next: make(map[*maze.Cell]*maze.Cell)}
+
+	// the neighbors, breadth first, point back the way they were found
+	found := map[*maze.Cell]bool{t.root: true}
+	queue := []*maze.Cell{t.root}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+
+		for _, n := range cell.Neighbors() {
+			if found[n] {
+				continue
+			}
+			found[n] = true
+			t.next[n] = cell
+			m.Link(n, cell)
+			queue = append(queue, n)
+		}
+	}
+	return t
+}
+
+// Root returns the root (origin) of the tree
+func (t *Tree) Root() *maze.Cell {
+	return t.root
+}
+
+// Shift moves the root to a random neighbor and returns it, and the cell the passage from it was removed
+// to; that is nil when the new root pointed to the old one, the maze is the same then
+func (t *Tree) Shift() (root, unlinked *maze.Cell) {
+	neighbors := t.root.Neighbors()
+	if len(neighbors) == 0 {
+		return t.root, nil
+	}
+	n := t.maze.RandomCellFromList(neighbors)
+
+	old := t.next[n]
+	t.next[t.root] = n
+	delete(t.next, n)
+
+	if old != t.root {
+		t.maze.Link(t.root, n)
+		if err := n.UnLink(old); err != nil {
+			// n is in the tree, it always has a next cell
+			panic(err)
+		}
+		unlinked = old
+	}
+
+	t.root = n
+	return t.root, unlinked
+}
+
+type OriginShift struct {
+	genalgos.Common
+}
+
+// Apply applies the origin shift algorithm to generate the maze: it links all cells into a tree and shifts
+// its root m.Config().OriginShiftSteps times
+func (a *OriginShift) Apply(m *maze.Maze, delay time.Duration, generating *abool.AtomicBool) error {
+	defer genalgos.TimeTrack(m, time.Now())
+
+	if m.Config().GetAllowWeaving() {
+		return fmt.Errorf("origin shift does not support weaving")
+	}
+
+	steps := m.Config().GetOriginShiftSteps()
+	if steps == 0 {
+		steps = int64(stepsPerCell * len(m.Cells()))
+	}
+
+	t := newGridTree(m)
+
+	for i := int64(0); i < steps; i++ {
+		if !generating.IsSet() {
+			return fmt.Errorf("stop requested")
+		}
+
+		time.Sleep(delay) // animation delay
+
+		root, _ := t.Shift()
+		m.SetGenCurrentLocation(root)
+	}
+
+	a.Cleanup(m)
+	return nil
+}
@@ -0,0 +1,177 @@
+package origin_shift
+
+import (
+	"github.com/DanTulovsky/mazes/maze"
+	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/DanTulovsky/mazes/utils"
+	"github.com/tevino/abool"
+	"testing"
+)
+
+var applytests = []struct {
+	config  *pb.MazeConfig
+	wantErr bool
+}{
+	{
+		config: &pb.MazeConfig{
+			Rows:    utils.Random64(5, 40),
+			Columns: utils.Random64(5, 40),
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:             10,
+			Columns:          15,
+			OriginShiftSteps: 1,
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:     10,
+			Columns:  15,
+			GridType: maze.GridHex,
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:     8,
+			GridType: maze.GridPolar,
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:     10,
+			Columns:  15,
+			GridType: maze.GridDelta,
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:    10,
+			Columns: 15,
+			Levels:  3,
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:    10,
+			Columns: 15,
+			Wrap:    maze.WrapBoth,
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:         10,
+			Columns:      15,
+			AllowWeaving: true,
+		},
+		wantErr: true,
+	},
+}
+
+func setup() *OriginShift {
+	return &OriginShift{}
+}
+
+func TestApply(t *testing.T) {
+
+	for _, tt := range applytests {
+		g, err := maze.NewMaze(tt.config, nil)
+		a := setup()
+
+		if err != nil {
+			t.Errorf("invalid config: %v", err)
+			continue
+		}
+
+		if err := a.Apply(g, 0, abool.NewBool(true)); err != nil {
+			if !tt.wantErr {
+				t.Errorf("apply failed: %v", err)
+			}
+			continue // skip the rest of the tests
+		}
+		if tt.wantErr {
+			t.Errorf("apply should have failed with config %v", tt.config)
+		}
+
+		if err := a.CheckGrid(g); err != nil {
+			t.Errorf("grid is not valid: %v", err)
+		}
+	}
+}
+
+// TestShift checks that the maze stays perfect while its root moves
+func TestShift(t *testing.T) {
+	g, err := maze.NewMaze(&pb.MazeConfig{Rows: 10, Columns: 10, CreateAlgo: "origin-shift"}, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	a := setup()
+	if err := a.Apply(g, 0, abool.NewBool(true)); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	tree, err := NewTree(g)
+	if err != nil {
+		t.Fatalf("NewTree failed: %v", err)
+	}
+
+	for i := 0; i < 500; i++ {
+		before := tree.Root()
+		root, unlinked := tree.Shift()
+
+		if !root.Linked(before) {
+			t.Fatalf("step %v: new root %v is not linked to the old root %v", i, root, before)
+		}
+		if unlinked != nil && root.Linked(unlinked) {
+			t.Fatalf("step %v: new root %v is still linked to %v", i, root, unlinked)
+		}
+		if tree.Root() != root {
+			t.Fatalf("step %v: root is %v, Shift returned %v", i, tree.Root(), root)
+		}
+		if i%50 == 0 {
+			if err := a.CheckGrid(g); err != nil {
+				t.Fatalf("step %v: grid is not valid: %v", i, err)
+			}
+		}
+	}
+}
+
+func TestNewTree(t *testing.T) {
+	g, err := maze.NewMaze(&pb.MazeConfig{Rows: 5, Columns: 5}, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	if _, err := NewTree(g); err == nil {
+		t.Errorf("NewTree should fail on a maze without passages")
+	}
+
+	if err := setup().Apply(g, 0, abool.NewBool(true)); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if _, err := NewTree(g); err != nil {
+		t.Errorf("NewTree failed on a perfect maze: %v", err)
+	}
+
+	g.Braid(1)
+	if _, err := NewTree(g); err == nil {
+		t.Errorf("NewTree should fail on a braided maze")
+	}
+}
+
+func BenchmarkApply(b *testing.B) {
+	config := &pb.MazeConfig{
+		Rows:    3,
+		Columns: 3,
+	}
+
+	for i := 0; i < b.N; i++ {
+		g, err := maze.NewMaze(config, nil)
+		if err != nil {
+			b.Errorf("invalid config: %v", err)
+		}
+		a := setup()
+		a.Apply(g, 0, abool.NewBool(true))
+	}
+
+}
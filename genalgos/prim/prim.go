@@ -10,7 +10,6 @@ import (
 	"github.com/tevino/abool"
 	"github.com/DanTulovsky/mazes/genalgos"
 	"github.com/DanTulovsky/mazes/maze"
-	"github.com/DanTulovsky/mazes/utils"
 )
 
 type Prim struct {
@@ -21,8 +20,8 @@ func (a *Prim) Apply(m *maze.Maze, delay time.Duration, generating *abool.Atomic
 	defer genalgos.TimeTrack(m, time.Now())
 
 	// Setup costs for all cells
-	for c := range m.Cells() {
-		w := utils.Random(0, int(m.Size())*100)
+	for _, c := range m.OrderedCells() {
+		w := m.Rand().Random(0, int(m.Size())*100)
 		c.SetWeight(w)
 	}
 
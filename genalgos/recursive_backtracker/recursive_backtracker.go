@@ -40,7 +40,7 @@ func (a *RecursiveBacktracker) Apply(m *maze.Maze, delay time.Duration, generati
 
 		neighbors := currentCell.Neighbors()
 
-		randomNeighbor := genalgos.RandomUnvisitedCellFromList(neighbors)
+		randomNeighbor := genalgos.RandomUnvisitedCellFromList(m, neighbors)
 
 		if randomNeighbor == nil {
 			// no more unvisited neighbors, go back
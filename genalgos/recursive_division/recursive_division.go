@@ -7,7 +7,6 @@ import (
 
 	"github.com/DanTulovsky/mazes/genalgos"
 	"github.com/DanTulovsky/mazes/maze"
-	"github.com/DanTulovsky/mazes/utils"
 
 	"github.com/tevino/abool"
 )
@@ -32,47 +31,68 @@ func initMaze(m *maze.Maze) {
 	}
 
 	for c := range m.Cells() {
-		for _, n := range c.Neighbors() {
-			// Does double the work by linking all cells twice
-			m.Link(c, n)
+		// passages around the edges of the grid are never divided, so they are left closed
+		for _, d := range c.Directions() {
+			if n := c.NeighborNoWrap(d); n != nil {
+				// Does double the work by linking all cells twice
+				m.Link(c, n)
+			}
 		}
 	}
 
 }
 
-func shouldStop(height, width int64) bool {
+// divideLevels puts a floor between each pair of levels, leaving a single passage open
+func divideLevels(m *maze.Maze) {
+	width, height := m.Dimensions()
+
+	for level := int64(0); level < m.Levels()-1; level++ {
+		passageAt := m.CellBeSure(int64(m.Rand().Random(0, int(width))), int64(m.Rand().Random(0, int(height))), level)
+
+		for x := int64(0); x < width; x++ {
+			for y := int64(0); y < height; y++ {
+				cell := m.CellBeSure(x, y, level)
+				if cell != passageAt && cell.Up() != nil {
+					cell.UnLink(cell.Up())
+				}
+			}
+		}
+	}
+}
+
+func shouldStop(m *maze.Maze, height, width int64) bool {
 	if height <= 1 || width <= 1 ||
 		height < MIN_ROOM_HEIGHT && width < MIN_ROOM_WIDTH &&
-			utils.Random(0, ROOM_SIZE_CHANCE_RATIO) == 0 {
+			m.Rand().Random(0, ROOM_SIZE_CHANCE_RATIO) == 0 {
 		return true
 	}
 	return false
 }
 
-func divide(m *maze.Maze, row, column, height, width int64, delay time.Duration, generating *abool.AtomicBool) error {
+func divide(m *maze.Maze, level, row, column, height, width int64, delay time.Duration, generating *abool.AtomicBool) error {
 
 	if !generating.IsSet() {
 		return fmt.Errorf("stop requested")
 	}
 
-	if shouldStop(height, width) {
+	if shouldStop(m, height, width) {
 		return nil
 	}
 
 	if height > width {
-		divideHorizontally(m, row, column, height, width, delay, generating)
+		divideHorizontally(m, level, row, column, height, width, delay, generating)
 	} else {
-		divideVertically(m, row, column, height, width, delay, generating)
+		divideVertically(m, level, row, column, height, width, delay, generating)
 	}
 
 	return nil
 }
 
-func divideHorizontally(m *maze.Maze, row, column, height, width int64,
+func divideHorizontally(m *maze.Maze, level, row, column, height, width int64,
 	delay time.Duration, generating *abool.AtomicBool) {
 
-	divideSouthOf := int64(utils.Random(0, int(height)-1))
-	passageAt := int64(utils.Random(0, int(width)))
+	divideSouthOf := int64(m.Rand().Random(0, int(height)-1))
+	passageAt := int64(m.Rand().Random(0, int(width)))
 
 	for x := int64(0); x < width; x++ {
 		time.Sleep(delay) // animation delay
@@ -81,8 +101,8 @@ func divideHorizontally(m *maze.Maze, row, column, height, width int64,
 			continue // keep this passage open
 		}
 
-		if cell, err := m.Cell(column+x, row+divideSouthOf, 0); err != nil {
-			log.Fatalf("failed to get cell at [%v, %v, %v]", column+x, row+divideSouthOf, 0)
+		if cell, err := m.Cell(column+x, row+divideSouthOf, level); err != nil {
+			log.Fatalf("failed to get cell at [%v, %v, %v]", column+x, row+divideSouthOf, level)
 		} else {
 			if cell.South() != nil {
 				cell.UnLink(cell.South())
@@ -90,14 +110,14 @@ func divideHorizontally(m *maze.Maze, row, column, height, width int64,
 		}
 	}
 
-	divide(m, row, column, divideSouthOf+1, width, delay, generating)
-	divide(m, row+divideSouthOf+1, column, height-divideSouthOf-1, width, delay, generating)
+	divide(m, level, row, column, divideSouthOf+1, width, delay, generating)
+	divide(m, level, row+divideSouthOf+1, column, height-divideSouthOf-1, width, delay, generating)
 }
 
-func divideVertically(m *maze.Maze, row, column, height, width int64, delay time.Duration, generating *abool.AtomicBool) {
+func divideVertically(m *maze.Maze, level, row, column, height, width int64, delay time.Duration, generating *abool.AtomicBool) {
 
-	divideEastOf := int64(utils.Random(0, int(width)-1))
-	passageAt := int64(utils.Random(0, int(height)))
+	divideEastOf := int64(m.Rand().Random(0, int(width)-1))
+	passageAt := int64(m.Rand().Random(0, int(height)))
 
 	for y := int64(0); y < height; y++ {
 		time.Sleep(delay) // animation delay
@@ -106,8 +126,8 @@ func divideVertically(m *maze.Maze, row, column, height, width int64, delay time
 			continue // keep this passage open
 		}
 
-		if cell, err := m.Cell(column+divideEastOf, row+y, 0); err != nil {
-			log.Fatalf("failed to get cell at [%v, %v, %v]", column+divideEastOf, row+y, 0)
+		if cell, err := m.Cell(column+divideEastOf, row+y, level); err != nil {
+			log.Fatalf("failed to get cell at [%v, %v, %v]", column+divideEastOf, row+y, level)
 		} else {
 			if cell.East() != nil {
 				cell.UnLink(cell.East())
@@ -115,8 +135,8 @@ func divideVertically(m *maze.Maze, row, column, height, width int64, delay time
 		}
 	}
 
-	divide(m, row, column, height, divideEastOf+1, delay, generating)
-	divide(m, row, column+divideEastOf+1, height, width-divideEastOf-1, delay, generating)
+	divide(m, level, row, column, height, divideEastOf+1, delay, generating)
+	divide(m, level, row, column+divideEastOf+1, height, width-divideEastOf-1, delay, generating)
 }
 
 // Apply applies the binary tree algorithm to generate the maze.
@@ -124,11 +144,18 @@ func (a *RecursiveDivision) Apply(m *maze.Maze, delay time.Duration, generating
 
 	defer genalgos.TimeTrack(m, time.Now())
 
+	if err := genalgos.CheckSquareGrid(m); err != nil {
+		return err
+	}
+
 	// links all cells together
 	initMaze(m)
 
 	width, height := m.Dimensions()
-	divide(m, 0, 0, height, width, delay, generating)
+	for level := int64(0); level < m.Levels(); level++ {
+		divide(m, level, 0, 0, height, width, delay, generating)
+	}
+	divideLevels(m)
 
 	a.Cleanup(m)
 	return nil
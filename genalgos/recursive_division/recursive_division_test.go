@@ -27,6 +27,22 @@ var applytests = []struct {
 			SkipGridCheck: true,
 		},
 		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:          10,
+			Columns:       15,
+			Levels:        3,
+			SkipGridCheck: true,
+		},
+		wantErr: false,
+	}, {
+		config: &pb.MazeConfig{
+			Rows:          10,
+			Columns:       15,
+			Wrap:          maze.WrapBoth,
+			SkipGridCheck: true,
+		},
+		wantErr: false,
 	},
 }
 
@@ -12,7 +12,6 @@ import (
 	"github.com/tevino/abool"
 	"github.com/DanTulovsky/mazes/genalgos"
 	"github.com/DanTulovsky/mazes/maze"
-	"github.com/DanTulovsky/mazes/utils"
 )
 
 type Sidewinder struct {
@@ -23,6 +22,10 @@ type Sidewinder struct {
 func (a *Sidewinder) Apply(m *maze.Maze, delay time.Duration, generating *abool.AtomicBool) error {
 	defer genalgos.TimeTrack(m, time.Now())
 
+	if err := genalgos.CheckSquareGrid(m); err != nil {
+		return err
+	}
+
 	gridWidth, _ := m.Dimensions()
 
 	for _, row := range m.Rows() {
@@ -41,22 +44,22 @@ func (a *Sidewinder) Apply(m *maze.Maze, delay time.Duration, generating *abool.
 			run = append(run, cell)
 
 			// 0 = north, 1 = east
-			rand := utils.Random(0, 2)
+			rand := m.Rand().Random(0, 2)
 
 			if rand == 1 {
 				// if possible, open passage east
-				if cell.East() != nil {
-					m.Link(cell, cell.East())
+				if cell.NeighborNoWrap("east") != nil {
+					m.Link(cell, cell.NeighborNoWrap("east"))
 					continue
-				} else if cell.North != nil {
+				} else if cell.NeighborNoWrap("north") != nil {
 					// close out run, we are at the far right wall
 					if x != 0 {
 						// something went wrong!
 						log.Fatalf("x=%v; expected x=%v (should be at far right)", x, len(row)-1)
 					}
 					c := m.RandomCellFromList(run)
-					if c.North() != nil {
-						m.Link(c, c.North())
+					if c.NeighborNoWrap("north") != nil {
+						m.Link(c, c.NeighborNoWrap("north"))
 					}
 					// clear out run
 					run = []*maze.Cell{} // not strictly necessary
@@ -68,6 +71,10 @@ func (a *Sidewinder) Apply(m *maze.Maze, delay time.Duration, generating *abool.
 				if l.X != gridWidth-1 || l.Y != 0 {
 					log.Fatalf("in cell %v, which is not top-right cell", cell)
 				}
+				// the top row of each level is connected to the level above
+				if c := m.RandomCellFromList(run); c.Up() != nil {
+					m.Link(c, c.Up())
+				}
 				run = []*maze.Cell{}
 				continue // should only happen at top right cell
 			}
@@ -75,12 +82,15 @@ func (a *Sidewinder) Apply(m *maze.Maze, delay time.Duration, generating *abool.
 			if rand == 0 {
 				// close out run, pick random cell
 				c := m.RandomCellFromList(run)
-				if c.North() != nil {
+				if c.NeighborNoWrap("north") != nil {
 					// open north passage
-					m.Link(c, c.North())
-				} else if cell.East() != nil {
+					m.Link(c, c.NeighborNoWrap("north"))
+				} else if cell.NeighborNoWrap("east") != nil {
 					// unless you can't, then open the east passage
-					m.Link(cell, cell.East())
+					m.Link(cell, cell.NeighborNoWrap("east"))
+				} else if c.Up() != nil {
+					// top-right cell, connect the level to the one above
+					m.Link(c, c.Up())
 				}
 				// clear out run
 				run = []*maze.Cell{}
@@ -8,11 +8,10 @@ import (
 
 	"github.com/DanTulovsky/mazes/colors"
 	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/DanTulovsky/mazes/render"
 	"github.com/DanTulovsky/mazes/utils"
 
 	"github.com/sasha-s/go-deadlock"
-	"github.com/veandco/go-sdl2/gfx"
-	"github.com/veandco/go-sdl2/sdl"
 )
 
 const (
@@ -25,6 +24,15 @@ type Cell struct {
 	x, y, z int64
 	// keep track of neighbors
 	north, south, east, west, below *Cell
+	// hex grid only
+	northeast, northwest, southeast, southwest *Cell
+	// polar grid only; outward neighbors are ordered clockwise
+	inward, cw, ccw *Cell
+	outward         []*Cell
+	// polar grid only; number of cells in the ring this cell is in
+	ringSize int64
+	// mazes with more than one level only
+	up, down *Cell
 	// keeps track of which cells this cell has a connection (no wall) to
 	links *safeMap2
 	// distances to other cells
@@ -44,8 +52,8 @@ type Cell struct {
 	// config
 	config *pb.MazeConfig
 
-	// keep track of what cells we have a path to for each client
-	pathNorth, pathSouth, pathEast, pathWest map[string]bool
+	// keep track of what directions we have a path to for each client (direction -> client -> bool)
+	pathDirections map[string]map[string]bool
 
 	// keep track of paths to specific cells
 	paths *safeMap2
@@ -62,6 +70,9 @@ type Cell struct {
 	// distance of this cell from the beginning
 	distance int
 
+	// the random numbers of the maze, see Maze.Rand
+	rand *utils.Rand
+
 	deadlock.RWMutex
 }
 
@@ -101,10 +112,8 @@ func NewCell(x, y, z int64, c *pb.MazeConfig) *Cell {
 		havePath:  make(map[string]map[*Cell]*Cell),
 		weight:    1,
 		visited:   make(map[string]int64),
-		pathNorth: make(map[string]bool),
-		pathSouth: make(map[string]bool),
-		pathEast:  make(map[string]bool),
-		pathWest:  make(map[string]bool),
+
+		pathDirections: make(map[string]map[string]bool),
 	}
 	cell.distances = NewDistances(cell)
 
@@ -112,37 +121,25 @@ func NewCell(x, y, z int64, c *pb.MazeConfig) *Cell {
 }
 
 // Encode encodes the cell (shape and cells/passages) to ascii
-// Each character is created by encoding the passages present in the cell into one of the 4 bits
+// Each character is created by encoding the passages present in the cell into one of the bits,
+// one bit per direction, in the order returned by encodedDirections(). For a square grid this is:
 // north, south, east, west
 // e.g. 0000 = no passages = 0
-// 1000 = passage north = 1
+// 1000 = passage north = 8
 // 1100 = passage north and south = C
+// Grids with more than 4 directions (hex) use more than one character per cell, triangles (delta) only use 3 bits.
 func (c *Cell) Encode() string {
 	var e int
 
-	switch 1 {
-	case 1:
-		if c.North() != nil && c.Linked(c.North()) {
-			e = utils.SetBit(e, 3)
-		}
-		fallthrough
-	case 2:
-		if c.South() != nil && c.Linked(c.South()) {
-			e = utils.SetBit(e, 2)
-		}
-		fallthrough
-	case 3:
-		if c.East() != nil && c.Linked(c.East()) {
-			e = utils.SetBit(e, 1)
-		}
-		fallthrough
-	case 4:
-		if c.West() != nil && c.Linked(c.West()) {
-			e = utils.SetBit(e, 0)
+	directions := c.encodedDirections()
+	for i, d := range directions {
+		n := c.Neighbor(d)
+		if n != nil && c.Linked(n) {
+			e = utils.SetBit(e, uint(len(directions)-1-i))
 		}
 	}
 
-	return fmt.Sprintf("%X", e)
+	return fmt.Sprintf("%0*X", gridEncodingWidth(c.config), e)
 }
 
 // Decode decodes the neighbors of a cell from the encoded string and sets them
@@ -153,32 +150,11 @@ func (c *Cell) Decode(e string) error {
 	}
 	enc := int(i)
 
-	switch 1 {
-	case 1:
-		if utils.HasBit(enc, 3) {
-			if err := c.Link(c.North()); err != nil {
-				return fmt.Errorf("encNorth: %b (%X); cell: %v, err: %v", enc, enc, c, err)
-			}
-		}
-		fallthrough
-	case 2:
-		if utils.HasBit(enc, 2) {
-			if err := c.Link(c.South()); err != nil {
-				return fmt.Errorf("encSouth: %b (%X); cell: %v, err: %v", enc, enc, c, err)
-			}
-		}
-		fallthrough
-	case 3:
-		if utils.HasBit(enc, 1) {
-			if err := c.Link(c.East()); err != nil {
-				return fmt.Errorf("encEast: %b (%X); cell: %v, err: %v", enc, enc, c, err)
-			}
-		}
-		fallthrough
-	case 4:
-		if utils.HasBit(enc, 0) {
-			if err := c.Link(c.West()); err != nil {
-				return fmt.Errorf("encWest: %b (%X); cell: %v, err: %v", enc, enc, c, err)
+	directions := c.encodedDirections()
+	for i, d := range directions {
+		if utils.HasBit(enc, uint(len(directions)-1-i)) {
+			if err := c.Link(c.Neighbor(d)); err != nil {
+				return fmt.Errorf("enc %v: %b (%X); cell: %v, err: %v", d, enc, enc, c, err)
 			}
 		}
 	}
@@ -256,22 +232,288 @@ func (c *Cell) West() *Cell {
 	return c.west
 }
 
-// HavePath returns true if there is a path to s (north, south, east, west)
-func (c *Cell) HavePath(client *client, s string) (have bool) {
+// SetNorthEast ...
+func (c *Cell) SetNorthEast(cell *Cell) {
+	c.Lock()
+	defer c.Unlock()
+	c.northeast = cell
+}
+
+// SetNorthWest ...
+func (c *Cell) SetNorthWest(cell *Cell) {
+	c.Lock()
+	defer c.Unlock()
+	c.northwest = cell
+}
+
+// SetSouthEast ...
+func (c *Cell) SetSouthEast(cell *Cell) {
+	c.Lock()
+	defer c.Unlock()
+	c.southeast = cell
+}
+
+// SetSouthWest ...
+func (c *Cell) SetSouthWest(cell *Cell) {
+	c.Lock()
+	defer c.Unlock()
+	c.southwest = cell
+}
+
+// NorthEast ...
+func (c *Cell) NorthEast() *Cell {
+	c.RLock()
+	defer c.RUnlock()
+	return c.northeast
+}
+
+// NorthWest ...
+func (c *Cell) NorthWest() *Cell {
+	c.RLock()
+	defer c.RUnlock()
+	return c.northwest
+}
+
+// SouthEast ...
+func (c *Cell) SouthEast() *Cell {
+	c.RLock()
+	defer c.RUnlock()
+	return c.southeast
+}
+
+// SouthWest ...
+func (c *Cell) SouthWest() *Cell {
+	c.RLock()
+	defer c.RUnlock()
+	return c.southwest
+}
+
+// Up ...
+func (c *Cell) Up() *Cell {
+	c.RLock()
+	defer c.RUnlock()
+	return c.up
+}
+
+// Down ...
+func (c *Cell) Down() *Cell {
+	c.RLock()
+	defer c.RUnlock()
+	return c.down
+}
+
+// SetUp ...
+func (c *Cell) SetUp(cell *Cell) {
+	c.Lock()
+	defer c.Unlock()
+	c.up = cell
+}
+
+// SetDown ...
+func (c *Cell) SetDown(cell *Cell) {
+	c.Lock()
+	defer c.Unlock()
+	c.down = cell
+}
+
+// Inward ...
+func (c *Cell) Inward() *Cell {
+	c.RLock()
+	defer c.RUnlock()
+	return c.inward
+}
+
+// CW ...
+func (c *Cell) CW() *Cell {
+	c.RLock()
+	defer c.RUnlock()
+	return c.cw
+}
+
+// CCW ...
+func (c *Cell) CCW() *Cell {
+	c.RLock()
+	defer c.RUnlock()
+	return c.ccw
+}
+
+// Outward returns the outward neighbors of the cell, in clockwise order
+func (c *Cell) Outward() []*Cell {
+	c.RLock()
+	defer c.RUnlock()
+	return c.outward
+}
+
+// SetInward ...
+func (c *Cell) SetInward(cell *Cell) {
+	c.Lock()
+	defer c.Unlock()
+	c.inward = cell
+}
+
+// SetCW ...
+func (c *Cell) SetCW(cell *Cell) {
+	c.Lock()
+	defer c.Unlock()
+	c.cw = cell
+}
+
+// SetCCW ...
+func (c *Cell) SetCCW(cell *Cell) {
+	c.Lock()
+	defer c.Unlock()
+	c.ccw = cell
+}
+
+// AddOutward adds cell as the next (clockwise) outward neighbor
+func (c *Cell) AddOutward(cell *Cell) {
+	c.Lock()
+	defer c.Unlock()
+	c.outward = append(c.outward, cell)
+}
+
+// Directions returns the names of all possible directions out of this cell, based on the grid type
+// On a polar grid, a cell with more than one outward neighbor has directions outward-0, outward-1, ...
+// (clockwise), otherwise the single outward neighbor is just outward.
+func (c *Cell) Directions() []string {
+	c.RLock()
+	defer c.RUnlock()
+	return c.directions()
+}
+
+// directions returns the names of all possible directions out of this cell, caller must hold the lock
+func (c *Cell) directions() []string {
+	switch gridType(c.config) {
+	case GridHex:
+		return hexDirections
+	case GridPolar:
+		directions := append([]string{}, polarDirections...)
+		return append(directions, outwardDirections(len(c.outward))...)
+	case GridDelta:
+		if c.upright() {
+			return deltaUpDirections
+		}
+		return deltaDownDirections
+	default:
+		if levels(c.config) > 1 {
+			return levelDirections
+		}
+		return squareDirections
+	}
+}
+
+// encodedDirections returns the directions encoded for this cell, in order
+func (c *Cell) encodedDirections() []string {
+	if gridType(c.config) == GridPolar {
+		// outward passages are not encoded, they are the inward passages of the next ring
+		return polarDirections
+	}
+	return c.Directions()
+}
+
+// Neighbor returns the neighbor in direction d (nil if there isn't one)
+func (c *Cell) Neighbor(d string) *Cell {
 	c.RLock()
 	defer c.RUnlock()
+	return c.neighbor(d)
+}
 
-	switch s {
+// neighbor returns the neighbor in direction d, caller must hold the lock
+func (c *Cell) neighbor(d string) *Cell {
+	switch d {
 	case "north":
-		have = c.pathNorth[client.id]
+		return c.north
 	case "south":
-		have = c.pathSouth[client.id]
+		return c.south
 	case "east":
-		have = c.pathEast[client.id]
+		return c.east
 	case "west":
-		have = c.pathWest[client.id]
+		return c.west
+	case "northeast":
+		return c.northeast
+	case "northwest":
+		return c.northwest
+	case "southeast":
+		return c.southeast
+	case "southwest":
+		return c.southwest
+	case "inward":
+		return c.inward
+	case "cw":
+		return c.cw
+	case "ccw":
+		return c.ccw
+	case "up":
+		return c.up
+	case "down":
+		return c.down
+	}
+
+	if i, ok := outwardIndex(d, len(c.outward)); ok {
+		return c.outward[i]
 	}
-	return have
+	return nil
+}
+
+// SetNeighbor sets the neighbor in direction d
+func (c *Cell) SetNeighbor(d string, cell *Cell) {
+	switch d {
+	case "north":
+		c.SetNorth(cell)
+	case "south":
+		c.SetSouth(cell)
+	case "east":
+		c.SetEast(cell)
+	case "west":
+		c.SetWest(cell)
+	case "northeast":
+		c.SetNorthEast(cell)
+	case "northwest":
+		c.SetNorthWest(cell)
+	case "southeast":
+		c.SetSouthEast(cell)
+	case "southwest":
+		c.SetSouthWest(cell)
+	case "inward":
+		c.SetInward(cell)
+	case "cw":
+		c.SetCW(cell)
+	case "ccw":
+		c.SetCCW(cell)
+	case "up":
+		c.SetUp(cell)
+	case "down":
+		c.SetDown(cell)
+	}
+}
+
+// forgetNeighbor removes cell from the neighbors of c
+func (c *Cell) forgetNeighbor(cell *Cell) {
+	c.Lock()
+	defer c.Unlock()
+
+	for _, n := range []**Cell{&c.north, &c.south, &c.east, &c.west,
+		&c.northeast, &c.northwest, &c.southeast, &c.southwest, &c.inward, &c.cw, &c.ccw, &c.up, &c.down} {
+		if *n == cell {
+			*n = nil
+		}
+	}
+
+	var outward []*Cell
+	for _, n := range c.outward {
+		if n != cell {
+			outward = append(outward, n)
+		}
+	}
+	c.outward = outward
+}
+
+// HavePath returns true if there is a path to s (north, south, east, west, ...)
+func (c *Cell) HavePath(client *client, s string) (have bool) {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.pathDirections[s][client.id]
 }
 
 // SetHavePath ...
@@ -279,16 +521,10 @@ func (c *Cell) SetHavePath(client *client, s string) {
 	c.Lock()
 	defer c.Unlock()
 
-	switch s {
-	case "north":
-		c.pathNorth[client.id] = true
-	case "south":
-		c.pathSouth[client.id] = true
-	case "east":
-		c.pathEast[client.id] = true
-	case "west":
-		c.pathWest[client.id] = true
+	if _, ok := c.pathDirections[s]; !ok {
+		c.pathDirections[s] = make(map[string]bool)
 	}
+	c.pathDirections[s][client.id] = true
 }
 
 // Weight returns the weight of the cell
@@ -399,6 +635,31 @@ func (c *Cell) SetVisited(client string) {
 	c.visited[client]++
 }
 
+// visits returns a copy of the number of times each client visited the cell
+func (c *Cell) visits() map[string]int64 {
+	c.RLock()
+	defer c.RUnlock()
+
+	if len(c.visited) == 0 {
+		return nil
+	}
+	visits := make(map[string]int64, len(c.visited))
+	for client, t := range c.visited {
+		visits[client] = t
+	}
+	return visits
+}
+
+// setVisits sets the number of times each client visited the cell
+func (c *Cell) setVisits(visits map[string]int64) {
+	c.Lock()
+	defer c.Unlock()
+
+	for client, t := range visits {
+		c.visited[client] = t
+	}
+}
+
 // SetUnVisited marks the cell as unvisited
 func (c *Cell) SetUnVisited(client string) {
 	c.Lock()
@@ -424,17 +685,10 @@ func (c *Cell) SetPaths(client *client, previous, next *Cell) {
 			return
 		}
 	}
-	if c.North() == previous || c.North() == next {
-		c.SetHavePath(client, "north")
-	}
-	if c.South() == previous || c.South() == next {
-		c.SetHavePath(client, "south")
-	}
-	if c.East() == previous || c.East() == next {
-		c.SetHavePath(client, "east")
-	}
-	if c.West() == previous || c.West() == next {
-		c.SetHavePath(client, "west")
+	for _, d := range c.Directions() {
+		if n := c.Neighbor(d); n != nil && (n == previous || n == next) {
+			c.SetHavePath(client, d)
+		}
 	}
 
 	c.havePath[client.id][previous] = next
@@ -454,6 +708,14 @@ func (c *Cell) Distances() *Distances {
 		return c.distances
 	}
 
+	c.distances = c.newDistances()
+	return c.distances
+}
+
+// newDistances works out the distances of all cells to this cell, without the cached ones (see Distances)
+func (c *Cell) newDistances() *Distances {
+	distances := NewDistances(c)
+
 	pending := make(CellPriorityQueue, 0)
 	heap.Init(&pending)
 	heap.Push(&pending, c)
@@ -462,24 +724,24 @@ func (c *Cell) Distances() *Distances {
 		cell := heap.Pop(&pending).(*Cell)
 
 		for _, l := range cell.Links() {
-			d, err := c.distances.Get(cell)
+			d, err := distances.Get(cell)
 			if err != nil {
 				log.Fatalf("error getting distance from [%v]->[%v]: %v", c, l, err)
 			}
 
 			totalWeight := d + l.weight // never changes once set
 
-			prevDistance, err := c.distances.Get(l)
+			prevDistance, err := distances.Get(l)
 
 			if totalWeight < prevDistance || err != nil {
 				heap.Push(&pending, l)
 				// sets distance to new cell
-				c.distances.Set(l, totalWeight)
+				distances.Set(l, totalWeight)
 			}
 		}
 
 	}
-	return c.distances
+	return distances
 }
 
 // BGColor returns the cell's background color
@@ -499,22 +761,36 @@ func (c *Cell) SetBGColor(color colors.Color) {
 }
 
 // Draw draws one cell on renderer.
-func (c *Cell) Draw(r *sdl.Renderer) *sdl.Renderer {
+func (c *Cell) Draw(r render.Renderer) render.Renderer {
+	return c.drawBG(r, c.BGColor())
+}
+
+// drawBG draws one cell on renderer, with bg as its background color
+func (c *Cell) drawBG(r render.Renderer, bg colors.Color) render.Renderer {
 	// defer utils.TimeTrack(time.Now(), "CellDraw")
+	switch gridType(c.config) {
+	case GridHex:
+		return c.drawHex(r, bg)
+	case GridPolar:
+		return c.drawPolar(r, bg)
+	case GridDelta:
+		return c.drawDelta(r, bg)
+	}
+
 	wallSpace := c.config.WallSpace / 2
 
 	// Fill in background color
-	colors.SetDrawColor(c.BGColor(), r)
+	r.SetDrawColor(bg)
 
 	var x, y, w, h int64
 
 	if c.z >= 0 { // don't color below cells
-		x = c.x*c.width + c.wallWidth + wallSpace + c.wallWidth/2
+		x = c.left() + c.wallWidth + wallSpace + c.wallWidth/2
 		y = c.y*c.width + c.wallWidth + wallSpace + c.wallWidth/2
 		w = c.width - wallSpace*2 - c.wallWidth/2 - c.wallWidth/2
 		h = c.width - wallSpace*2 - c.wallWidth/2 - c.wallWidth/2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
 	}
 	linkEast, linkWest, linkSouth, linkNorth := c.Linked(c.East()), c.Linked(c.West()), c.Linked(c.South()), c.Linked(c.North())
 
@@ -523,119 +799,119 @@ func (c *Cell) Draw(r *sdl.Renderer) *sdl.Renderer {
 	// draw stubs
 	if linkNorth {
 		// background
-		colors.SetDrawColor(c.BGColor(), r)
-		x = c.x*c.width + c.wallWidth + wallSpace + c.wallWidth/2
+		r.SetDrawColor(bg)
+		x = c.left() + c.wallWidth + wallSpace + c.wallWidth/2
 		y = c.y*c.width + c.wallWidth
 		w = c.width - wallSpace*2 - c.wallWidth
 		h = wallSpace + c.wallWidth/2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
 
-		colors.SetDrawColor(c.wallColor, r)
-		// colors.SetDrawColor(colors.GetColor("red"), r)
+		r.SetDrawColor(c.wallColor)
+		// r.SetDrawColor(colors.GetColor("red"))
 
 		// east
-		x = c.x*c.width + c.width - wallSpace + c.wallWidth/2
+		x = c.left() + c.width - wallSpace + c.wallWidth/2
 		y = c.y*c.width + c.wallWidth
 		w = c.wallWidth / 2
 		h = wallSpace + c.wallWidth/2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
 
 		// west
-		x = c.x*c.width + c.wallWidth + wallSpace
+		x = c.left() + c.wallWidth + wallSpace
 		y = c.y*c.width + c.wallWidth
 		w = c.wallWidth / 2
 		h = wallSpace + c.wallWidth/2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
 	}
 
 	if linkSouth {
 		// background
-		colors.SetDrawColor(c.BGColor(), r)
-		x = c.x*c.width + c.wallWidth + wallSpace + c.wallWidth/2
+		r.SetDrawColor(bg)
+		x = c.left() + c.wallWidth + wallSpace + c.wallWidth/2
 		y = c.y*c.width + c.width - wallSpace + c.wallWidth/2
 		w = c.width - wallSpace*2 - c.wallWidth
 		h = wallSpace + c.wallWidth/2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
 
-		colors.SetDrawColor(c.wallColor, r)
+		r.SetDrawColor(c.wallColor)
 		// east
-		x = c.x*c.width + c.width - c.wallWidth/2 + c.wallWidth - wallSpace
+		x = c.left() + c.width - c.wallWidth/2 + c.wallWidth - wallSpace
 		y = c.y*c.width + c.width - wallSpace + c.wallWidth/2
 		w = c.wallWidth / 2
 		h = wallSpace + c.wallWidth/2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
 
 		// west
-		x = c.x*c.width + c.wallWidth + wallSpace
+		x = c.left() + c.wallWidth + wallSpace
 		y = c.y*c.width + wallSpace + c.width + c.wallWidth/2 - wallSpace*2
 		w = c.wallWidth / 2
 		h = wallSpace + c.wallWidth/2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
 	}
 
 	if linkEast {
 		// background
-		colors.SetDrawColor(c.BGColor(), r)
-		// colors.SetDrawColor(colors.GetColor("blue"), r)
-		x = c.x*c.width + c.wallWidth/2 + wallSpace + c.width - wallSpace*2
+		r.SetDrawColor(bg)
+		// r.SetDrawColor(colors.GetColor("blue"))
+		x = c.left() + c.wallWidth/2 + wallSpace + c.width - wallSpace*2
 		y = c.y*c.width + c.wallWidth + wallSpace
 		w = wallSpace + c.wallWidth/2
 		h = c.width - wallSpace*2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
 
-		colors.SetDrawColor(c.wallColor, r)
+		r.SetDrawColor(c.wallColor)
 
 		// north
-		x = c.x*c.width + c.width - c.wallWidth/2 + c.wallWidth - wallSpace
+		x = c.left() + c.width - c.wallWidth/2 + c.wallWidth - wallSpace
 		y = c.y*c.width + c.wallWidth + wallSpace
 		w = wallSpace + c.wallWidth/2
 		h = c.wallWidth / 2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
 
 		// south
-		x = c.x*c.width + c.width - c.wallWidth/2 + c.wallWidth - wallSpace
+		x = c.left() + c.width - c.wallWidth/2 + c.wallWidth - wallSpace
 		y = c.y*c.width + c.width - c.wallWidth/2 + c.wallWidth - wallSpace
 		w = wallSpace + c.wallWidth/2
 		h = c.wallWidth / 2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
 	}
 
 	if linkWest {
 		// background
-		colors.SetDrawColor(c.BGColor(), r)
-		x = c.x*c.width + c.wallWidth
+		r.SetDrawColor(bg)
+		x = c.left() + c.wallWidth
 		y = c.y*c.width + c.wallWidth + wallSpace
 		w = wallSpace + c.wallWidth/2
 		h = c.width - wallSpace*2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
 
-		colors.SetDrawColor(c.wallColor, r)
+		r.SetDrawColor(c.wallColor)
 
 		// north
-		x = c.x*c.width + c.wallWidth
+		x = c.left() + c.wallWidth
 		y = c.y*c.width + c.wallWidth + wallSpace
 		w = wallSpace + c.wallWidth/2
 		h = c.wallWidth / 2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
 
 		// south
-		x = c.x*c.width + c.wallWidth
+		x = c.left() + c.wallWidth
 		y = c.y*c.width + c.width - c.wallWidth/2 + c.wallWidth - wallSpace
 		w = wallSpace + c.wallWidth/2
 		h = c.wallWidth / 2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
 	}
 
 	// Don't draw anything below here for cells below other cells
@@ -644,107 +920,116 @@ func (c *Cell) Draw(r *sdl.Renderer) *sdl.Renderer {
 	}
 
 	// walls
-	colors.SetDrawColor(c.wallColor, r)
+	r.SetDrawColor(c.wallColor)
 
 	// East
 	if !linkEast {
-		x = c.x*c.width + c.width - c.wallWidth/2 + c.wallWidth - wallSpace
+		x = c.left() + c.width - c.wallWidth/2 + c.wallWidth - wallSpace
 		y = c.y*c.width + c.wallWidth + wallSpace
 		w = c.wallWidth / 2
 		h = c.width - wallSpace*2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
 	}
 
 	// West
 	if !linkWest {
-		x = c.x*c.width + c.wallWidth + wallSpace
+		x = c.left() + c.wallWidth + wallSpace
 		y = c.y*c.width + c.wallWidth + wallSpace
 		w = c.wallWidth / 2
 		h = c.width - wallSpace*2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
 	}
 
 	// North
 	if !linkNorth {
-		x = c.x*c.width + c.wallWidth + wallSpace
+		x = c.left() + c.wallWidth + wallSpace
 		y = c.y*c.width + c.wallWidth + wallSpace
 		w = c.width - wallSpace*2
 		h = c.wallWidth / 2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
 	}
 
 	// South
 	if !linkSouth {
-		x = c.x*c.width + c.wallWidth + wallSpace
+		x = c.left() + c.wallWidth + wallSpace
 		y = c.y*c.width + c.width - c.wallWidth/2 + c.wallWidth - wallSpace
 		w = c.width - wallSpace*2
 		h = c.wallWidth / 2
 
-		r.FillRect(&sdl.Rect{int32(x), int32(y), int32(w), int32(h)})
+		r.FillRect(&render.Rect{int32(x), int32(y), int32(w), int32(h)})
+	}
+
+	if levels(c.config) > 1 {
+		c.drawStairs(r)
 	}
+	if c.config.GetWrap() != WrapNone {
+		c.drawWraps(r)
+	}
+
+	c.drawValues(r, c.left()+c.wallWidth+1+wallSpace, c.y*c.width+c.wallWidth+1+wallSpace)
 
+	return r
+}
+
+// drawValues displays the distance and weight values (if enabled) at x, y
+func (c *Cell) drawValues(r render.Renderer, x, y int64) {
 	// Display distance value
 	if c.config.GetShowDistanceValues() {
-		x := c.x*c.width + c.wallWidth + 1 + wallSpace
-		y := c.y*c.width + c.wallWidth + 1 + wallSpace
-
-		if e := gfx.StringRGBA(r, int32(x), int32(y), fmt.Sprintf("%v", c.Distance()), 0, 0, 0, 255); e != true {
-			log.Printf("error: %v", sdl.GetError())
+		if err := r.String(int32(x), int32(y), fmt.Sprintf("%v", c.Distance()), colors.GetColor("black")); err != nil {
+			log.Printf("error: %v", err)
 		}
-		gfx.SetFont(nil, 0, 0)
 	}
 
 	if c.config.GetShowWeightValues() {
-		x := c.x*c.width + c.wallWidth + 1 + wallSpace
-		y := c.y*c.width + c.wallWidth + 1 + wallSpace
-
-		if e := gfx.StringRGBA(r, int32(x), int32(y), fmt.Sprintf("%v", c.Weight()), 0, 0, 0, 255); e != true {
-			log.Printf("error: %v", sdl.GetError())
+		if err := r.String(int32(x), int32(y), fmt.Sprintf("%v", c.Weight()), colors.GetColor("black")); err != nil {
+			log.Printf("error: %v", err)
 		}
-		gfx.SetFont(nil, 0, 0)
 	}
-
-	return r
 }
 
 // DrawCurrentLocation marks the current location of the user
-func (c *Cell) DrawCurrentLocation(r *sdl.Renderer, client *client, avatar *sdl.Texture, facing string) {
+func (c *Cell) DrawCurrentLocation(r render.Renderer, client *client, avatar render.Texture, facing string) {
 
 	PixelsPerCell := c.width
 
 	// rotateAngle returns the angle of rotation based on facing direction
 	// the texture used for the avatar is assumed to be "facing" "west"
-	rotateAngle := func(f string) (angle float64, flip sdl.RendererFlip) {
+	rotateAngle := func(f string) (angle float64, flip render.Flip) {
 
 		switch f {
 		case "north":
 			angle = 90
-			flip = sdl.FLIP_NONE
+			flip = render.FlipNone
 
 		case "east":
 			angle = 180
-			flip = sdl.FLIP_VERTICAL
+			flip = render.FlipVertical
 
 		case "south":
 			angle = -90
-			flip = sdl.FLIP_NONE
+			flip = render.FlipNone
 
 		case "west":
 			angle = 0
-			flip = sdl.FLIP_NONE
+			flip = render.FlipNone
 		}
 
 		return angle, flip
 	}
 
+	if gridType(c.config) != GridSquare {
+		c.drawCenteredLocation(r, client)
+		return
+	}
+
 	if avatar == nil {
-		colors.SetDrawColor(colors.GetColor(client.config.CurrentLocationColor), r)
+		r.SetDrawColor(colors.GetColor(client.config.CurrentLocationColor))
 		// draw a standard box
-		sq := &sdl.Rect{
-			int32(c.x*PixelsPerCell + PixelsPerCell/4),
+		sq := &render.Rect{
+			int32(c.left() + PixelsPerCell/4),
 			int32(c.y*PixelsPerCell + PixelsPerCell/4),
 			int32(PixelsPerCell/2 - c.wallWidth/2),
 			int32(PixelsPerCell/2 - c.wallWidth/2)}
@@ -752,27 +1037,30 @@ func (c *Cell) DrawCurrentLocation(r *sdl.Renderer, client *client, avatar *sdl.
 	} else {
 		angle, flip := rotateAngle(facing)
 
-		sq := &sdl.Rect{
-			int32(c.x*PixelsPerCell + PixelsPerCell/4),
+		sq := &render.Rect{
+			int32(c.left() + PixelsPerCell/4),
 			int32(c.y*PixelsPerCell + PixelsPerCell/4),
 			int32(c.pathWidth * 15),
 			int32(c.pathWidth * 15)}
 
-		r.CopyEx(avatar, nil, sq, angle, nil, flip)
+		r.CopyEx(avatar, sq, angle, flip)
 	}
 }
 
 // DrawVisited draws the visited marker.
-func (c *Cell) DrawVisited(r *sdl.Renderer, client *client) {
+func (c *Cell) DrawVisited(r render.Renderer, client *client) {
 	if client.config.NumberMarkVisitedCells {
 		wallSpace := c.config.WallSpace / 2
-		x := c.x*c.width + c.wallWidth + 1 + wallSpace
+		x := c.left() + c.wallWidth + 1 + wallSpace
 		y := c.y*c.width + c.wallWidth + 1 + wallSpace
+		if gridType(c.config) != GridSquare {
+			cx, cy := c.center()
+			x, y = int64(cx)-c.width/4, int64(cy)
+		}
 
-		if e := gfx.StringRGBA(r, int32(x), int32(y), fmt.Sprint(c.VisitedTimes(client.id)), 0, 0, 0, 255); e != true {
-			log.Printf("error: %v", sdl.GetError())
+		if err := r.String(int32(x), int32(y), fmt.Sprint(c.VisitedTimes(client.id)), colors.GetColor("black")); err != nil {
+			log.Printf("error: %v", err)
 		}
-		gfx.SetFont(nil, 0, 0)
 	}
 
 	if client.config.MarkVisitedCells {
@@ -780,7 +1068,7 @@ func (c *Cell) DrawVisited(r *sdl.Renderer, client *client) {
 
 		// don't mark cells under other cell
 		if client.config.MarkVisitedCells && c.Visited(client.id) && c.z >= 0 {
-			colors.SetDrawColor(colors.GetColor(client.config.VisitedCellColor), r)
+			r.SetDrawColor(colors.GetColor(client.config.VisitedCellColor))
 
 			times := c.VisitedTimes(client.id)
 			factor := times * 3
@@ -796,7 +1084,11 @@ func (c *Cell) DrawVisited(r *sdl.Renderer, client *client) {
 			}
 
 			// draw a small box to mark visited cells
-			box := &sdl.Rect{int32(c.x*PixelsPerCell+c.wallWidth) + offset, int32(c.y*PixelsPerCell+c.wallWidth) + offset, h, w}
+			box := &render.Rect{int32(c.left()+c.wallWidth) + offset, int32(c.y*PixelsPerCell+c.wallWidth) + offset, h, w}
+			if gridType(c.config) != GridSquare {
+				cx, cy := c.center()
+				box = &render.Rect{int32(cx) - w/2, int32(cy) - h/2, h, w}
+			}
 			r.FillRect(box)
 		}
 	}
@@ -853,20 +1145,15 @@ func (c *Cell) Links() []*Cell {
 	return keys
 }
 
-// DirectionTo returns the direction (north, south, east, west) of cell from c
+// DirectionTo returns the direction (north, south, east, west, ...) of cell from c
 // c and cell must be linked
 // TODO(dan): raise appropriate error if cells are not linked
 func (c *Cell) DirectionTo(cell *Cell, client string) (*pb.Direction, error) {
 
-	switch {
-	case c.North() == cell:
-		return &pb.Direction{Name: "north", Visited: cell.Visited(client)}, nil
-	case c.South() == cell:
-		return &pb.Direction{Name: "south", Visited: cell.Visited(client)}, nil
-	case c.East() == cell:
-		return &pb.Direction{Name: "east", Visited: cell.Visited(client)}, nil
-	case c.West() == cell:
-		return &pb.Direction{Name: "west", Visited: cell.Visited(client)}, nil
+	for _, d := range c.Directions() {
+		if c.Neighbor(d) == cell {
+			return &pb.Direction{Name: d, Visited: cell.Visited(client)}, nil
+		}
 	}
 
 	return &pb.Direction{Name: "", Visited: false}, fmt.Errorf("error: cell [%v] not linked to [%v]", c, cell)
@@ -900,7 +1187,7 @@ func (c *Cell) RandomLink() (*Cell, error) {
 	if len(keys) == 0 {
 		return nil, fmt.Errorf("no cells linked to %v", c)
 	}
-	return keys[utils.Random(0, len(keys))], nil
+	return keys[c.random(0, len(keys))], nil
 }
 
 // RandomUnLink returns a random cell not linked to this one, but one that is a neighbor
@@ -915,7 +1202,7 @@ func (c *Cell) RandomUnLink() (*Cell, error) {
 	if len(keys) == 0 {
 		return nil, fmt.Errorf("no cells unlinked from %v", c)
 	}
-	return keys[utils.Random(0, len(keys))], nil
+	return keys[c.random(0, len(keys))], nil
 }
 
 // UnLinked returns all cells not linked anywhere, but ones that are neighbors
@@ -932,7 +1219,7 @@ func (c *Cell) UnLinked() []*Cell {
 }
 
 // RandomUnLinkPreferDeadEnds returns a random cell not linked to this one, but one that is a neighbor
-// It prefers returning a cell that is itself a dead end
+// It prefers returning a cell that is itself a dead end; nil if all neighbors are linked (e.g. next to orphans)
 func (c *Cell) RandomUnLinkPreferDeadEnds() *Cell {
 	var keys []*Cell
 	var deadEnds []*Cell
@@ -945,10 +1232,13 @@ func (c *Cell) RandomUnLinkPreferDeadEnds() *Cell {
 			deadEnds = append(deadEnds, k)
 		}
 	}
+	if len(keys) == 0 {
+		return nil
+	}
 	if len(deadEnds) > 0 {
-		return keys[utils.Random(0, len(deadEnds))]
+		return keys[c.random(0, len(deadEnds))]
 	}
-	return keys[utils.Random(0, len(keys))]
+	return keys[c.random(0, len(keys))]
 }
 
 // RandomUnvisitedLink returns a random cell linked to this one that has not been visited
@@ -963,7 +1253,16 @@ func (c *Cell) RandomUnvisitedLink(client string) *Cell {
 	if len(keys) == 0 {
 		return nil
 	}
-	return keys[utils.Random(0, len(keys))]
+	return keys[c.random(0, len(keys))]
+}
+
+// random returns a random number in [min, max) from the random numbers of the maze
+func (c *Cell) random(min, max int) int {
+	if c.rand == nil {
+		// not part of a maze, e.g. in tests
+		return utils.Random(min, max)
+	}
+	return c.rand.Random(min, max)
 }
 
 // Linked returns true if the two cells are linked (joined by a passage)
@@ -986,22 +1285,27 @@ func (c *Cell) AllNeighbors() []*Cell {
 
 	var n []*Cell
 
-	for _, cell := range []*Cell{c.North(), c.South(), c.East(), c.West()} {
-		if cell != nil {
+	for _, d := range c.directions() {
+		if cell := c.neighbor(d); cell != nil {
 			n = append(n, cell)
 		}
 	}
 
-	if c.North() != nil {
-		for _, cell := range []*Cell{c.North().East(), c.North().West()} {
+	// on a hex grid all the neighbors already touch the cell
+	if gridType(c.config) != GridSquare {
+		return n
+	}
+
+	if c.north != nil {
+		for _, cell := range []*Cell{c.north.East(), c.north.West()} {
 			if cell != nil {
 				n = append(n, cell)
 			}
 		}
 	}
 
-	if c.South() != nil {
-		for _, cell := range []*Cell{c.South().East(), c.South().West()} {
+	if c.south != nil {
+		for _, cell := range []*Cell{c.south.East(), c.south.West()} {
 			if cell != nil {
 				n = append(n, cell)
 			}
@@ -1017,14 +1321,14 @@ func (c *Cell) Neighbors() []*Cell {
 
 	var n []*Cell
 
-	for _, cell := range []*Cell{c.north, c.south, c.east, c.west} {
-		if cell != nil {
+	for _, d := range c.directions() {
+		if cell := c.neighbor(d); cell != nil {
 			n = append(n, cell)
 		}
 	}
 
 	// if weaving is allowed, add additional possibilities for neighbors
-	if c.config.AllowWeaving && utils.Random(0, 100) <= int(c.config.WeavingProbability*100) {
+	if c.config.AllowWeaving && c.random(0, 100) <= int(c.config.WeavingProbability*100) {
 		if c.canTunnelNorth() {
 			n = append(n, c.north.North())
 		}
@@ -1044,60 +1348,40 @@ func (c *Cell) Neighbors() []*Cell {
 
 // RandomNeighbor returns a random neighbor of this cell
 func (c *Cell) RandomNeighbor() *Cell {
-	c.RLock()
-	defer c.RUnlock()
-
 	n := c.Neighbors()
 
-	return n[utils.Random(0, len(n))]
+	return n[c.random(0, len(n))]
 }
 
 // RandomAllNeighbor returns a random neighbor of this cell (including diagonals)
 func (c *Cell) RandomAllNeighbor() *Cell {
-	c.RLock()
-	defer c.RUnlock()
-
 	n := c.AllNeighbors()
 
-	return n[utils.Random(0, len(n))]
+	return n[c.random(0, len(n))]
 }
 
 // GetFacingDirection returns the direction walker was facing when moving to toCell from this cell
-// north, south, east, west
+// north, south, east, west, ...
 func (c *Cell) GetFacingDirection(toCell *Cell) string {
 	c.RLock()
 	defer c.RUnlock()
 
 	facing := ""
 
-	if c.North() == toCell {
-		facing = "north"
-	}
-	if c.East() == toCell {
-		facing = "east"
-	}
-	if c.West() == toCell {
-		facing = "west"
-	}
-	if c.South() == toCell {
-		facing = "south"
+	for _, d := range c.directions() {
+		if c.neighbor(d) == toCell {
+			facing = d
+		}
 	}
 	return facing
 }
 
 // Orphan isolates the cell from all of its neighbors
 func (c *Cell) Orphan() {
-	if c.East() != nil {
-		c.East().SetWest(nil)
-	}
-	if c.West() != nil {
-		c.West().SetEast(nil)
-	}
-	if c.North() != nil {
-		c.North().SetSouth(nil)
-	}
-	if c.South() != nil {
-		c.South().SetNorth(nil)
+	for _, d := range c.Directions() {
+		if n := c.Neighbor(d); n != nil {
+			n.forgetNeighbor(c)
+		}
 	}
 
 	c.SetOrphan()
@@ -12,6 +12,12 @@ type client struct {
 	TravelPath      *Path
 	fromCell        *Cell
 	toCell          *Cell
+	recorder        *recorder // records the client solving the maze, nil if not enabled
+}
+
+// ID returns the client's id
+func (c *client) ID() string {
+	return c.id
 }
 
 // UpdateClientViewAndLocation sets the client's current location
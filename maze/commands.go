@@ -11,4 +11,8 @@ const (
 	CommandAddClient
 	CommandResetClient
 	CommandExportMaze
+	CommandRenderMaze
+	CommandTextMaze
+	CommandGetMaze
+	CommandSaveMaze
 )
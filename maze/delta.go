@@ -0,0 +1,124 @@
+package maze
+
+import (
+	"math"
+
+	"github.com/DanTulovsky/mazes/colors"
+	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/DanTulovsky/mazes/render"
+	"github.com/DanTulovsky/mazes/utils"
+)
+
+// deltaMetrics returns half the width and the height of a triangle cell of the given width
+func deltaMetrics(width int64) (halfWidth, height float64) {
+	return float64(width) / 2, float64(width) * math.Sqrt(3) / 2
+}
+
+// deltaWindowSize returns the size (width, height) in pixels needed to draw a delta maze
+func deltaWindowSize(c *pb.MazeConfig) (int64, int64) {
+	halfWidth, height := deltaMetrics(c.GetCellWidth())
+
+	w := halfWidth * float64(c.GetColumns()+1)
+	h := height * float64(c.GetRows())
+
+	return int64(math.Ceil(w)) + c.GetWallWidth()*2, int64(math.Ceil(h)) + c.GetWallWidth()*2
+}
+
+// upright returns true if the (delta) cell is a triangle pointing up
+func (c *Cell) upright() bool {
+	return !utils.IsOdd(int(c.x + c.y))
+}
+
+// configureDeltaCells configures triangle cells with their neighbors; m must be locked
+func (m *Maze) configureDeltaCells() {
+	z := int64(0)
+
+	for x := int64(0); x < m.columns; x++ {
+		for y := int64(0); y < m.rows; y++ {
+			cell, err := m.Cell(x, y, z)
+			if err != nil {
+				Fail(err)
+			}
+
+			var c *Cell
+			// error is ignored, we just set nil if there is no neighbor
+			c, _ = m.Cell(x-1, y, z)
+			cell.SetWest(c)
+
+			c, _ = m.Cell(x+1, y, z)
+			cell.SetEast(c)
+
+			// triangles pointing up share their base with the cell below, the others with the cell above
+			if cell.upright() {
+				c, _ = m.Cell(x, y+1, z)
+				cell.SetSouth(c)
+			} else {
+				c, _ = m.Cell(x, y-1, z)
+				cell.SetNorth(c)
+			}
+		}
+	}
+}
+
+// deltaCorners returns the corners of a triangle cell: west, east and the apex
+func (c *Cell) deltaCorners() (vx, vy []int16) {
+	halfWidth, height := deltaMetrics(c.width)
+
+	cx := halfWidth + halfWidth*float64(c.x) + float64(c.wallWidth)
+	top := height*float64(c.y) + float64(c.wallWidth)
+	bottom := top + height
+
+	xs := []float64{cx - halfWidth, cx + halfWidth, cx}
+	ys := []float64{bottom, bottom, top}
+	if !c.upright() {
+		ys = []float64{top, top, bottom}
+	}
+
+	for i := range xs {
+		vx = append(vx, int16(math.Round(xs[i])))
+		vy = append(vy, int16(math.Round(ys[i])))
+	}
+	return vx, vy
+}
+
+// deltaCenter returns the pixel coordinates of the center (centroid) of a triangle cell
+func (c *Cell) deltaCenter() (float64, float64) {
+	vx, vy := c.deltaCorners()
+	return float64(vx[0]+vx[1]+vx[2]) / 3, float64(vy[0]+vy[1]+vy[2]) / 3
+}
+
+// drawDelta draws one triangle cell on renderer
+func (c *Cell) drawDelta(r render.Renderer, bg colors.Color) render.Renderer {
+	vx, vy := c.deltaCorners()
+
+	r.FilledPolygon(vx, vy, bg)
+
+	wallWidth := int32(c.wallWidth)
+	if wallWidth < 1 {
+		wallWidth = 1
+	}
+
+	// the base is between the first two corners, the sides go to the apex
+	walls := map[string][2]int{
+		"west": {0, 2},
+		"east": {1, 2},
+	}
+	if c.upright() {
+		walls["south"] = [2]int{0, 1}
+	} else {
+		walls["north"] = [2]int{0, 1}
+	}
+
+	for d, corners := range walls {
+		if c.Linked(c.Neighbor(d)) {
+			continue
+		}
+		from, to := corners[0], corners[1]
+		r.ThickLine(int32(vx[from]), int32(vy[from]), int32(vx[to]), int32(vy[to]), wallWidth, c.wallColor)
+	}
+
+	cx, cy := c.deltaCenter()
+	c.drawValues(r, int64(cx)-c.width/8, int64(cy))
+
+	return r
+}
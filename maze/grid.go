@@ -0,0 +1,146 @@
+package maze
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DanTulovsky/mazes/colors"
+	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/DanTulovsky/mazes/render"
+)
+
+const (
+	// GridSquare is the default grid, made up of square cells with 4 neighbors each
+	GridSquare = "square"
+	// GridHex is a grid made up of hexagonal cells with 6 neighbors each
+	// Odd columns are shifted down by half a cell.
+	GridHex = "hex"
+	// GridPolar is a grid of concentric rings, the number of cells in each ring grows with the ring's size
+	// Rows are rings (0 is the center), columns are the position in the ring, clockwise.
+	GridPolar = "polar"
+	// GridDelta is a grid of triangles with 3 neighbors each
+	// Cells where column+row is even point up, the rest point down.
+	GridDelta = "delta"
+)
+
+var (
+	// directions out of a cell, in the order used to encode the cell
+	squareDirections = []string{"north", "south", "east", "west"}
+	hexDirections    = []string{"north", "south", "northeast", "northwest", "southeast", "southwest"}
+	// outward directions on the polar grid depend on the cell, see Cell.Directions()
+	polarDirections = []string{"inward", "cw", "ccw"}
+	// triangles pointing up have a neighbor to the south, ones pointing down have one to the north
+	deltaUpDirections   = []string{"south", "east", "west"}
+	deltaDownDirections = []string{"north", "east", "west"}
+)
+
+// gridType returns the grid type of the maze, square is the default
+func gridType(c *pb.MazeConfig) string {
+	if c.GetGridType() == "" {
+		return GridSquare
+	}
+	return c.GetGridType()
+}
+
+// outwardDirections returns the names of the outward directions of a polar cell with n outward neighbors
+func outwardDirections(n int) []string {
+	if n == 1 {
+		return []string{"outward"}
+	}
+
+	var directions []string
+	for i := 0; i < n; i++ {
+		directions = append(directions, fmt.Sprintf("outward-%d", i))
+	}
+	return directions
+}
+
+// outwardIndex returns the index into the outward neighbors of a polar cell with n outward neighbors
+func outwardIndex(d string, n int) (int, bool) {
+	if d == "outward" {
+		return 0, n == 1
+	}
+	if !strings.HasPrefix(d, "outward-") || n < 2 {
+		return 0, false
+	}
+
+	i, err := strconv.Atoi(strings.TrimPrefix(d, "outward-"))
+	if err != nil || i < 0 || i >= n {
+		return 0, false
+	}
+	return i, true
+}
+
+// gridEncodingWidth returns the number of hex characters used to encode each cell on this grid
+func gridEncodingWidth(c *pb.MazeConfig) int {
+	if gridType(c) == GridHex || levels(c) > 1 {
+		// 6 directions need 6 bits
+		return 2
+	}
+	return 1
+}
+
+// checkGridConfig validates the grid related parts of the config
+func checkGridConfig(c *pb.MazeConfig) error {
+	switch gridType(c) {
+	case GridSquare:
+	case GridHex, GridPolar, GridDelta:
+		if c.GetAllowWeaving() {
+			return fmt.Errorf("weaving is only supported on %v grids", GridSquare)
+		}
+	default:
+		return fmt.Errorf("invalid grid type: %v", c.GetGridType())
+	}
+	if err := checkLevelsConfig(c); err != nil {
+		return err
+	}
+	return checkWrapConfig(c)
+}
+
+// WindowSize returns the size (width, height) in pixels needed to draw the maze
+func WindowSize(c *pb.MazeConfig) (int64, int64) {
+	switch gridType(c) {
+	case GridHex:
+		return hexWindowSize(c)
+	case GridPolar:
+		return polarWindowSize(c)
+	case GridDelta:
+		return deltaWindowSize(c)
+	default:
+		width := levelWidth(c)
+		if levelView(c) == LevelViewSideBySide {
+			width *= levels(c)
+		}
+		return width, c.GetRows()*c.GetCellWidth() + c.GetWallWidth()*2
+	}
+}
+
+// center returns the pixel coordinates of the center of the cell
+func (c *Cell) center() (float64, float64) {
+	switch gridType(c.config) {
+	case GridHex:
+		return c.hexCenter()
+	case GridPolar:
+		return c.polarCenter()
+	case GridDelta:
+		return c.deltaCenter()
+	default:
+		return float64(c.left() + c.width/2 + c.wallWidth), float64(c.y*c.width + c.width/2 + c.wallWidth)
+	}
+}
+
+// GridType returns the type of grid the maze is built on (square, hex, polar, delta)
+func (m *Maze) GridType() string {
+	return gridType(m.config)
+}
+
+// drawCenteredLocation marks the current location of the user with a box in the middle of the cell
+// Used for grids where cells are not squares.
+func (c *Cell) drawCenteredLocation(r render.Renderer, client *client) {
+	cx, cy := c.center()
+	side := c.width/2 - c.wallWidth/2
+
+	r.SetDrawColor(colors.GetColor(client.config.CurrentLocationColor))
+	r.FillRect(&render.Rect{X: int32(cx) - int32(side/2), Y: int32(cy) - int32(side/2), W: int32(side), H: int32(side)})
+}
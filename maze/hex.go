@@ -0,0 +1,131 @@
+package maze
+
+import (
+	"math"
+
+	"github.com/DanTulovsky/mazes/colors"
+	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/DanTulovsky/mazes/render"
+	"github.com/DanTulovsky/mazes/utils"
+)
+
+// hexWalls maps each direction to the two corners (see hexCorners) making up the wall on that side
+var hexWalls = map[string][2]int{
+	"southeast": {0, 1},
+	"south":     {1, 2},
+	"southwest": {2, 3},
+	"northwest": {3, 4},
+	"north":     {4, 5},
+	"northeast": {5, 0},
+}
+
+// hexMetrics returns the dimensions of a hex cell of the given width (corner to corner)
+// size is the distance from the center to a corner, a is half of a side, b is half of the height
+func hexMetrics(width int64) (size, a, b float64) {
+	size = float64(width) / 2
+	return size, size / 2, size * math.Sqrt(3) / 2
+}
+
+// hexWindowSize returns the size (width, height) in pixels needed to draw a hex maze
+func hexWindowSize(c *pb.MazeConfig) (int64, int64) {
+	_, a, b := hexMetrics(c.GetCellWidth())
+
+	w := 3*a*float64(c.GetColumns()) + a
+	h := b*2*float64(c.GetRows()) + b
+
+	return int64(math.Ceil(w)) + c.GetWallWidth()*2, int64(math.Ceil(h)) + c.GetWallWidth()*2
+}
+
+// configureHexCells configures hex cells with their neighbors; m must be locked
+func (m *Maze) configureHexCells() {
+	z := int64(0)
+
+	for x := int64(0); x < m.columns; x++ {
+		for y := int64(0); y < m.rows; y++ {
+			cell, err := m.Cell(x, y, z)
+			if err != nil {
+				Fail(err)
+			}
+
+			// odd columns are shifted down by half a cell
+			northDiagonal, southDiagonal := y-1, y
+			if utils.IsOdd(int(x)) {
+				northDiagonal, southDiagonal = y, y+1
+			}
+
+			var c *Cell
+			// error is ignored, we just set nil if there is no neighbor
+			c, _ = m.Cell(x, y-1, z)
+			cell.SetNorth(c)
+
+			c, _ = m.Cell(x, y+1, z)
+			cell.SetSouth(c)
+
+			c, _ = m.Cell(x+1, northDiagonal, z)
+			cell.SetNorthEast(c)
+
+			c, _ = m.Cell(x-1, northDiagonal, z)
+			cell.SetNorthWest(c)
+
+			c, _ = m.Cell(x+1, southDiagonal, z)
+			cell.SetSouthEast(c)
+
+			c, _ = m.Cell(x-1, southDiagonal, z)
+			cell.SetSouthWest(c)
+		}
+	}
+}
+
+// hexCenter returns the pixel coordinates of the center of a hex cell
+func (c *Cell) hexCenter() (float64, float64) {
+	size, a, b := hexMetrics(c.width)
+
+	cx := size + 3*a*float64(c.x) + float64(c.wallWidth)
+	cy := b + b*2*float64(c.y) + float64(c.wallWidth)
+	if utils.IsOdd(int(c.x)) {
+		cy += b
+	}
+	return cx, cy
+}
+
+// hexCorners returns the corners of a hex cell, clockwise starting from the far east corner
+func (c *Cell) hexCorners() (vx, vy []int16) {
+	cx, cy := c.hexCenter()
+	size, a, b := hexMetrics(c.width)
+
+	xs := []float64{cx + size, cx + a, cx - a, cx - size, cx - a, cx + a}
+	ys := []float64{cy, cy + b, cy + b, cy, cy - b, cy - b}
+
+	for i := range xs {
+		vx = append(vx, int16(math.Round(xs[i])))
+		vy = append(vy, int16(math.Round(ys[i])))
+	}
+	return vx, vy
+}
+
+// drawHex draws one hex cell on renderer
+func (c *Cell) drawHex(r render.Renderer, bg colors.Color) render.Renderer {
+	vx, vy := c.hexCorners()
+
+	r.FilledPolygon(vx, vy, bg)
+
+	wallWidth := int32(c.wallWidth)
+	if wallWidth < 1 {
+		wallWidth = 1
+	}
+
+	for _, d := range hexDirections {
+		if c.Linked(c.Neighbor(d)) {
+			continue
+		}
+		corners := hexWalls[d]
+		from, to := corners[0], corners[1]
+		r.ThickLine(int32(vx[from]), int32(vy[from]), int32(vx[to]), int32(vy[to]), wallWidth, c.wallColor)
+	}
+
+	cx, cy := c.hexCenter()
+	_, a, _ := hexMetrics(c.width)
+	c.drawValues(r, int64(cx-a), int64(cy))
+
+	return r
+}
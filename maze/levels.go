@@ -0,0 +1,147 @@
+package maze
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DanTulovsky/mazes/colors"
+	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/DanTulovsky/mazes/render"
+)
+
+const (
+	// LevelViewSideBySide draws all the levels of the maze next to each other, level 0 on the left
+	LevelViewSideBySide = "side-by-side"
+	// LevelViewFollow draws one level at a time; the one the generator or the first client is on
+	LevelViewFollow = "follow"
+)
+
+var (
+	// square cells on a maze with more than one level can also move up and down
+	levelDirections = []string{"north", "south", "east", "west", "up", "down"}
+)
+
+// levels returns the number of levels in the maze, there is always at least one
+func levels(c *pb.MazeConfig) int64 {
+	if c.GetLevels() < 1 {
+		return 1
+	}
+	return c.GetLevels()
+}
+
+// levelView returns how multiple levels are drawn, side by side is the default
+func levelView(c *pb.MazeConfig) string {
+	if c.GetLevelView() == "" {
+		return LevelViewSideBySide
+	}
+	return c.GetLevelView()
+}
+
+// checkLevelsConfig validates the level related parts of the config
+func checkLevelsConfig(c *pb.MazeConfig) error {
+	if c.GetLevels() < 0 {
+		return fmt.Errorf("invalid number of levels: %v", c.GetLevels())
+	}
+
+	switch levelView(c) {
+	case LevelViewSideBySide, LevelViewFollow:
+	default:
+		return fmt.Errorf("invalid level view: %v", c.GetLevelView())
+	}
+
+	if levels(c) == 1 {
+		return nil
+	}
+
+	if gridType(c) != GridSquare {
+		return fmt.Errorf("multiple levels are only supported on %v grids", GridSquare)
+	}
+	if c.GetAllowWeaving() {
+		return fmt.Errorf("weaving is not supported on mazes with multiple levels")
+	}
+	return nil
+}
+
+// levelWidth returns the width in pixels of one level of a square maze, including the border
+func levelWidth(c *pb.MazeConfig) int64 {
+	return c.GetColumns()*c.GetCellWidth() + c.GetWallWidth()*2
+}
+
+// EncodedDimensions returns the number of columns, rows and levels of the encoded maze
+// Levels are separated by an empty line, see Maze.Encode.
+func EncodedDimensions(c *pb.MazeConfig, encoded string) (columns, rows, levels int64) {
+	blocks := strings.Split(strings.TrimRight(encoded, "\n"), "\n\n")
+
+	levels = int64(len(blocks))
+	lines := strings.Split(blocks[0], "\n")
+	rows = int64(len(lines))
+
+	config := &pb.MazeConfig{GridType: c.GetGridType(), Levels: levels}
+	columns = int64(len(lines[0]) / gridEncodingWidth(config))
+
+	return columns, rows, levels
+}
+
+// Levels returns the number of levels in the maze
+func (m *Maze) Levels() int64 {
+	// No lock, does not change
+	return m.levels
+}
+
+// visibleLevel returns the level currently drawn when only one level is shown at a time
+// This is the level the generator is on while generating, then the level of the first client.
+func (m *Maze) visibleLevel() int64 {
+	if cell := m.GenCurrentLocation(); cell != nil {
+		return cell.z
+	}
+
+	for _, client := range m.ClientsSorted() {
+		if cell := client.CurrentLocation(); cell != nil {
+			return cell.z
+		}
+	}
+	return 0
+}
+
+// isVisible returns true if the cell is on a level that is currently drawn
+func (m *Maze) isVisible(c *Cell) bool {
+	if m.levels == 1 || levelView(m.config) != LevelViewFollow {
+		return true
+	}
+	return c.z == m.visibleLevel()
+}
+
+// levelOffset returns the horizontal offset in pixels of the level the cell is on
+func (c *Cell) levelOffset() int64 {
+	if c.z <= 0 || levelView(c.config) == LevelViewFollow {
+		return 0
+	}
+	return c.z * levelWidth(c.config)
+}
+
+// left returns the x coordinate in pixels of the left edge of a square cell
+func (c *Cell) left() int64 {
+	return c.x*c.width + c.levelOffset()
+}
+
+// drawStairs draws a small triangle in the corner of the cell for each passage up or down
+// up is in the top right corner pointing up, down in the bottom right corner pointing down
+func (c *Cell) drawStairs(r render.Renderer) {
+	size := int32(c.width / 4)
+	if size < 2 {
+		return
+	}
+	wallSpace := int32(c.config.WallSpace / 2)
+	right := int32(c.left()+c.width) - wallSpace - size/2
+	top := int32(c.y*c.width+c.wallWidth) + wallSpace + size/2
+	bottom := int32(c.y*c.width+c.width) - wallSpace - size/2
+
+	color := colors.GetColor("black")
+
+	if c.Linked(c.Up()) {
+		r.FilledTrigon(right-size, top+size, right, top+size, right-size/2, top, color)
+	}
+	if c.Linked(c.Down()) {
+		r.FilledTrigon(right-size, bottom-size, right, bottom-size, right-size/2, bottom, color)
+	}
+}
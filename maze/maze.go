@@ -8,7 +8,6 @@ import (
 	_ "image/png"
 	"log"
 	"math"
-	"math/rand"
 	"os"
 	"runtime/debug"
 	"sort"
@@ -20,6 +19,7 @@ import (
 	pb "github.com/DanTulovsky/mazes/proto"
 	"github.com/DanTulovsky/mazes/utils"
 
+	"github.com/DanTulovsky/mazes/render"
 	"github.com/DanTulovsky/mazes/tree"
 
 	"io/ioutil"
@@ -28,13 +28,8 @@ import (
 
 	metrics "github.com/rcrowley/go-metrics"
 	deadlock "github.com/sasha-s/go-deadlock"
-	"github.com/veandco/go-sdl2/sdl"
 )
 
-func init() {
-	rand.Seed(time.Now().UTC().UnixNano()) // need to initialize the seed
-}
-
 // Location is x,y coordinate of a cell
 type Location struct {
 	X, Y, Z int
@@ -46,7 +41,8 @@ type Maze struct {
 	config           *pb.MazeConfig
 	rows             int64
 	columns          int64
-	cells            [][]*Cell
+	levels           int64
+	cells            [][][]*Cell    // [column][row][level]
 	mazeCells        map[*Cell]bool // cells that are in the maze, not orphaned (for caching)
 	orphanCells      map[*Cell]bool // cells that are orphaned (for caching)
 	cellWidth        int64
@@ -61,21 +57,27 @@ type Maze struct {
 
 	lastUpdatedCell *Cell // the last updated cell for game of life motion events
 
-	genCurrentLocation *Cell // the current location of generator
+	genCurrentLocation *Cell                    // the current location of generator
+	genStepFunc        func(l *pb.MazeLocation) // called on every generator step, see OnGenStep
 
 	// map of client IDs to client structures
 	clients     map[string]*client
 	clientsLock deadlock.RWMutex
 
-	avatar *sdl.Texture
+	avatar render.Texture
 
-	bg                  *sdl.Texture
+	bg                  render.Texture
+	bgLevel             int64 // the level drawn on bg when only one level is shown at a time
 	bgLock              deadlock.RWMutex
 	winWidth, winHeight int
-	r                   *sdl.Renderer
+	r                   render.Renderer
 
 	encoded string // the maze cells and passages encoded as ascii
 
+	genRecorder *recorder // records the generator, nil if not enabled
+
+	rand *utils.Rand // all random numbers of the maze come from here, seeded with config.Seed
+
 	deadlock.RWMutex
 }
 
@@ -123,19 +125,26 @@ func (m *Maze) Config() *pb.MazeConfig {
 }
 
 // BGTexture returns the maze's background texture
-func (m *Maze) BGTexture() *sdl.Texture {
+func (m *Maze) BGTexture() render.Texture {
 	m.bgLock.RLock()
 	defer m.bgLock.RUnlock()
 	return m.bg
 }
 
 // SetBGTexture sets the maze's background texture
-func (m *Maze) SetBGTexture(t *sdl.Texture) {
+func (m *Maze) SetBGTexture(t render.Texture) {
 	m.bgLock.Lock()
 	defer m.bgLock.Unlock()
 	m.bg = t
 }
 
+// BGLevel returns the level drawn on the background texture when only one level is shown at a time
+func (m *Maze) BGLevel() int64 {
+	m.bgLock.RLock()
+	defer m.bgLock.RUnlock()
+	return m.bgLevel
+}
+
 // setupMazeMask reads in the mask image and creates the maze based on it.
 // The size of the maze is the size of the image, in pixels.
 // Any *black* pixel in the mask image becomes an orphan square.
@@ -183,7 +192,7 @@ func setupMazeMask(f string, c *pb.MazeConfig, mask []*pb.MazeLocation) ([]*pb.M
 }
 
 // NewMazeFromImage creates a new maze from the image at file f
-func NewMazeFromImage(c *pb.MazeConfig, f string, r *sdl.Renderer) (*Maze, error) {
+func NewMazeFromImage(c *pb.MazeConfig, f string, r render.Renderer) (*Maze, error) {
 	mask := make([]*pb.MazeLocation, 0)
 	mask, err := setupMazeMask(f, c, mask)
 	if err != nil {
@@ -195,12 +204,24 @@ func NewMazeFromImage(c *pb.MazeConfig, f string, r *sdl.Renderer) (*Maze, error
 }
 
 // NewMaze returns a new grid.
-func NewMaze(c *pb.MazeConfig, r *sdl.Renderer) (*Maze, error) {
+func NewMaze(c *pb.MazeConfig, r render.Renderer) (*Maze, error) {
+	if err := checkGridConfig(c); err != nil {
+		return nil, err
+	}
+
+	if gridType(c) == GridPolar {
+		// the outer ring has the most cells, the rest of the cells in each ring are orphans
+		c.Columns = polarColumns(c.GetRows())
+	}
+
+	winWidth, winHeight := WindowSize(c)
+
 	m := &Maze{
 		id:          c.GetId(),
 		rows:        c.GetRows(),
 		columns:     c.GetColumns(),
-		cells:       [][]*Cell{},
+		levels:      levels(c),
+		cells:       [][][]*Cell{},
 		cellWidth:   c.GetCellWidth(),
 		wallWidth:   c.GetWallWidth(),
 		pathWidth:   c.GetPathWidth(),
@@ -209,8 +230,8 @@ func NewMaze(c *pb.MazeConfig, r *sdl.Renderer) (*Maze, error) {
 		wallColor:   colors.GetColor(c.GetWallColor()),
 		fromCell:    make(map[string]*Cell),
 		toCell:      make(map[string]*Cell),
-		winWidth:    int((c.GetColumns())*c.GetCellWidth() + c.GetWallWidth()*2),
-		winHeight:   int((c.GetRows())*c.GetCellWidth() + c.GetWallWidth()*2),
+		winWidth:    int(winWidth),
+		winHeight:   int(winHeight),
 		r:           r,
 
 		config: c,
@@ -221,6 +242,16 @@ func NewMaze(c *pb.MazeConfig, r *sdl.Renderer) (*Maze, error) {
 		clients: make(map[string]*client),
 	}
 
+	if c.GetSeed() == 0 {
+		c.Seed = utils.NewSeed()
+	}
+	m.rand = utils.NewRand(c.GetSeed())
+
+	var err error
+	if m.genRecorder, err = newRecorder(c.GetRecord()); err != nil {
+		return nil, err
+	}
+
 	if err := m.prepareGrid(); err != nil {
 		return nil, err
 	}
@@ -235,7 +266,9 @@ func NewMaze(c *pb.MazeConfig, r *sdl.Renderer) (*Maze, error) {
 }
 
 // Encode encodes the maze (shape and cells/passages) to ascii
-// The maze is encoded into an ascii grid. Each cell is represented by a hex character
+// The maze is encoded into an ascii grid. Each cell is represented by one hex character (two on a hex grid
+// or when there is more than one level)
+// Each level is encoded as its own grid, starting with level 0, levels are separated by an empty line.
 // See cell.Encode for explanation
 func (m *Maze) Encode() (string, error) {
 	m.Lock()
@@ -243,17 +276,23 @@ func (m *Maze) Encode() (string, error) {
 
 	var enc string
 
-	for x := int64(0); x < m.rows; x++ {
-		for y := int64(0); y < m.columns; y++ {
-			c, err := m.Cell(y, x, 0)
-			if err != nil {
-				return "", err
-			}
+	for z := int64(0); z < m.levels; z++ {
+		if z > 0 {
+			enc = enc + "\n"
+		}
+
+		for x := int64(0); x < m.rows; x++ {
+			for y := int64(0); y < m.columns; y++ {
+				c, err := m.Cell(y, x, z)
+				if err != nil {
+					return "", err
+				}
 
-			e := c.Encode()
-			enc = enc + e
+				e := c.Encode()
+				enc = enc + e
+			}
+			enc = enc + "\n"
 		}
-		enc = enc + "\n"
 	}
 
 	return enc, nil
@@ -265,32 +304,72 @@ func (m *Maze) Decode(encoded string) error {
 	m.Lock()
 	m.Unlock()
 
-	if int(m.rows*m.columns) != len(encoded)-int(m.rows) {
-		return fmt.Errorf("maze size=%v (%v, %v) does not match encoded size (length=%v):\n%v",
-			m.rows*m.columns, m.columns, m.rows, len(encoded)-int(m.rows), encoded)
+	width := int64(gridEncodingWidth(m.config))
+	// one newline per row, one more between levels
+	newlines := m.rows*m.levels + m.levels - 1
+
+	if m.rows*m.columns*m.levels*width != int64(len(encoded))-newlines {
+		return fmt.Errorf("maze size=%v (%v, %v, %v) does not match encoded size (length=%v):\n%v",
+			m.rows*m.columns*m.levels, m.columns, m.rows, m.levels, int64(len(encoded))-newlines, encoded)
 	}
 
-	p := 0
+	p := int64(0)
 
-	for x := int64(0); x < m.rows; x++ {
-		for y := int64(0); y < m.columns; y++ {
-			c, err := m.Cell(y, x, 0)
-			if err != nil {
-				return err
-			}
+	for z := int64(0); z < m.levels; z++ {
+		if z > 0 {
+			p++
+		}
 
-			if err := c.Decode(string(encoded[p])); err != nil {
-				return err
+		for x := int64(0); x < m.rows; x++ {
+			for y := int64(0); y < m.columns; y++ {
+				c, err := m.Cell(y, x, z)
+				if err != nil {
+					return err
+				}
+
+				if err := c.Decode(encoded[p : p+width]); err != nil {
+					return err
+				}
+				p += width
 			}
 			p++
 		}
-		p++
 	}
 
 	return nil
 
 }
 
+// CheckEncoded returns an error if the maze, after Decode, is not exactly the encoded maze
+// Decode links both cells of a passage, so a passage only one of its cells has (one way) shows up here.
+func (m *Maze) CheckEncoded(encoded string) error {
+	width := int64(gridEncodingWidth(m.config))
+	p := int64(0)
+
+	for z := int64(0); z < m.levels; z++ {
+		if z > 0 {
+			p++
+		}
+
+		for x := int64(0); x < m.rows; x++ {
+			for y := int64(0); y < m.columns; y++ {
+				c, err := m.Cell(y, x, z)
+				if err != nil {
+					return err
+				}
+
+				if e := c.Encode(); !strings.EqualFold(e, encoded[p:p+width]) {
+					return fmt.Errorf("cell %v is encoded as %v, but its passages and its neighbors' make it %v (one way passage?)",
+						c, encoded[p:p+width], e)
+				}
+				p += width
+			}
+			p++
+		}
+	}
+	return nil
+}
+
 // Export exports the maze as encoded ascii to the file
 func (m *Maze) Export(dir string) error {
 
@@ -381,13 +460,18 @@ func (m *Maze) configToCell(config *pb.ClientConfig, c string) (*Cell, error) {
 	case "random":
 		return m.RandomCell(), nil
 	default:
+		// x,y or x,y,z on mazes with more than one level
 		from := strings.Split(c, ",")
-		if len(from) != 2 {
+		if len(from) != 2 && len(from) != 3 {
 			log.Fatalf("%v is not a valid coordinate", config.FromCell)
 		}
 		x, _ := strconv.ParseInt(from[0], 10, 64)
 		y, _ := strconv.ParseInt(from[1], 10, 64)
-		cell, err := m.Cell(x, y, 0)
+		var z int64
+		if len(from) == 3 {
+			z, _ = strconv.ParseInt(from[2], 10, 64)
+		}
+		cell, err := m.Cell(x, y, z)
 		if err != nil {
 			return nil, fmt.Errorf("invalid cell: %v", err)
 		}
@@ -397,38 +481,44 @@ func (m *Maze) configToCell(config *pb.ClientConfig, c string) (*Cell, error) {
 }
 
 // MakeBGTexture creates the background texture.
-func (m *Maze) MakeBGTexture() (*sdl.Texture, error) {
+func (m *Maze) MakeBGTexture() (render.Texture, error) {
 	r := m.r
 	winWidth := int32(m.winWidth)
 	winHeight := int32(m.winHeight)
-	mTexture, err := m.r.CreateTexture(sdl.PIXELFORMAT_RGB24, sdl.TEXTUREACCESS_TARGET, int32(winWidth), int32(winHeight))
+	mTexture, err := m.r.NewTexture(winWidth, winHeight)
 	if err != nil {
 		return nil, err
 	}
 
 	// draw on the texture
-	sdl.Do(func() {
-		if err := r.SetRenderTarget(mTexture); err != nil {
+	r.Do(func() {
+		if err := r.SetTarget(mTexture); err != nil {
 			log.Fatalf("error setting texture as render targer: %v", err)
 		}
 		// background is black so that transparency works
-		colors.SetDrawColor(colors.GetColor("white"), r)
+		r.SetDrawColor(colors.GetColor("white"))
 		if err := r.Clear(); err != nil {
 			log.Fatalf("error clearing: %v", err)
 		}
 	})
+	level := m.visibleLevel()
 	m.DrawMazeBackground(r)
-	sdl.Do(func() {
+
+	m.bgLock.Lock()
+	m.bgLevel = level
+	m.bgLock.Unlock()
+
+	r.Do(func() {
 		// TODO: This causes a crash.  Why is this even here?
 		// r.Present()
 	})
 
 	// Reset to drawing on the screen
-	sdl.Do(func() {
-		if err := r.SetRenderTarget(nil); err != nil {
+	r.Do(func() {
+		if err := r.SetTarget(nil); err != nil {
 			log.Fatalf("error resetting render target: %v", err)
 		}
-		if err := r.Copy(mTexture, nil, nil); err != nil {
+		if err := r.Copy(mTexture, nil); err != nil {
 			log.Fatalf("error copying texture to renderer: %v", err)
 		}
 		r.Present()
@@ -449,6 +539,10 @@ func (m *Maze) AddClient(id string, config *pb.ClientConfig) (fromCell *Cell, to
 		number:     m.nextClient,
 	}
 
+	if c.recorder, err = newRecorder(config.GetRecord()); err != nil {
+		return nil, nil, err
+	}
+
 	if config.GetFromCell() != "" {
 		fromCell, err = m.configToCell(config, config.FromCell)
 		if err != nil {
@@ -505,14 +599,15 @@ func (m *Maze) Braid(p float64) {
 	log.Printf("Removing dead ends with probability %v", p)
 
 	for _, c := range m.DeadEnds() {
-		if utils.Random(0, 100) >= int(p*100) {
+		if m.rand.Random(0, 100) >= int(p*100) {
 			continue
 		}
 
 		// make sure still dead end
 		if len(c.Links()) == 1 {
-			n := c.RandomUnLinkPreferDeadEnds()
-			m.Link(c, n)
+			if n := c.RandomUnLinkPreferDeadEnds(); n != nil {
+				m.Link(c, n)
+			}
 		}
 
 	}
@@ -576,54 +671,26 @@ func (m *Maze) MoveClient(clientID, direction string) (*client, error) {
 		return nil, fmt.Errorf("failed to find client: %v", err)
 	}
 
-	switch direction {
-	case "north":
-		if client.CurrentLocation().Linked(client.CurrentLocation().North()) {
-			client.SetCurrentLocation(client.CurrentLocation().North())
-			s := NewSegment(client.CurrentLocation(), "north", true)
-			client.TravelPath.AddSegement(s)
-			client.CurrentLocation().SetVisited(clientID)
-			m.SetClientPath(client)
-		} else {
-			err = fmt.Errorf("cannot move 'north' from %v", client.CurrentLocation().String())
-		}
-	case "south":
-		if client.CurrentLocation().Linked(client.CurrentLocation().South()) {
-			client.SetCurrentLocation(client.CurrentLocation().South())
-			s := NewSegment(client.CurrentLocation(), "south", true)
-			client.TravelPath.AddSegement(s)
-			client.CurrentLocation().SetVisited(clientID)
-			m.SetClientPath(client)
-		} else {
-			err = fmt.Errorf("cannot move 'south' from %v", client.CurrentLocation().String())
-		}
-	case "west":
-		if client.CurrentLocation().Linked(client.CurrentLocation().West()) {
-			client.SetCurrentLocation(client.CurrentLocation().West())
-			s := NewSegment(client.CurrentLocation(), "west", true)
-			client.TravelPath.AddSegement(s)
-			client.CurrentLocation().SetVisited(clientID)
-			m.SetClientPath(client)
-		} else {
-			err = fmt.Errorf("cannot move 'west' from %v", client.CurrentLocation().String())
-
-		}
-	case "east":
-		if client.CurrentLocation().Linked(client.CurrentLocation().East()) {
-			client.SetCurrentLocation(client.CurrentLocation().East())
-			s := NewSegment(client.CurrentLocation(), "east", true)
-			client.TravelPath.AddSegement(s)
-			client.CurrentLocation().SetVisited(clientID)
-			m.SetClientPath(client)
-		} else {
-			err = fmt.Errorf("cannot move 'east' from %v", client.CurrentLocation().String())
-
-		}
-	default:
+	current := client.CurrentLocation()
+	if current == nil {
+		return nil, fmt.Errorf("client %v is not in the maze yet, it must be placed on its from cell first", clientID)
+	}
+	if !utils.StrInList(current.Directions(), direction) {
 		log.Printf("invalid direction: %v", direction)
-		err = fmt.Errorf("invalid direction: %v", direction)
+		return client, fmt.Errorf("invalid direction: %v", direction)
+	}
+
+	next := current.Neighbor(direction)
+	if !current.Linked(next) {
+		return client, fmt.Errorf("cannot move '%v' from %v", direction, current.String())
 	}
 
+	client.SetCurrentLocation(next)
+	s := NewSegment(client.CurrentLocation(), direction, true)
+	client.TravelPath.AddSegement(s)
+	client.CurrentLocation().SetVisited(clientID)
+	m.SetClientPath(client)
+
 	return client, err
 }
 
@@ -639,7 +706,7 @@ func (m *Maze) Link(c1, c2 *Cell) {
 	if m.config.AllowWeaving {
 		// is there a cell between this one and the link to cell?
 		if c1.North() != nil && c2.South() != nil && c1.North() == c2.South() {
-			linkCell = NewCell(c1.North().x, c1.North().y, c1.North().z-1, m.config) // under
+			linkCell = m.newCell(c1.North().x, c1.North().y, c1.North().z-1) // under
 			c1.North().SetBelow(linkCell)
 			// rework neighbor links
 			c1.SetNorth(linkCell)
@@ -647,21 +714,21 @@ func (m *Maze) Link(c1, c2 *Cell) {
 			linkCell.SetSouth(c1)
 			linkCell.SetNorth(c2)
 		} else if c1.South() != nil && c2.North() != nil && c1.South() == c2.North() {
-			linkCell = NewCell(c1.South().x, c1.South().y, c1.South().z-1, m.config) // under
+			linkCell = m.newCell(c1.South().x, c1.South().y, c1.South().z-1) // under
 			c1.South().SetBelow(linkCell)
 			c1.SetSouth(linkCell)
 			c2.SetNorth(linkCell)
 			linkCell.SetSouth(c2)
 			linkCell.SetNorth(c1)
 		} else if c1.East() != nil && c2.West() != nil && c1.East() == c2.West() {
-			linkCell = NewCell(c1.East().x, c1.East().y, c1.East().z-1, m.config) // under
+			linkCell = m.newCell(c1.East().x, c1.East().y, c1.East().z-1) // under
 			c1.East().SetBelow(linkCell)
 			c1.SetEast(linkCell)
 			c2.SetWest(linkCell)
 			linkCell.SetEast(c2)
 			linkCell.SetWest(c1)
 		} else if c1.West() != nil && c2.East() != nil && c1.West() == c2.East() {
-			linkCell = NewCell(c1.West().x, c1.West().y, c1.West().z-1, m.config) // under
+			linkCell = m.newCell(c1.West().x, c1.West().y, c1.West().z-1) // under
 			c1.West().SetBelow(linkCell)
 			c1.SetWest(linkCell)
 			c2.SetEast(linkCell)
@@ -685,10 +752,11 @@ func (m *Maze) Link(c1, c2 *Cell) {
 		c2.linkOneWay(c1)
 	}
 
+	m.recordGenStep()
 }
 
 //// loadAvatar reads in the avatar image
-//func (m *Maze) loadAvatar(r *sdl.Renderer) {
+//func (m *Maze) loadAvatar(r render.Renderer) {
 //	if m.avatar != nil {
 //		return
 //	}
@@ -703,7 +771,7 @@ func (m *Maze) Link(c1, c2 *Cell) {
 //}
 //
 // getAvatar returns the avatar texture
-func (m *Maze) getAvatar() *sdl.Texture {
+func (m *Maze) getAvatar() render.Texture {
 	// TODO: Fix this to be per client
 	return nil
 	//if m.avatar == nil && m.config.AvatarImage != "" {
@@ -721,17 +789,24 @@ func (m *Maze) prepareGrid() error {
 		return fmt.Errorf("invalid maze dimensions: %v, %v", m.columns, m.rows)
 	}
 
-	z := int64(0)
-	m.cells = make([][]*Cell, m.columns)
+	m.cells = make([][][]*Cell, m.columns)
 
 	for x := int64(0); x < m.columns; x++ {
-		m.cells[x] = make([]*Cell, m.rows)
+		m.cells[x] = make([][]*Cell, m.rows)
 
 		for y := int64(0); y < m.rows; y++ {
-			m.cells[x][y] = NewCell(x, y, z, m.config)
+			m.cells[x][y] = make([]*Cell, m.levels)
+
+			for z := int64(0); z < m.levels; z++ {
+				m.cells[x][y][z] = m.newCell(x, y, z)
+			}
 		}
 	}
 
+	if gridType(m.config) == GridPolar {
+		m.preparePolarGrid()
+	}
+
 	return nil
 }
 
@@ -740,9 +815,11 @@ func (m *Maze) setWeights() error {
 		for y := int64(0); y < m.rows; y++ {
 			weight := 1
 			if utils.IsOdd(int(x)) && utils.IsOdd(int(y)) && y != m.columns-1 || (y > m.columns/2 && x != 0 && y != m.columns-1) {
-				weight = utils.Random(100, 900)
+				weight = m.rand.Random(100, 900)
+			}
+			for z := int64(0); z < m.levels; z++ {
+				m.cells[x][y][z].SetWeight(weight)
 			}
-			m.cells[x][y].SetWeight(weight)
 		}
 	}
 
@@ -754,33 +831,19 @@ func (m *Maze) configureCells() {
 	m.Lock()
 	defer m.Unlock()
 
-	z := int64(0)
-
-	for x := int64(0); x < m.columns; x++ {
-		for y := int64(0); y < m.rows; y++ {
-			cell, err := m.Cell(x, y, z)
-			if err != nil {
-				log.Fatalf("failed to initialize grid: %v", err)
-			}
-			var c *Cell
-			// error is ignored, we just set nil if there is no neighbor
-			c, _ = m.Cell(x, y-1, z)
-			cell.SetNorth(c)
-
-			c, _ = m.Cell(x, y+1, z)
-			cell.SetSouth(c)
-
-			c, _ = m.Cell(x-1, y, z)
-			cell.SetWest(c)
-
-			c, _ = m.Cell(x+1, y, z)
-			cell.SetEast(c)
-
-		}
+	switch gridType(m.config) {
+	case GridHex:
+		m.configureHexCells()
+	case GridPolar:
+		m.configurePolarCells()
+	case GridDelta:
+		m.configureDeltaCells()
+	default:
+		m.configureSquareCells()
 	}
 
 	for _, o := range m.config.GetOrphanMask() {
-		cell, err := m.Cell(o.X, o.Y, 0)
+		cell, err := m.Cell(o.X, o.Y, o.Z)
 		if err != nil {
 			Fail(err)
 		}
@@ -789,11 +852,62 @@ func (m *Maze) configureCells() {
 
 }
 
+// configureSquareCells configures square cells with their neighbors; m must be locked
+// On mazes with more than one level, up is the same cell on the next level.
+// If the grid wraps around, edge cells are neighbors of the cells on the opposite edge.
+func (m *Maze) configureSquareCells() {
+	for z := int64(0); z < m.levels; z++ {
+		for x := int64(0); x < m.columns; x++ {
+			for y := int64(0); y < m.rows; y++ {
+				cell, err := m.Cell(x, y, z)
+				if err != nil {
+					log.Fatalf("failed to initialize grid: %v", err)
+				}
+				var c *Cell
+				// error is ignored, we just set nil if there is no neighbor
+				c, _ = m.wrappedCell(x, y-1, z)
+				cell.SetNorth(c)
+
+				c, _ = m.wrappedCell(x, y+1, z)
+				cell.SetSouth(c)
+
+				c, _ = m.wrappedCell(x-1, y, z)
+				cell.SetWest(c)
+
+				c, _ = m.wrappedCell(x+1, y, z)
+				cell.SetEast(c)
+
+				if m.levels > 1 {
+					c, _ = m.Cell(x, y, z+1)
+					cell.SetUp(c)
+
+					c, _ = m.Cell(x, y, z-1)
+					cell.SetDown(c)
+				}
+			}
+		}
+	}
+}
+
 // SetGenCurrentLocatio sets the current cell location of the generator algorithm
 func (m *Maze) SetGenCurrentLocation(cell *Cell) {
 	m.Lock()
-	defer m.Unlock()
 	m.genCurrentLocation = cell
+	f := m.genStepFunc
+	m.Unlock()
+
+	m.recordGenStep()
+	if f != nil && cell != nil {
+		f(cell.Location())
+	}
+}
+
+// OnGenStep sets f to be called with the location of the generator at every generator step, nil stops it
+// f is called from the generator, it should not block.
+func (m *Maze) OnGenStep(f func(l *pb.MazeLocation)) {
+	m.Lock()
+	defer m.Unlock()
+	m.genStepFunc = f
 }
 
 // GenCurrentLocation returns the current cell location of the generator algorithm
@@ -915,54 +1029,75 @@ func (m *Maze) SetToCell(client *client, c *Cell) {
 }
 
 // DrawMazeBackground renders the gui maze background in memory
-func (m *Maze) DrawMazeBackground(r *sdl.Renderer) {
+func (m *Maze) DrawMazeBackground(r render.Renderer) {
 	t := metrics.GetOrRegisterTimer("maze.draw.background.latency", nil)
 	defer t.UpdateSince(time.Now())
 
+	m.drawBackground(r, nil)
+}
+
+// drawBackground draws the cells, the ones in bgColors with that background color instead of their own
+func (m *Maze) drawBackground(r render.Renderer, bgColors map[*Cell]colors.Color) {
+	draw := func(cell *Cell) {
+		if bg, ok := bgColors[cell]; ok {
+			cell.drawBG(r, bg)
+		} else {
+			cell.Draw(r)
+		}
+	}
+
 	// Each cell draws its background, half the wall as well as anything inside it
-	for x := int64(0); x < m.columns; x++ {
-		for y := int64(0); y < m.rows; y++ {
-			cell, err := m.Cell(x, y, 0)
-			if err != nil {
-				Fail(fmt.Errorf("Error drawing cell (%v, %v): %v", x, y, err))
-			}
+	for z := int64(0); z < m.levels; z++ {
+		for x := int64(0); x < m.columns; x++ {
+			for y := int64(0); y < m.rows; y++ {
+				cell, err := m.Cell(x, y, z)
+				if err != nil {
+					Fail(fmt.Errorf("Error drawing cell (%v, %v, %v): %v", x, y, z, err))
+				}
 
-			if cell.IsOrphan() {
-				// these are cells not connected to the maze
-				continue
-			}
+				if cell.IsOrphan() {
+					// these are cells not connected to the maze
+					continue
+				}
 
-			// draw the below cell if it exists
-			if cell.Below() != nil {
-				// cell exists
-				cell.Below().Draw(r)
-			}
+				if !m.isVisible(cell) {
+					// only one level is shown at a time
+					continue
+				}
 
-			cell.Draw(r)
-			// this is used on the client side which re-draws the background on every pass
-			// the server only call this function when generating maze
-			clients := m.ClientsSorted()
-			if len(clients) > 0 {
-				for _, client := range clients {
-					if cell.Visited(client.id) {
-						cell.DrawVisited(r, client)
+				// draw the below cell if it exists
+				if cell.Below() != nil {
+					// cell exists
+					draw(cell.Below())
+				}
+
+				draw(cell)
+				// this is used on the client side which re-draws the background on every pass
+				// the server only call this function when generating maze
+				clients := m.ClientsSorted()
+				if len(clients) > 0 {
+					for _, client := range clients {
+						if cell.Visited(client.id) {
+							cell.DrawVisited(r, client)
+						}
 					}
 				}
-			}
 
+			}
 		}
 	}
 }
 
 // Draw renders the gui maze in memory, display by calling Present
-func (m *Maze) DrawMaze(r *sdl.Renderer, bg *sdl.Texture) {
+func (m *Maze) DrawMaze(r render.Renderer, bg render.Texture) {
 	t := metrics.GetOrRegisterTimer("maze.draw.all.latency", nil)
 	defer t.UpdateSince(time.Now())
 
 	tbg := metrics.GetOrRegisterTimer("maze.draw.bg-copy.latency", nil)
 
-	if bg != nil {
-		tbg.Time(func() { r.Copy(bg, nil, nil) }) // copy the background texture
+	// when showing one level at a time, the background is only valid for the level it was drawn for
+	if bg != nil && (levelView(m.config) != LevelViewFollow || m.BGLevel() == m.visibleLevel()) {
+		tbg.Time(func() { r.Copy(bg, nil) }) // copy the background texture
 	} else {
 		m.DrawMazeBackground(r) // draw it from scratch
 	}
@@ -973,59 +1108,68 @@ func (m *Maze) DrawMaze(r *sdl.Renderer, bg *sdl.Texture) {
 	// Draw the path and location of solver
 	clients := m.ClientsSorted()
 	for _, client := range clients {
-		m.drawClientPath(r, client)
+		m.drawClient(r, client)
+	}
+}
 
-		var fromColor colors.Color
-		var toColor colors.Color
+// drawClient draws the path, location and from/to cells of the client
+func (m *Maze) drawClient(r render.Renderer, client *client) {
+	m.drawClientPath(r, client)
 
-		if client.config.GetFromCellColor() != "" {
-			fromColor = colors.GetColor(client.config.GetFromCellColor())
-		} else {
-			fromColor = colors.Darker(client.config.GetPathColor(), 0.5)
-		}
+	var fromColor colors.Color
+	var toColor colors.Color
 
-		if client.config.GetToCellColor() != "" {
-			toColor = colors.GetColor(client.config.GetToCellColor())
-		} else {
-			toColor = colors.Lighter(client.config.GetPathColor(), 0.5)
-		}
+	if client.config.GetFromCellColor() != "" {
+		fromColor = colors.GetColor(client.config.GetFromCellColor())
+	} else {
+		fromColor = colors.Darker(client.config.GetPathColor(), 0.5)
+	}
+
+	if client.config.GetToCellColor() != "" {
+		toColor = colors.GetColor(client.config.GetToCellColor())
+	} else {
+		toColor = colors.Lighter(client.config.GetPathColor(), 0.5)
+	}
 
-		client.fromCell.SetBGColor(fromColor)
-		client.toCell.SetBGColor(toColor)
+	client.fromCell.SetBGColor(fromColor)
+	client.toCell.SetBGColor(toColor)
 
-		// update from/to cell colors
+	// update from/to cell colors
+	if m.isVisible(client.fromCell) {
 		client.fromCell.Draw(r)
+	}
+	if m.isVisible(client.toCell) {
 		client.toCell.Draw(r)
 	}
 }
 
 // DrawBorder renders the maze border in memory, display by calling Present
-func (m *Maze) drawBorder(r *sdl.Renderer) *sdl.Renderer {
+func (m *Maze) drawBorder(r render.Renderer) render.Renderer {
 	t := metrics.GetOrRegisterTimer("maze.draw.border.latency", nil)
 	defer t.UpdateSince(time.Now())
 
-	colors.SetDrawColor(m.borderColor, r)
+	r.SetDrawColor(m.borderColor)
 
-	var bg sdl.Rect
-	var rects []sdl.Rect
-	winWidth := int32(m.columns*m.cellWidth + m.wallWidth*2)
-	winHeight := int32(m.rows*m.cellWidth + m.wallWidth*2)
+	var bg render.Rect
+	var rects []render.Rect
+	winWidth := int32(m.winWidth)
+	winHeight := int32(m.winHeight)
 	wallWidth := int32(m.wallWidth)
 
 	// top
-	bg = sdl.Rect{0, 0, winWidth, wallWidth}
+	bg = render.Rect{0, 0, winWidth, wallWidth}
 	rects = append(rects, bg)
 
 	// left
-	bg = sdl.Rect{0, 0, wallWidth, winHeight}
+	bg = render.Rect{0, 0, wallWidth, winHeight}
 	rects = append(rects, bg)
 
 	// bottom
-	bg = sdl.Rect{0, winHeight - wallWidth, winWidth, wallWidth}
+	bg = render.Rect{0, winHeight - wallWidth, winWidth, wallWidth}
 	rects = append(rects, bg)
 
 	// right
-	bg = sdl.Rect{winWidth - wallWidth, 0, wallWidth, winHeight}
+	bg = render.Rect{winWidth - wallWidth, 0, wallWidth, winHeight}
 	rects = append(rects, bg)
 
 	if err := r.FillRects(rects); err != nil {
@@ -1034,7 +1178,7 @@ func (m *Maze) drawBorder(r *sdl.Renderer) *sdl.Renderer {
 	return r
 }
 
-func (m *Maze) drawGenCurrentLocation(r *sdl.Renderer) *sdl.Renderer {
+func (m *Maze) drawGenCurrentLocation(r render.Renderer) render.Renderer {
 	t := metrics.GetOrRegisterTimer("maze.draw.gen-current-location.latency", nil)
 	defer t.UpdateSince(time.Now())
 
@@ -1054,34 +1198,29 @@ func (m *Maze) drawGenCurrentLocation(r *sdl.Renderer) *sdl.Renderer {
 }
 
 // DrawPath renders the gui maze path in memory, display by calling Present
-func (m *Maze) drawClientPath(r *sdl.Renderer, client *client) {
+func (m *Maze) drawClientPath(r render.Renderer, client *client) {
 	t := metrics.GetOrRegisterTimer("maze.draw.path.latency", nil)
 	defer t.UpdateSince(time.Now())
 
-	client.TravelPath.Draw(r, client, m.getAvatar())
+	client.TravelPath.Draw(r, client, m.getAvatar(), m.isVisible)
 }
 
-// Cell returns the cell at r,c
+// Cell returns the cell at r,c on level z
 func (m *Maze) Cell(column, row, z int64) (*Cell, error) {
-	if column < 0 || column >= m.columns || row < 0 || row >= m.rows {
-		return nil, fmt.Errorf("(%v, %v) is outside the grid (%v, %v)", column, row, m.columns, m.rows)
+	if column < 0 || column >= m.columns || row < 0 || row >= m.rows || z < 0 || z >= m.levels {
+		return nil, fmt.Errorf("(%v, %v, %v) is outside the grid (%v, %v, %v)", column, row, z, m.columns, m.rows, m.levels)
 	}
-	return m.cells[column][row], nil
+	return m.cells[column][row][z], nil
 }
 
-// CellBeSure returns the cell at r,c. No error handling!
+// CellBeSure returns the cell at r,c on level z. No error handling!
 func (m *Maze) CellBeSure(column, row, z int64) *Cell {
-	return m.cells[column][row]
+	return m.cells[column][row][z]
 }
 
 // CellFromLocation returns the cell at r,c
 func (m *Maze) CellFromLocation(l *pb.MazeLocation) (*Cell, error) {
-	column, row := l.X, l.Y
-
-	if column < 0 || column >= m.columns || row < 0 || row >= m.rows {
-		return nil, fmt.Errorf("(%v, %v) is outside the grid (%v, %v)", column, row, m.columns, m.rows)
-	}
-	return m.cells[column][row], nil
+	return m.Cell(l.X, l.Y, l.Z)
 }
 
 func CellMapKeys(m map[*Cell]bool) []*Cell {
@@ -1094,37 +1233,50 @@ func CellMapKeys(m map[*Cell]bool) []*Cell {
 
 // RandomCell returns a random cell out of all non-orphaned cells
 func (m *Maze) RandomCell() *Cell {
-	cells := CellMapKeys(m.Cells())
-	// cells := m.OrderedCells()
-	// cells := CellMapKeys(m.getMazeCells())
+	cells := m.OrderedCells()
 
-	return cells[utils.Random(0, len(cells))]
+	return cells[m.rand.Random(0, len(cells))]
 }
 
 // RandomCellFromList returns a random cell from the provided list of cells
 func (g *Maze) RandomCellFromList(cells []*Cell) *Cell {
-	return cells[utils.Random(0, len(cells))]
+	return cells[g.rand.Random(0, len(cells))]
+}
+
+// Rand returns the source of the random numbers of the maze, seeded with Config().Seed
+// Generators (and random solvers working on the maze) draw from it so the maze can be made again from its config.
+func (m *Maze) Rand() *utils.Rand {
+	return m.rand
+}
+
+// newCell returns a new cell of the maze, drawing from the random numbers of the maze
+func (m *Maze) newCell(x, y, z int64) *Cell {
+	c := NewCell(x, y, z, m.config)
+	c.rand = m.rand
+	return c
 }
 
 // Size returns the number of cells in the grid
 func (m *Maze) Size() int64 {
 	// No lock, does not change
-	return m.columns * m.rows
+	return m.columns * m.rows * m.levels
 }
 
 // Rows returns a list of rows (essentially the grid) - excluding the orphaned cells
 func (m *Maze) Rows() [][]*Cell {
 	rows := [][]*Cell{}
 
-	for y := m.rows - 1; y >= 0; y-- {
-		cells := []*Cell{}
-		for x := m.columns - 1; x >= 0; x-- {
-			cell, _ := m.Cell(x, y, 0)
-			if !cell.IsOrphan() {
-				cells = append(cells, cell)
+	for z := int64(0); z < m.levels; z++ {
+		for y := m.rows - 1; y >= 0; y-- {
+			cells := []*Cell{}
+			for x := m.columns - 1; x >= 0; x-- {
+				cell, _ := m.Cell(x, y, z)
+				if !cell.IsOrphan() {
+					cells = append(cells, cell)
+				}
 			}
+			rows = append(rows, cells)
 		}
-		rows = append(rows, cells)
 	}
 	return rows
 }
@@ -1133,14 +1285,16 @@ func (m *Maze) Rows() [][]*Cell {
 func (m *Maze) OrderedCells() []*Cell {
 	cells := make([]*Cell, 0)
 
-	for y := m.rows - 1; y >= 0; y-- {
-		for x := m.columns - 1; x >= 0; x-- {
-			cell, _ := m.Cell(x, y, 0)
-			if !cell.IsOrphan() {
-				cells = append(cells, cell)
+	for z := int64(0); z < m.levels; z++ {
+		for y := m.rows - 1; y >= 0; y-- {
+			for x := m.columns - 1; x >= 0; x-- {
+				cell, _ := m.Cell(x, y, z)
+				if !cell.IsOrphan() {
+					cells = append(cells, cell)
 
-				if cell.Below() != nil {
-					cells = append(cells, cell.Below())
+					if cell.Below() != nil && !cell.Below().IsOrphan() {
+						cells = append(cells, cell.Below())
+					}
 				}
 			}
 		}
@@ -1149,13 +1303,15 @@ func (m *Maze) OrderedCells() []*Cell {
 }
 
 // LargestCell returns the "largest" cell that is in the grid (not orphaned)
-// Used as "max" value in genmaze.go
+// Used as "max" value in genmaze.go; on the top level
 func (m *Maze) LargestCell() *Cell {
-	for y := m.rows - 1; y >= 0; y-- {
-		for x := m.columns - 1; x >= 0; x-- {
-			cell := m.cells[x][y]
-			if !cell.IsOrphan() {
-				return cell
+	for z := m.levels - 1; z >= 0; z-- {
+		for y := m.rows - 1; y >= 0; y-- {
+			for x := m.columns - 1; x >= 0; x-- {
+				cell := m.cells[x][y][z]
+				if !cell.IsOrphan() {
+					return cell
+				}
 			}
 		}
 	}
@@ -1168,12 +1324,14 @@ func (m *Maze) LargestCell() *Cell {
 func (m *Maze) SmallestCell() *Cell {
 	var c *Cell
 
-	for y := m.rows - 1; y >= 0; y-- {
-		for x := m.columns - 1; x >= 0; x-- {
-			cell := m.cells[x][y]
-			if !cell.IsOrphan() {
-				c = cell
+	for z := m.levels - 1; z >= 0; z-- {
+		for y := m.rows - 1; y >= 0; y-- {
+			for x := m.columns - 1; x >= 0; x-- {
+				cell := m.cells[x][y][z]
+				if !cell.IsOrphan() {
+					c = cell
 
+				}
 			}
 		}
 	}
@@ -1184,19 +1342,21 @@ func (m *Maze) SmallestCell() *Cell {
 // Cells returns a list of un-orphaned cells in the grid
 func (m *Maze) Cells() map[*Cell]bool {
 	cells := make(map[*Cell]bool)
-	for y := m.rows - 1; y >= 0; y-- {
-		for x := m.columns - 1; x >= 0; x-- {
-			cell := m.cells[x][y]
-			if !cell.IsOrphan() {
-				cells[cell] = true
-
-				if cell.Below() != nil {
-					if !cell.Below().IsOrphan() {
-						cells[cell.Below()] = true
+	for z := int64(0); z < m.levels; z++ {
+		for y := m.rows - 1; y >= 0; y-- {
+			for x := m.columns - 1; x >= 0; x-- {
+				cell := m.cells[x][y][z]
+				if !cell.IsOrphan() {
+					cells[cell] = true
+
+					if cell.Below() != nil {
+						if !cell.Below().IsOrphan() {
+							cells[cell.Below()] = true
+						}
 					}
 				}
-			}
 
+			}
 		}
 	}
 
@@ -1228,15 +1388,17 @@ func (m *Maze) OrphanCells() map[*Cell]bool {
 	}
 
 	cells := make(map[*Cell]bool)
-	for y := int64(0); y < m.rows; y++ {
-		for x := int64(0); x < m.columns; x++ {
-			cell := m.cells[x][y]
-			if cell.IsOrphan() {
-				cells[cell] = true
-
-				if cell.Below() != nil {
-					if cell.Below().IsOrphan() {
-						cells[cell.Below()] = true
+	for z := int64(0); z < m.levels; z++ {
+		for y := int64(0); y < m.rows; y++ {
+			for x := int64(0); x < m.columns; x++ {
+				cell := m.cells[x][y][z]
+				if cell.IsOrphan() {
+					cells[cell] = true
+
+					if cell.Below() != nil {
+						if cell.Below().IsOrphan() {
+							cells[cell.Below()] = true
+						}
 					}
 				}
 			}
@@ -1247,6 +1409,22 @@ func (m *Maze) OrphanCells() map[*Cell]bool {
 	return cells
 }
 
+// ApplyOrphanMask orphans the cells at the locations in mask, like the OrphanMask of the config does
+// for mazes that are generated with their orphans; the config is not changed.
+func (m *Maze) ApplyOrphanMask(mask []*pb.MazeLocation) error {
+	for _, o := range mask {
+		cell, err := m.CellFromLocation(o)
+		if err != nil {
+			return err
+		}
+		cell.Orphan()
+	}
+
+	// OrphanCells finds them again
+	m.setOrphanMazeCells(make(map[*Cell]bool))
+	return nil
+}
+
 func (m *Maze) getOrphanMazeCells() map[*Cell]bool {
 	m.RLock()
 	defer m.RUnlock()
@@ -1264,7 +1442,7 @@ func (m *Maze) setOrphanMazeCells(cells map[*Cell]bool) {
 func (m *Maze) UnvisitedCells(client string) []*Cell {
 	cells := []*Cell{}
 
-	for cell := range m.Cells() {
+	for _, cell := range m.OrderedCells() {
 		if !cell.Visited(client) {
 			cells = append(cells, cell)
 		}
@@ -1279,8 +1457,8 @@ func (m *Maze) ConnectCells(cells []*Cell) {
 	for x := 0; x < len(cells)-1; x++ {
 		cell := cells[x]
 		// no lock, does not change
-		for _, n := range []*Cell{cell.North(), cell.South(), cell.East(), cell.West()} {
-			if n == cells[x+1] {
+		for _, d := range cell.Directions() {
+			if n := cell.Neighbor(d); n != nil && n == cells[x+1] {
 				m.Link(cell, n)
 				break
 			}
@@ -1342,6 +1520,8 @@ func (m *Maze) SetClientPath(client *client) {
 
 		s.Cell().SetPaths(client, prev, next)
 	}
+
+	m.recordClientStep(client)
 }
 
 // ShortestPath finds the shortest path from fromCell to toCell
@@ -1411,10 +1591,7 @@ func (m *Maze) SetDistanceInfo(client *client, c *Cell) {
 		// dColor := d - int(cell.Weight()) // ignore weights when coloring distance
 
 		if m.config.ShowDistanceColors {
-			// decrease bridghtnessAdjustto make the longest cells brighter. max = 255 (good = 228)
-			bridghtnessAdjust := 228
-			adjustedColor := bridghtnessAdjust - utils.AffineTransform(float64(d), 0, float64(longestPath), 0, float64(bridghtnessAdjust))
-			cell.SetBGColor(colors.OpacityAdjust(m.bgColor, adjustedColor))
+			cell.SetBGColor(m.distanceColor(d, longestPath))
 		}
 
 		cell.SetDistance(d)
@@ -1423,11 +1600,20 @@ func (m *Maze) SetDistanceInfo(client *client, c *Cell) {
 	m.SetFromCell(client, c)
 }
 
+// distanceColor returns the background color of a cell d away from the start, longest is the furthest distance
+// Uses alpha blending, works for any color.
+func (m *Maze) distanceColor(d, longest int) colors.Color {
+	// decrease bridghtnessAdjustto make the longest cells brighter. max = 255 (good = 228)
+	bridghtnessAdjust := 228
+	adjustedColor := bridghtnessAdjust - utils.AffineTransform(float64(d), 0, float64(longest), 0, float64(bridghtnessAdjust))
+	return colors.OpacityAdjust(m.bgColor, adjustedColor)
+}
+
 // DeadEnds returns a list of cells that are deadends (only linked to one neighbor)
 func (m *Maze) DeadEnds() []*Cell {
 	var deadends []*Cell
 
-	for cell := range m.Cells() {
+	for _, cell := range m.OrderedCells() {
 		if len(cell.Links()) == 1 {
 			deadends = append(deadends, cell)
 		}
@@ -1457,21 +1643,7 @@ func (m *Maze) resetDistances() {
 }
 
 // GetFacingDirection returns the direction walker was facing when moving fromCell -> toCell
-// north, south, east, west
+// north, south, east, west, ...
 func (m *Maze) GetFacingDirection(fromCell, toCell *Cell) string {
-	facing := ""
-
-	if fromCell.North() == toCell {
-		facing = "north"
-	}
-	if fromCell.East() == toCell {
-		facing = "east"
-	}
-	if fromCell.West() == toCell {
-		facing = "west"
-	}
-	if fromCell.South() == toCell {
-		facing = "south"
-	}
-	return facing
+	return fromCell.GetFacingDirection(toCell)
 }
@@ -1,12 +1,21 @@
 package maze
 
 import (
+	"bytes"
+	"image/gif"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 
 	"fmt"
 
+	"github.com/DanTulovsky/mazes/colors"
 	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/DanTulovsky/mazes/render"
 	"github.com/DanTulovsky/mazes/utils"
 )
 
@@ -285,6 +294,1055 @@ func TestCellDecode(t *testing.T) {
 		}
 	}
 }
+
+var hexneighbortests = []struct {
+	x, y      int64
+	neighbors map[string]*pb.MazeLocation
+}{
+	{
+		// even column, shifted up
+		x: 2, y: 2,
+		neighbors: map[string]*pb.MazeLocation{
+			"north":     {X: 2, Y: 1},
+			"south":     {X: 2, Y: 3},
+			"northeast": {X: 3, Y: 1},
+			"northwest": {X: 1, Y: 1},
+			"southeast": {X: 3, Y: 2},
+			"southwest": {X: 1, Y: 2},
+		},
+	}, {
+		// odd column, shifted down
+		x: 3, y: 2,
+		neighbors: map[string]*pb.MazeLocation{
+			"north":     {X: 3, Y: 1},
+			"south":     {X: 3, Y: 3},
+			"northeast": {X: 4, Y: 2},
+			"northwest": {X: 2, Y: 2},
+			"southeast": {X: 4, Y: 3},
+			"southwest": {X: 2, Y: 3},
+		},
+	}, {
+		// corner
+		x: 0, y: 0,
+		neighbors: map[string]*pb.MazeLocation{
+			"north":     nil,
+			"south":     {X: 0, Y: 1},
+			"northeast": nil,
+			"northwest": nil,
+			"southeast": {X: 1, Y: 0},
+			"southwest": nil,
+		},
+	},
+}
+
+func TestHexNeighbors(t *testing.T) {
+	m, err := NewMaze(&pb.MazeConfig{Rows: 6, Columns: 6, GridType: GridHex}, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	for _, tt := range hexneighbortests {
+		cell, err := m.Cell(tt.x, tt.y, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(cell.Directions()) != 6 {
+			t.Errorf("expected 6 directions, but have %v", cell.Directions())
+		}
+
+		for d, want := range tt.neighbors {
+			n := cell.Neighbor(d)
+			if want == nil {
+				if n != nil {
+					t.Errorf("%v: expected no neighbor %v, but have %v", cell, d, n)
+				}
+				continue
+			}
+			if n == nil || !utils.LocsSame(n.Location(), want) {
+				t.Errorf("%v: expected neighbor %v to be %v, but have %v", cell, d, want, n)
+				continue
+			}
+			// neighbors must point back
+			if !CellInCellList(cell, n.Neighbors()) {
+				t.Errorf("%v: neighbor %v (%v) does not point back", cell, d, n)
+			}
+		}
+	}
+}
+
+func TestHexEncodeDecode(t *testing.T) {
+	config := &pb.MazeConfig{Rows: 5, Columns: 7, GridType: GridHex}
+	m, err := NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	// carve a passage in every direction out of one cell
+	cell, _ := m.Cell(3, 2, 0)
+	for _, d := range cell.Directions() {
+		m.Link(cell, cell.Neighbor(d))
+	}
+
+	if e := cell.Encode(); e != "3F" {
+		t.Errorf("expected cell encoding 3F, but have %v", e)
+	}
+
+	e, err := m.Encode()
+	if err != nil {
+		t.Fatalf("failed to encode maze: %v", err)
+	}
+	if m.rows*m.columns*2+m.rows != int64(len(e)) {
+		t.Errorf("expected encoding of length %v, but have %v.", m.rows*m.columns*2+m.rows, len(e))
+	}
+
+	m2, err := NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	if err := m2.Decode(e); err != nil {
+		t.Fatalf("error decoding: %v", err)
+	}
+
+	e2, err := m2.Encode()
+	if err != nil {
+		t.Fatalf("failed to encode maze: %v", err)
+	}
+	if e != e2 {
+		t.Errorf("decoded maze does not match, expected:\n%v\nhave:\n%v", e, e2)
+	}
+}
+
+func TestHexMoveClient(t *testing.T) {
+	m, err := NewMaze(&pb.MazeConfig{Rows: 5, Columns: 5, GridType: GridHex}, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	from, _ := m.Cell(1, 1, 0)
+	m.Link(from, from.NorthEast())
+
+	c := &client{id: "test", TravelPath: NewPath(), config: &pb.ClientConfig{}}
+	c.SetCurrentLocation(from)
+	m.clients[c.id] = c
+
+	if _, err := m.MoveClient(c.id, "southwest"); err == nil {
+		t.Errorf("expected error moving through a wall")
+	}
+	if _, err := m.MoveClient(c.id, "east"); err == nil {
+		t.Errorf("expected error moving in a direction not on the grid")
+	}
+	if _, err := m.MoveClient(c.id, "northeast"); err != nil {
+		t.Errorf("failed to move: %v", err)
+	}
+	if c.CurrentLocation() != from.NorthEast() {
+		t.Errorf("expected to be at %v, but at %v", from.NorthEast(), c.CurrentLocation())
+	}
+}
+
+func TestPolarGrid(t *testing.T) {
+	var rings int64 = 8
+
+	m, err := NewMaze(&pb.MazeConfig{Rows: rings, GridType: GridPolar}, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	sizes := polarRingSizes(rings)
+	if sizes[0] != 1 {
+		t.Errorf("expected a single cell in the center, but have %v", sizes[0])
+	}
+
+	var total int64
+	for y, size := range sizes {
+		if y > 0 && size < sizes[y-1] {
+			t.Errorf("ring %v has fewer cells (%v) than the ring inside it (%v)", y, size, sizes[y-1])
+		}
+		total += size
+	}
+	if int64(len(m.Cells())) != total {
+		t.Errorf("expected %v cells, but have %v", total, len(m.Cells()))
+	}
+
+	for cell := range m.Cells() {
+		l := cell.Location()
+
+		if (l.Y == 0) != (cell.Inward() == nil) {
+			t.Errorf("%v: only the center cell should have no inward neighbor", cell)
+		}
+		if (l.Y == rings-1) != (len(cell.Outward()) == 0) {
+			t.Errorf("%v: only the outer ring should have no outward neighbors", cell)
+		}
+
+		for _, d := range cell.Directions() {
+			n := cell.Neighbor(d)
+			if n == nil {
+				continue
+			}
+			if n.IsOrphan() {
+				t.Errorf("%v: neighbor %v (%v) is an orphan", cell, d, n)
+			}
+			if !CellInCellList(cell, n.Neighbors()) {
+				t.Errorf("%v: neighbor %v (%v) does not point back", cell, d, n)
+			}
+		}
+	}
+}
+
+func TestPolarEncodeDecode(t *testing.T) {
+	config := &pb.MazeConfig{Rows: 5, GridType: GridPolar}
+	m, err := NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	// carve a passage in every direction out of a few cells
+	for _, l := range []*pb.MazeLocation{{X: 0, Y: 0}, {X: 3, Y: 2}, {X: 0, Y: 3}} {
+		cell, err := m.CellFromLocation(l)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range cell.Directions() {
+			if n := cell.Neighbor(d); n != nil {
+				m.Link(cell, n)
+			}
+		}
+	}
+
+	e, err := m.Encode()
+	if err != nil {
+		t.Fatalf("failed to encode maze: %v", err)
+	}
+	if m.rows*m.columns+m.rows != int64(len(e)) {
+		t.Errorf("expected encoding of length %v, but have %v.", m.rows*m.columns+m.rows, len(e))
+	}
+
+	m2, err := NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	if err := m2.Decode(e); err != nil {
+		t.Fatalf("error decoding: %v", err)
+	}
+
+	for cell := range m.Cells() {
+		cell2, err := m2.CellFromLocation(cell.Location())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cell.Links()) != len(cell2.Links()) {
+			t.Errorf("%v: expected %v links after decoding, but have %v", cell, len(cell.Links()), len(cell2.Links()))
+		}
+	}
+}
+
+func TestDeltaGrid(t *testing.T) {
+	config := &pb.MazeConfig{Rows: 4, Columns: 7, GridType: GridDelta}
+	m, err := NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	for cell := range m.Cells() {
+		directions := cell.Directions()
+		if len(directions) != 3 {
+			t.Errorf("%v: expected 3 directions, but have %v", cell, directions)
+		}
+
+		l := cell.Location()
+		if cell.upright() {
+			if cell.North() != nil || (l.Y < m.rows-1) != (cell.South() != nil) {
+				t.Errorf("%v: triangle pointing up should only have a south neighbor", cell)
+			}
+		} else {
+			if cell.South() != nil || (l.Y > 0) != (cell.North() != nil) {
+				t.Errorf("%v: triangle pointing down should only have a north neighbor", cell)
+			}
+		}
+
+		for _, d := range directions {
+			n := cell.Neighbor(d)
+			if n == nil {
+				continue
+			}
+			if n.upright() == cell.upright() {
+				t.Errorf("%v: neighbor %v (%v) points the same way", cell, d, n)
+			}
+			if !CellInCellList(cell, n.Neighbors()) {
+				t.Errorf("%v: neighbor %v (%v) does not point back", cell, d, n)
+			}
+		}
+
+		// link everything
+		for _, n := range cell.Neighbors() {
+			m.Link(cell, n)
+		}
+	}
+
+	cell, _ := m.Cell(3, 1, 0)
+	if e := cell.Encode(); e != "7" {
+		t.Errorf("expected cell encoding 7, but have %v", e)
+	}
+
+	e, err := m.Encode()
+	if err != nil {
+		t.Fatalf("failed to encode maze: %v", err)
+	}
+	if m.rows*m.columns+m.rows != int64(len(e)) {
+		t.Errorf("expected encoding of length %v, but have %v.", m.rows*m.columns+m.rows, len(e))
+	}
+
+	m2, err := NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	if err := m2.Decode(e); err != nil {
+		t.Fatalf("error decoding: %v", err)
+	}
+	if e2, _ := m2.Encode(); e != e2 {
+		t.Errorf("decoded maze does not match, expected:\n%v\nhave:\n%v", e, e2)
+	}
+}
+
+func TestInvalidGridType(t *testing.T) {
+	if _, err := NewMaze(&pb.MazeConfig{Rows: 5, Columns: 5, GridType: "octagon"}, nil); err == nil {
+		t.Errorf("expected error creating maze with invalid grid type")
+	}
+	if _, err := NewMaze(&pb.MazeConfig{Rows: 5, Columns: 5, GridType: GridHex, AllowWeaving: true}, nil); err == nil {
+		t.Errorf("expected error creating hex maze with weaving")
+	}
+	if _, err := NewMaze(&pb.MazeConfig{Rows: 5, GridType: GridPolar, AllowWeaving: true}, nil); err == nil {
+		t.Errorf("expected error creating polar maze with weaving")
+	}
+	if _, err := NewMaze(&pb.MazeConfig{Rows: 5, Columns: 5, GridType: GridDelta, AllowWeaving: true}, nil); err == nil {
+		t.Errorf("expected error creating delta maze with weaving")
+	}
+}
+
+func TestLevels(t *testing.T) {
+	config := &pb.MazeConfig{Rows: 4, Columns: 5, Levels: 3}
+	m, err := NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	if m.Size() != 4*5*3 {
+		t.Errorf("expected %v cells, but have %v", 4*5*3, m.Size())
+	}
+	if len(m.Cells()) != 4*5*3 {
+		t.Errorf("expected %v cells in the maze, but have %v", 4*5*3, len(m.Cells()))
+	}
+	if _, err := m.Cell(0, 0, 3); err == nil {
+		t.Errorf("expected error getting cell on level 3")
+	}
+
+	for cell := range m.Cells() {
+		l := cell.Location()
+		if (l.Z < 2) != (cell.Up() != nil) {
+			t.Errorf("%v: up neighbor is %v", cell, cell.Up())
+		}
+		if (l.Z > 0) != (cell.Down() != nil) {
+			t.Errorf("%v: down neighbor is %v", cell, cell.Down())
+		}
+		if cell.Up() != nil && cell.Up().Down() != cell {
+			t.Errorf("%v: up neighbor (%v) does not point back", cell, cell.Up())
+		}
+		if n := cell.North(); n != nil && n.Location().Z != l.Z {
+			t.Errorf("%v: north neighbor (%v) is on a different level", cell, n)
+		}
+	}
+
+	if c := m.LargestCell(); c.Location().Z != 2 {
+		t.Errorf("expected largest cell on the top level, but have %v", c)
+	}
+	if c := m.SmallestCell(); c.Location().Z != 0 {
+		t.Errorf("expected smallest cell on level 0, but have %v", c)
+	}
+}
+
+func TestLevelsEncodeDecode(t *testing.T) {
+	config := &pb.MazeConfig{Rows: 3, Columns: 4, Levels: 2}
+	m, err := NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	// carve a passage in every direction out of one cell
+	cell, _ := m.Cell(1, 1, 0)
+	for _, d := range cell.Directions() {
+		if n := cell.Neighbor(d); n != nil {
+			m.Link(cell, n)
+		}
+	}
+
+	if e := cell.Encode(); e != "3E" {
+		t.Errorf("expected cell encoding 3E, but have %v", e)
+	}
+
+	e, err := m.Encode()
+	if err != nil {
+		t.Fatalf("failed to encode maze: %v", err)
+	}
+	expected := "00100000\n083E0400\n00200000\n\n00000000\n00010000\n00000000\n"
+	if e != expected {
+		t.Errorf("expected encoding:\n%v\nhave:\n%v", expected, e)
+	}
+
+	columns, rows, levels := EncodedDimensions(config, e)
+	if columns != 4 || rows != 3 || levels != 2 {
+		t.Errorf("expected dimensions (4, 3, 2), but have (%v, %v, %v)", columns, rows, levels)
+	}
+
+	m2, err := NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	if err := m2.Decode(e); err != nil {
+		t.Fatalf("error decoding: %v", err)
+	}
+	if e2, _ := m2.Encode(); e != e2 {
+		t.Errorf("decoded maze does not match, expected:\n%v\nhave:\n%v", e, e2)
+	}
+}
+
+func TestInvalidLevels(t *testing.T) {
+	if _, err := NewMaze(&pb.MazeConfig{Rows: 5, Columns: 5, Levels: -1}, nil); err == nil {
+		t.Errorf("expected error creating maze with negative levels")
+	}
+	if _, err := NewMaze(&pb.MazeConfig{Rows: 5, Columns: 5, Levels: 2, GridType: GridHex}, nil); err == nil {
+		t.Errorf("expected error creating hex maze with levels")
+	}
+	if _, err := NewMaze(&pb.MazeConfig{Rows: 5, Columns: 5, Levels: 2, AllowWeaving: true}, nil); err == nil {
+		t.Errorf("expected error creating maze with levels and weaving")
+	}
+	if _, err := NewMaze(&pb.MazeConfig{Rows: 5, Columns: 5, Levels: 2, LevelView: "stacked"}, nil); err == nil {
+		t.Errorf("expected error creating maze with invalid level view")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	config := &pb.MazeConfig{Rows: 4, Columns: 5, Wrap: WrapBoth}
+	m, err := NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	for cell := range m.Cells() {
+		if len(cell.Neighbors()) != 4 {
+			t.Errorf("%v: expected 4 neighbors on a torus, but have %v", cell, len(cell.Neighbors()))
+		}
+		for _, d := range cell.Directions() {
+			if n := cell.Neighbor(d); !CellInCellList(cell, n.Neighbors()) {
+				t.Errorf("%v: neighbor %v (%v) does not point back", cell, d, n)
+			}
+		}
+	}
+
+	corner, _ := m.Cell(0, 0, 0)
+	if !corner.Wraps("north") || !corner.Wraps("west") || corner.Wraps("south") || corner.Wraps("east") {
+		t.Errorf("%v: expected only north and west to wrap", corner)
+	}
+	if n := corner.NeighborNoWrap("west"); n != nil {
+		t.Errorf("%v: expected no west neighbor without wrapping, but have %v", corner, n)
+	}
+	if w := corner.West(); w != m.CellBeSure(4, 0, 0) {
+		t.Errorf("%v: expected west neighbor (4, 0, 0), but have %v", corner, w)
+	}
+
+	// link everything, the shortest path goes around the edge
+	for cell := range m.Cells() {
+		for _, n := range cell.Neighbors() {
+			m.Link(cell, n)
+		}
+	}
+	_, path := m.ShortestPath(corner, m.CellBeSure(4, 3, 0))
+	if path.Length() != 3 {
+		t.Errorf("expected a path of 3 cells around the edges, but have %v", path.Length())
+	}
+}
+
+func TestWrapHorizontal(t *testing.T) {
+	m, err := NewMaze(&pb.MazeConfig{Rows: 4, Columns: 5, Wrap: WrapHorizontal}, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	cell, _ := m.Cell(4, 0, 0)
+	if cell.East() != m.CellBeSure(0, 0, 0) {
+		t.Errorf("%v: expected east neighbor (0, 0, 0), but have %v", cell, cell.East())
+	}
+	if cell.North() != nil {
+		t.Errorf("%v: expected no north neighbor, but have %v", cell, cell.North())
+	}
+}
+
+func TestInvalidWrap(t *testing.T) {
+	if _, err := NewMaze(&pb.MazeConfig{Rows: 5, Columns: 5, Wrap: "sideways"}, nil); err == nil {
+		t.Errorf("expected error creating maze with invalid wrap")
+	}
+	if _, err := NewMaze(&pb.MazeConfig{Rows: 5, Columns: 5, Wrap: WrapBoth, GridType: GridHex}, nil); err == nil {
+		t.Errorf("expected error creating hex maze with wrap")
+	}
+	if _, err := NewMaze(&pb.MazeConfig{Rows: 5, Columns: 5, Wrap: WrapBoth, AllowWeaving: true}, nil); err == nil {
+		t.Errorf("expected error creating maze with wrap and weaving")
+	}
+	if _, err := NewMaze(&pb.MazeConfig{Rows: 5, Columns: 2, Wrap: WrapHorizontal}, nil); err == nil {
+		t.Errorf("expected error creating maze wrapping around 2 columns")
+	}
+	if _, err := NewMaze(&pb.MazeConfig{Rows: 2, Columns: 5, Wrap: WrapVertical}, nil); err == nil {
+		t.Errorf("expected error creating maze wrapping around 2 rows")
+	}
+}
+
+var drawimagetests = []*pb.MazeConfig{
+	{Rows: 4, Columns: 5},
+	{Rows: 4, Columns: 5, GridType: GridHex},
+	{Rows: 4, Columns: 5, GridType: GridPolar},
+	{Rows: 4, Columns: 5, GridType: GridDelta},
+	{Rows: 4, Columns: 5, Levels: 2},
+	{Rows: 4, Columns: 5, Wrap: WrapBoth},
+}
+
+func TestDrawImage(t *testing.T) {
+	for _, config := range drawimagetests {
+		config.CellWidth = 20
+		config.WallWidth = 2
+		config.PathWidth = 2
+		config.BgColor = "white"
+		config.BorderColor = "red"
+		config.WallColor = "black"
+		config.ShowDistanceValues = true
+
+		w, h := WindowSize(config)
+		r := render.NewImage(int(w), int(h))
+
+		m, err := NewMaze(config, r)
+		if err != nil {
+			t.Fatalf("invalid config: %v", err)
+		}
+
+		bg, err := m.MakeBGTexture()
+		if err != nil {
+			t.Fatalf("%v: error making background: %v", config, err)
+		}
+		m.SetBGTexture(bg)
+		m.DrawMaze(r, m.BGTexture())
+
+		// nothing is linked yet, so there must be walls all over the place
+		img := r.RGBA()
+		walls := 0
+		for x := 0; x < int(w); x++ {
+			for y := 0; y < int(h); y++ {
+				if c := img.RGBAAt(x, y); c.R == 0 && c.G == 0 && c.B == 0 {
+					walls++
+				}
+			}
+		}
+		if walls == 0 {
+			t.Errorf("%v: no walls drawn", config)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	config := &pb.MazeConfig{Rows: 4, Columns: 5, CellWidth: 20, WallWidth: 2, PathWidth: 2,
+		BgColor: "white", BorderColor: "black", WallColor: "black"}
+	m, err := NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	// every row is a corridor, all connected on the west edge
+	for cell := range m.Cells() {
+		if cell.East() != nil {
+			m.Link(cell, cell.East())
+		}
+		if cell.x == 0 && cell.South() != nil {
+			m.Link(cell, cell.South())
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := m.Render(&buf, "jpeg", RenderOptions{}); err == nil {
+		t.Errorf("expected error rendering an unknown format")
+	}
+	if err := m.RenderPNG(&buf, RenderOptions{DistanceColors: true}); err == nil {
+		t.Errorf("expected error rendering distance colors without a client")
+	}
+	if err := m.RenderPNG(&buf, RenderOptions{ClientID: "missing"}); err == nil {
+		t.Errorf("expected error rendering an unknown client")
+	}
+
+	from, _, err := m.AddClient("test", &pb.ClientConfig{FromCell: "0,0", ToCell: "4,3", PathColor: "red", DrawPathLength: -1})
+	if err != nil {
+		t.Fatalf("failed to add client: %v", err)
+	}
+	c, _ := m.Client("test")
+	c.SetCurrentLocation(from)
+	c.TravelPath.AddSegement(NewSegment(from, "north", true))
+	for _, d := range []string{"east", "east"} {
+		if _, err := m.MoveClient("test", d); err != nil {
+			t.Fatalf("failed to move: %v", err)
+		}
+	}
+	opts := RenderOptions{ClientID: "test", DistanceColors: true}
+	bgColor := from.East().BGColor()
+
+	buf.Reset()
+	if err := m.RenderPNG(&buf, opts); err != nil {
+		t.Fatalf("failed to render png: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("invalid png: %v", err)
+	}
+	if w, h := WindowSize(config); img.Bounds().Dx() != int(w) || img.Bounds().Dy() != int(h) {
+		t.Errorf("expected a %vx%v image, but have %v", w, h, img.Bounds())
+	}
+	// the path goes through the second cell
+	red := 0
+	cell := from.East()
+	for x := cell.left() + cell.wallWidth; x < cell.left()+cell.wallWidth+cell.width; x++ {
+		for y := cell.y*cell.width + cell.wallWidth; y < (cell.y+1)*cell.width+cell.wallWidth; y++ {
+			if r, g, b, _ := img.At(int(x), int(y)).RGBA(); r>>8 == 255 && g == 0 && b == 0 {
+				red++
+			}
+		}
+	}
+	if red == 0 {
+		t.Errorf("expected the path to go through %v", cell)
+	}
+	if from.East().BGColor() != bgColor {
+		t.Errorf("rendering distance colors changed the color of the cell")
+	}
+
+	buf.Reset()
+	if err := m.RenderSVG(&buf, opts); err != nil {
+		t.Fatalf("failed to render svg: %v", err)
+	}
+	if svg := buf.String(); !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, `fill="#ff0000"`) {
+		t.Errorf("expected an svg document with a red path, but have: %v", svg)
+	}
+}
+
+func TestDistanceColors(t *testing.T) {
+	config := &pb.MazeConfig{Rows: 2, Columns: 2, BgColor: "white"}
+	m, err := NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	for cell := range m.Cells() {
+		cell.SetWeight(1)
+	}
+	a, b, c, d := m.CellBeSure(0, 0, 0), m.CellBeSure(1, 0, 0), m.CellBeSure(1, 1, 0), m.CellBeSure(0, 1, 0)
+	m.Link(a, b)
+	m.Link(b, c)
+	m.Link(c, d)
+
+	if _, _, err := m.AddClient("test", &pb.ClientConfig{FromCell: "0,0", ToCell: "0,1"}); err != nil {
+		t.Fatalf("failed to add client: %v", err)
+	}
+	client, _ := m.Client("test")
+	a.Distances() // cached, d is 3 away
+
+	// the passages change, d is now next to a
+	c.UnLink(d)
+	m.Link(a, d)
+
+	bgColors := m.distanceColors(client)
+	for cell, want := range map[*Cell]colors.Color{
+		a: m.distanceColor(0, 2), b: m.distanceColor(1, 2), c: m.distanceColor(2, 2), d: m.distanceColor(1, 2)} {
+		if bgColors[cell] != want {
+			t.Errorf("%v has color %v, want %v", cell, bgColors[cell], want)
+		}
+	}
+}
+
+func TestRecordGenerator(t *testing.T) {
+	dir := t.TempDir()
+	config := &pb.MazeConfig{Rows: 3, Columns: 3, CellWidth: 10, WallWidth: 2,
+		Record: &pb.RecordConfig{File: filepath.Join(dir, "gen.gif"), FrameSkip: 1}}
+	m, err := NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	// 8 links and 8 location updates, every other one is recorded
+	cell := m.CellBeSure(0, 0, 0)
+	for _, d := range []string{"east", "east", "south", "west", "west", "south", "east", "east"} {
+		next := cell.Neighbor(d)
+		m.SetGenCurrentLocation(next)
+		m.Link(cell, next)
+		cell = next
+	}
+	if err := m.SaveGenRecording(); err != nil {
+		t.Fatalf("error saving recording: %v", err)
+	}
+
+	f, err := os.Open(config.Record.File)
+	if err != nil {
+		t.Fatalf("recording not saved: %v", err)
+	}
+	defer f.Close()
+	g, err := gif.DecodeAll(f)
+	if err != nil {
+		t.Fatalf("invalid gif: %v", err)
+	}
+	// plus the final frame
+	if len(g.Image) != 9 {
+		t.Errorf("expected 9 frames, but have %v", len(g.Image))
+	}
+}
+
+func TestRecordMaxFrames(t *testing.T) {
+	dir := t.TempDir()
+	config := &pb.MazeConfig{Rows: 3, Columns: 3, CellWidth: 10, WallWidth: 2,
+		Record: &pb.RecordConfig{File: filepath.Join(dir, "gen.png"), MaxFrames: 3}}
+	m, err := NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	for cell := range m.Cells() {
+		m.SetGenCurrentLocation(cell)
+	}
+
+	// saved as soon as it is full, without calling SaveGenRecording
+	data, err := ioutil.ReadFile(config.Record.File)
+	if err != nil {
+		t.Fatalf("recording not saved: %v", err)
+	}
+	if n := bytes.Count(data, []byte("fcTL")); n != 3 {
+		t.Errorf("expected an apng with 3 frames, but have %v", n)
+	}
+}
+
+func TestRecordClient(t *testing.T) {
+	dir := t.TempDir()
+	m, err := NewMaze(&pb.MazeConfig{Rows: 2, Columns: 5, CellWidth: 10, WallWidth: 2}, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	for cell := range m.Cells() {
+		if cell.East() != nil {
+			m.Link(cell, cell.East())
+		}
+	}
+
+	file := filepath.Join(dir, "solve.gif")
+	from, _, err := m.AddClient("test", &pb.ClientConfig{FromCell: "0,0", ToCell: "4,0", DrawPathLength: -1,
+		Record: &pb.RecordConfig{File: file}})
+	if err != nil {
+		t.Fatalf("failed to add client: %v", err)
+	}
+	c, _ := m.Client("test")
+	c.SetCurrentLocation(from)
+	c.TravelPath.AddSegement(NewSegment(from, "north", true))
+
+	for x := 0; x < 4; x++ {
+		if _, err := os.Stat(file); err == nil {
+			t.Fatalf("recording saved before the client solved the maze")
+		}
+		if _, err := m.MoveClient("test", "east"); err != nil {
+			t.Fatalf("failed to move: %v", err)
+		}
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatalf("recording not saved when solved: %v", err)
+	}
+	defer f.Close()
+	g, err := gif.DecodeAll(f)
+	if err != nil {
+		t.Fatalf("invalid gif: %v", err)
+	}
+	if len(g.Image) != 4 {
+		t.Errorf("expected 4 frames, but have %v", len(g.Image))
+	}
+}
+
+var invalidrecordtests = []*pb.RecordConfig{
+	{File: "maze.gif", Format: "mp4"},
+	{File: "maze.gif", FrameSkip: -1},
+	{File: "maze.gif", MaxFrames: -1},
+	{File: "maze.gif", FrameDelay: "soon"},
+}
+
+func TestInvalidRecord(t *testing.T) {
+	for _, tt := range invalidrecordtests {
+		if _, err := NewMaze(&pb.MazeConfig{Rows: 3, Columns: 3, Record: tt}, nil); err == nil {
+			t.Errorf("%v: expected error", tt)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	config := &pb.MazeConfig{Rows: 3, Columns: 4, CellWidth: 20, WallWidth: 2, PathWidth: 2,
+		BgColor: "white", BorderColor: "black", WallColor: "black", OrphanMask: []*pb.MazeLocation{{X: 3, Y: 2}}}
+	m, err := NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	// every row is a corridor, all connected on the west edge
+	for cell := range m.Cells() {
+		if cell.East() != nil && !cell.East().IsOrphan() {
+			m.Link(cell, cell.East())
+		}
+		if cell.x == 0 && cell.South() != nil {
+			m.Link(cell, cell.South())
+		}
+	}
+
+	if _, err := m.Text(TextOptions{Path: true}); err == nil {
+		t.Errorf("expected error drawing the path without a client")
+	}
+	if _, err := m.Text(TextOptions{ClientID: "missing"}); err == nil {
+		t.Errorf("expected error drawing an unknown client")
+	}
+
+	from, _, err := m.AddClient("test", &pb.ClientConfig{FromCell: "0,0", ToCell: "2,1"})
+	if err != nil {
+		t.Fatalf("failed to add client: %v", err)
+	}
+	c, _ := m.Client("test")
+	c.SetCurrentLocation(from)
+	c.TravelPath.AddSegement(NewSegment(from, "north", true))
+	for _, d := range []string{"south", "east", "east"} {
+		if _, err := m.MoveClient("test", d); err != nil {
+			t.Fatalf("failed to move: %v", err)
+		}
+	}
+
+	var texttests = []struct {
+		opts     TextOptions
+		expected string
+	}{
+		{
+			opts: TextOptions{},
+			expected: `
+┌───────────────┐
+│               │
+│   ╶───────────┤
+│               │
+│   ╶───────┬───┘
+│           │░░░
+└───────────┘
+`,
+		},
+		{
+			opts: TextOptions{ClientID: "test", Path: true, Clients: true},
+			expected: `
+┌───────────────┐
+│ ·             │
+│ · ╶───────────┤
+│ · · · · @     │
+│   ╶───────┬───┘
+│           │░░░
+└───────────┘
+`,
+		},
+		{
+			opts: TextOptions{ClientID: "test", Visited: true, ASCII: true},
+			expected: `
++---+---+---+---+
+|               |
++   +---+---+---+
+|  1   1   1    |
++   +---+---+---+
+|           |###
++---+---+---+
+`,
+		},
+		{
+			opts: TextOptions{ClientID: "test", Path: true, Clients: true, CellWidth: 1},
+			expected: `
+┌───────┐
+│·      │
+│·╶─────┤
+│····@  │
+│ ╶───┬─┘
+│     │░
+└─────┘
+`,
+		},
+	}
+
+	for _, tt := range texttests {
+		text, err := m.Text(tt.opts)
+		if err != nil {
+			t.Fatalf("failed to draw text: %v", err)
+		}
+		if text != strings.TrimPrefix(tt.expected, "\n") {
+			t.Errorf("%+v: expected:\n%v\nhave:\n%v", tt.opts, tt.expected, text)
+		}
+	}
+}
+
+func TestProto(t *testing.T) {
+	config := &pb.MazeConfig{Rows: 3, Columns: 3, CellWidth: 20, WallWidth: 2, WallSpace: 4, PathWidth: 2,
+		AllowWeaving: true, OrphanMask: []*pb.MazeLocation{{X: 2, Y: 2}}}
+	m, err := NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	cell := func(x, y, z int64) *Cell {
+		c, err := m.Cell(x, y, z)
+		if err != nil {
+			t.Fatalf("no cell: %v", err)
+		}
+		return c
+	}
+
+	// a tunnel from (1,0) to (1,2) under (1,1), which is a passage from (0,1) to (2,1)
+	m.Link(cell(0, 1, 0), cell(1, 1, 0))
+	m.Link(cell(1, 1, 0), cell(2, 1, 0))
+	m.Link(cell(1, 0, 0), cell(1, 2, 0))
+	m.Link(cell(0, 0, 0), cell(1, 0, 0))
+	cell(2, 0, 0).SetWeight(5)
+	if cell(1, 1, 0).Below() == nil {
+		t.Fatalf("expected a tunnel under (1,1)")
+	}
+
+	from, _, err := m.AddClient("test", &pb.ClientConfig{FromCell: "0,0", ToCell: "2,1", DrawPathLength: -1})
+	if err != nil {
+		t.Fatalf("failed to add client: %v", err)
+	}
+	client, _ := m.Client("test")
+	client.SetCurrentLocation(from)
+	client.TravelPath.AddSegement(NewSegment(from, "north", true))
+	from.SetVisited("test")
+	// east, then through the tunnel and back
+	for _, d := range []string{"east", "south", "north"} {
+		if _, err := m.MoveClient("test", d); err != nil {
+			t.Fatalf("failed to move: %v", err)
+		}
+	}
+
+	pm := m.Proto()
+	if len(pm.GetCells()) != 9 {
+		t.Errorf("expected 9 cells, but have %v", len(pm.GetCells()))
+	}
+	if len(pm.GetClients()) != 1 || pm.GetClients()[0].GetClientId() != "test" {
+		t.Errorf("expected client test, but have %v", pm.GetClients())
+	}
+
+	c, err := NewMazeFromProto(pm, nil)
+	if err != nil {
+		t.Fatalf("failed to create maze from proto: %v", err)
+	}
+	for y := int64(0); y < 3; y++ {
+		for x := int64(0); x < 3; x++ {
+			want, _ := m.Cell(x, y, 0)
+			have, _ := c.Cell(x, y, 0)
+			if want.Encode() != have.Encode() || want.Weight() != have.Weight() || want.IsOrphan() != have.IsOrphan() {
+				t.Errorf("cell %v: expected %v (weight %v, orphan %v), but have %v (weight %v, orphan %v)", want,
+					want.Encode(), want.Weight(), want.IsOrphan(), have.Encode(), have.Weight(), have.IsOrphan())
+			}
+			if (want.Below() == nil) != (have.Below() == nil) {
+				t.Errorf("cell %v: expected tunnel %v, but have %v", want, want.Below(), have.Below())
+			}
+		}
+	}
+
+	// the tunnel connects the cells north and south of (1,1)
+	top, _ := c.Cell(1, 0, 0)
+	bottom, _ := c.Cell(1, 2, 0)
+	if d, err := top.Distances().Get(bottom); err != nil || d != 2 {
+		t.Errorf("expected distance 2 through the tunnel, but have %v (%v)", d, err)
+	}
+
+	if err := c.RestoreClients(pm); err != nil {
+		t.Fatalf("failed to restore clients: %v", err)
+	}
+	restored, err := c.Client("test")
+	if err != nil {
+		t.Fatalf("client not restored: %v", err)
+	}
+	if restored.CurrentLocation() != top {
+		t.Errorf("expected client at %v, but have %v", top, restored.CurrentLocation())
+	}
+	if restored.TravelPath.Length() != client.TravelPath.Length() {
+		t.Errorf("expected path of %v, but have %v", client.TravelPath.Length(), restored.TravelPath.Length())
+	}
+	if tunnel := restored.TravelPath.Segments()[2].Cell(); tunnel != top.South() || tunnel == cell(1, 1, 0) {
+		t.Errorf("expected the third step in the tunnel, but have %v", tunnel)
+	}
+	if n := top.VisitedTimes("test"); n != 2 {
+		t.Errorf("expected %v visited twice, but have %v", top, n)
+	}
+}
+
+var checkencodedtests = []struct {
+	encoded string
+	wantErr bool
+}{
+	{
+		encoded: "61\nA1\n",
+		wantErr: false,
+	}, {
+		encoded: "61\na1\n", // lower case hex
+		wantErr: false,
+	}, {
+		encoded: "61\n81\n", // west out of (1, 1), but no east out of (0, 1)
+		wantErr: true,
+	},
+}
+
+func TestCheckEncoded(t *testing.T) {
+	for _, tt := range checkencodedtests {
+		m, err := NewMaze(&pb.MazeConfig{Rows: 2, Columns: 2}, nil)
+		if err != nil {
+			t.Fatalf("invalid config: %v", err)
+		}
+		if err := m.Decode(tt.encoded); err != nil {
+			t.Fatalf("error decoding %q: %v", tt.encoded, err)
+		}
+
+		err = m.CheckEncoded(tt.encoded)
+		if err != nil && !tt.wantErr {
+			t.Errorf("%q: unexpected error: %v", tt.encoded, err)
+		}
+		if err == nil && tt.wantErr {
+			t.Errorf("%q: expected an error", tt.encoded)
+		}
+	}
+}
+
+func TestOnGenStep(t *testing.T) {
+	m, err := NewMaze(&pb.MazeConfig{Rows: 3, Columns: 4}, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	var steps []*pb.MazeLocation
+	m.OnGenStep(func(l *pb.MazeLocation) {
+		steps = append(steps, l)
+	})
+
+	for _, l := range []*pb.MazeLocation{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}, {X: 1, Y: 2, Z: 0}} {
+		cell, err := m.CellFromLocation(l)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.SetGenCurrentLocation(cell)
+	}
+	// the generator is done
+	m.SetGenCurrentLocation(nil)
+
+	m.OnGenStep(nil)
+	m.SetGenCurrentLocation(m.RandomCell())
+
+	if len(steps) != 3 {
+		t.Fatalf("expected 3 steps, have %v: %v", len(steps), steps)
+	}
+	if steps[2].String() != (&pb.MazeLocation{X: 1, Y: 2, Z: 0}).String() {
+		t.Errorf("expected the last step at 1,2,0, have %v", steps[2])
+	}
+}
+
 func BenchmarkNewMaze(b *testing.B) {
 	config := &pb.MazeConfig{
 		Rows:    10,
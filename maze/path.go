@@ -5,11 +5,11 @@ import (
 	"time"
 
 	"github.com/DanTulovsky/mazes/colors"
+	"github.com/DanTulovsky/mazes/render"
 	"github.com/DanTulovsky/mazes/utils"
 
 	metrics "github.com/rcrowley/go-metrics"
 	deadlock "github.com/sasha-s/go-deadlock"
-	"github.com/veandco/go-sdl2/sdl"
 )
 
 // Path is a path (ordered collection of cells) through the maze
@@ -183,8 +183,8 @@ func (p *Path) ListCells() map[*Cell]bool {
 	return p.cellMap
 }
 
-// Draw draws the path
-func (p *Path) Draw(r *sdl.Renderer, client *client, avatar *sdl.Texture) {
+// Draw draws the path, skipping cells for which visible returns false
+func (p *Path) Draw(r render.Renderer, client *client, avatar render.Texture, visible func(*Cell) bool) {
 	alreadyDone := make(map[*PathSegment]bool)
 
 	metrics.GetOrRegisterGauge("maze.path.tavel.length", nil).Update(int64(p.Length()))
@@ -198,6 +198,10 @@ func (p *Path) Draw(r *sdl.Renderer, client *client, avatar *sdl.Texture) {
 		// cache state of this cell
 		alreadyDone[segment] = true
 
+		if !visible(segment.Cell()) {
+			continue
+		}
+
 		p.drawSegment(segment, r, client, false)
 
 		if client.config.GetMarkVisitedCells() || client.config.GetNumberMarkVisitedCells() {
@@ -206,7 +210,7 @@ func (p *Path) Draw(r *sdl.Renderer, client *client, avatar *sdl.Texture) {
 	}
 
 	// handle last segment
-	if segment := p.LastSegment(); segment != nil {
+	if segment := p.LastSegment(); segment != nil && visible(segment.Cell()) {
 		if client.config.GetDrawPathLength() != 0 {
 			p.drawSegment(segment, r, client, true)
 		}
@@ -216,12 +220,17 @@ func (p *Path) Draw(r *sdl.Renderer, client *client, avatar *sdl.Texture) {
 }
 
 // drawSegment draws one segment of the path
-func (p *Path) drawSegment(ps *PathSegment, r *sdl.Renderer, client *client, isLast bool) {
+func (p *Path) drawSegment(ps *PathSegment, r render.Renderer, client *client, isLast bool) {
 	t := metrics.GetOrRegisterTimer("maze.draw.path.segment.latency", nil)
 	defer t.UpdateSince(time.Now())
 
 	cell := ps.Cell()
 
+	if gridType(cell.config) != GridSquare {
+		p.drawSegmentLines(ps, r, client, isLast)
+		return
+	}
+
 	pathWidth := cell.pathWidth
 	PixelsPerCell := cell.width
 
@@ -232,7 +241,7 @@ func (p *Path) drawSegment(ps *PathSegment, r *sdl.Renderer, client *client, isL
 	}
 	// TODO: Limit offset to fit inside cell
 
-	getPathRect := func(d string, inSolution bool) *sdl.Rect {
+	getPathRect := func(d string, inSolution bool) *render.Rect {
 		if !inSolution {
 			pathWidth = cell.pathWidth / 2
 		} else {
@@ -240,27 +249,27 @@ func (p *Path) drawSegment(ps *PathSegment, r *sdl.Renderer, client *client, isL
 		}
 
 		// these are the path segments from the middle towards the given direction
-		paths := map[string]*sdl.Rect{
+		paths := map[string]*render.Rect{
 			"east": {
-				int32(cell.x*PixelsPerCell+PixelsPerCell/2) + offset,
+				int32(cell.left()+PixelsPerCell/2) + offset,
 				int32(cell.y*PixelsPerCell+PixelsPerCell/2) + offset,
 				int32(PixelsPerCell/2+cell.wallWidth) - offset,
 				int32(pathWidth),
 			},
 			"west": {
-				int32(cell.x*PixelsPerCell + cell.wallWidth),
+				int32(cell.left() + cell.wallWidth),
 				int32(cell.y*PixelsPerCell+PixelsPerCell/2) + offset,
 				int32(PixelsPerCell/2+pathWidth-cell.wallWidth) + offset,
 				int32(pathWidth),
 			},
 			"north": {
-				int32(cell.x*PixelsPerCell+PixelsPerCell/2) + offset,
+				int32(cell.left()+PixelsPerCell/2) + offset,
 				int32(cell.y*PixelsPerCell + cell.wallWidth),
 				int32(pathWidth),
 				int32(PixelsPerCell/2-cell.wallWidth) + offset,
 			},
 			"south": {
-				int32(cell.x*PixelsPerCell+PixelsPerCell/2) + offset,
+				int32(cell.left()+PixelsPerCell/2) + offset,
 				int32(cell.y*PixelsPerCell+PixelsPerCell/2) + offset,
 				int32(pathWidth),
 				int32(PixelsPerCell/2+cell.wallWidth) - offset,
@@ -268,27 +277,27 @@ func (p *Path) drawSegment(ps *PathSegment, r *sdl.Renderer, client *client, isL
 		}
 
 		// stubs are for cells below other cells, we only draw a small part of the path
-		stubs := map[string]*sdl.Rect{
+		stubs := map[string]*render.Rect{
 			"east": {
-				int32(cell.x*PixelsPerCell + PixelsPerCell + cell.wallWidth - cell.config.WallSpace/2),
+				int32(cell.left() + PixelsPerCell + cell.wallWidth - cell.config.WallSpace/2),
 				int32(cell.y*PixelsPerCell+PixelsPerCell/2) + offset,
 				int32(cell.config.WallSpace / 2),
 				int32(pathWidth),
 			},
 			"west": {
-				int32(cell.x*PixelsPerCell + cell.wallWidth),
+				int32(cell.left() + cell.wallWidth),
 				int32(cell.y*PixelsPerCell+PixelsPerCell/2) + offset,
 				int32(cell.config.WallSpace / 2),
 				int32(pathWidth),
 			},
 			"north": {
-				int32(cell.x*PixelsPerCell+PixelsPerCell/2) + offset,
+				int32(cell.left()+PixelsPerCell/2) + offset,
 				int32(cell.y*PixelsPerCell + cell.wallWidth),
 				int32(pathWidth),
 				int32(cell.config.WallSpace / 2),
 			},
 			"south": {
-				int32(cell.x*PixelsPerCell+PixelsPerCell/2) + offset,
+				int32(cell.left()+PixelsPerCell/2) + offset,
 				int32(cell.y*PixelsPerCell + PixelsPerCell + cell.wallWidth - cell.config.WallSpace/2),
 				int32(pathWidth),
 				int32(cell.config.WallSpace / 2),
@@ -311,7 +320,7 @@ func (p *Path) drawSegment(ps *PathSegment, r *sdl.Renderer, client *client, isL
 		pathColor = colors.SetOpacity(pathColor, 60) // travel path is less visible
 	}
 
-	colors.SetDrawColor(pathColor, r)
+	r.SetDrawColor(pathColor)
 
 	if isLast && !cell.Visited(client.id) {
 		switch ps.Facing() {
@@ -334,7 +343,7 @@ func (p *Path) drawSegment(ps *PathSegment, r *sdl.Renderer, client *client, isL
 			} else {
 				pathColor = colors.SetOpacity(pathColor, 60)
 			}
-			colors.SetDrawColor(pathColor, r)
+			r.SetDrawColor(pathColor)
 			r.FillRect(getPathRect("east", eastInSolution && currentSegmentInSolution))
 
 		}
@@ -345,7 +354,7 @@ func (p *Path) drawSegment(ps *PathSegment, r *sdl.Renderer, client *client, isL
 			} else {
 				pathColor = colors.SetOpacity(pathColor, 60)
 			}
-			colors.SetDrawColor(pathColor, r)
+			r.SetDrawColor(pathColor)
 			r.FillRect(getPathRect("west", westInSolution && currentSegmentInSolution))
 
 		}
@@ -356,7 +365,7 @@ func (p *Path) drawSegment(ps *PathSegment, r *sdl.Renderer, client *client, isL
 			} else {
 				pathColor = colors.SetOpacity(pathColor, 60)
 			}
-			colors.SetDrawColor(pathColor, r)
+			r.SetDrawColor(pathColor)
 			r.FillRect(getPathRect("north", northInSolution && currentSegmentInSolution))
 
 		}
@@ -367,7 +376,7 @@ func (p *Path) drawSegment(ps *PathSegment, r *sdl.Renderer, client *client, isL
 			} else {
 				pathColor = colors.SetOpacity(pathColor, 60)
 			}
-			colors.SetDrawColor(pathColor, r)
+			r.SetDrawColor(pathColor)
 			r.FillRect(getPathRect("south", southInSolution && currentSegmentInSolution))
 
 		}
@@ -375,6 +384,56 @@ func (p *Path) drawSegment(ps *PathSegment, r *sdl.Renderer, client *client, isL
 
 }
 
+// drawSegmentLines draws one segment of the path as lines from the center of the cell towards its neighbors
+// Used for grids where cells are not squares.
+func (p *Path) drawSegmentLines(ps *PathSegment, r render.Renderer, client *client, isLast bool) {
+	cell := ps.Cell()
+	currentSegmentInSolution := ps.Solution()
+
+	var offset float64
+	// offset client path based on the client.id
+	if !client.config.GetDisableDrawOffset() {
+		offset = float64(utils.DrawOffset(client.number) * int(cell.pathWidth))
+	}
+
+	// drawTo draws the path from the center of the cell to the edge shared with n
+	drawTo := func(n *Cell, inSolution bool) {
+		pathColor := colors.SetOpacity(colors.GetColor(client.config.GetPathColor()), 60) // travel path is less visible
+		pathWidth := cell.pathWidth / 2
+		if inSolution {
+			pathColor = colors.SetOpacity(pathColor, 255) // solution is fully visible
+			pathWidth = cell.pathWidth
+		}
+		if pathWidth < 1 {
+			pathWidth = 1
+		}
+
+		x1, y1 := cell.center()
+		x2, y2 := n.center()
+		x2, y2 = (x1+x2)/2, (y1+y2)/2
+
+		r.ThickLine(int32(x1+offset), int32(y1+offset), int32(x2+offset), int32(y2+offset), int32(pathWidth), pathColor)
+	}
+
+	if isLast && !cell.Visited(client.id) {
+		// only draw the part of the path we came in from
+		if segments := p.Segments(); len(segments) > 1 {
+			if previous := segments[len(segments)-2].Cell(); cell.Linked(previous) {
+				drawTo(previous, currentSegmentInSolution)
+			}
+		}
+		return
+	}
+
+	for _, d := range cell.Directions() {
+		n := cell.Neighbor(d)
+		if n == nil || !cell.HavePath(client, d) {
+			continue
+		}
+		drawTo(n, p.CellInPath(n) && currentSegmentInSolution)
+	}
+}
+
 // PathSegment is one segement of a path. A cell, and metadata.
 type PathSegment struct {
 	cell     *Cell
@@ -430,6 +489,6 @@ func (ps *PathSegment) UpdateFacingDirection(f string) {
 }
 
 // DrawCurrentLocation marks the current location of the user
-func (ps *PathSegment) DrawCurrentLocation(r *sdl.Renderer, client *client, avatar *sdl.Texture) {
+func (ps *PathSegment) DrawCurrentLocation(r render.Renderer, client *client, avatar render.Texture) {
 	ps.Cell().DrawCurrentLocation(r, client, avatar, ps.Facing())
 }
@@ -0,0 +1,200 @@
+package maze
+
+import (
+	"math"
+
+	"github.com/DanTulovsky/mazes/colors"
+	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/DanTulovsky/mazes/render"
+)
+
+// polarRingSizes returns the number of cells in each ring of a polar grid with the given number of rings
+// The center is a single cell, every other ring is split so that its cells are roughly as wide as they are tall.
+func polarRingSizes(rings int64) []int64 {
+	if rings <= 0 {
+		return nil
+	}
+
+	sizes := []int64{1}
+	ringHeight := 1 / float64(rings)
+
+	for r := int64(1); r < rings; r++ {
+		radius := float64(r) / float64(rings)
+		circumference := 2 * math.Pi * radius
+
+		previous := sizes[r-1]
+		estimatedCellWidth := circumference / float64(previous)
+		ratio := int64(math.Round(estimatedCellWidth / ringHeight))
+		if ratio < 1 {
+			ratio = 1
+		}
+
+		sizes = append(sizes, previous*ratio)
+	}
+	return sizes
+}
+
+// polarColumns returns the number of cells in the largest (outer) ring of a polar grid
+func polarColumns(rings int64) int64 {
+	sizes := polarRingSizes(rings)
+	if len(sizes) == 0 {
+		return 0
+	}
+	return sizes[len(sizes)-1]
+}
+
+// polarWindowSize returns the size (width, height) in pixels needed to draw a polar maze
+func polarWindowSize(c *pb.MazeConfig) (int64, int64) {
+	size := c.GetRows()*c.GetCellWidth()*2 + c.GetWallWidth()*2
+	return size, size
+}
+
+// preparePolarGrid marks cells past the end of each ring as orphans; m must be locked
+func (m *Maze) preparePolarGrid() {
+	for y, size := range polarRingSizes(m.rows) {
+		for x := int64(0); x < m.columns; x++ {
+			cell := m.cells[x][y][0]
+			cell.ringSize = size
+			if x >= size {
+				cell.SetOrphan()
+			}
+		}
+	}
+}
+
+// configurePolarCells configures polar cells with their neighbors; m must be locked
+func (m *Maze) configurePolarCells() {
+	sizes := polarRingSizes(m.rows)
+
+	for y := int64(1); y < m.rows; y++ {
+		size := sizes[y]
+		ratio := size / sizes[y-1]
+
+		for x := int64(0); x < size; x++ {
+			cell := m.cells[x][y][0]
+
+			if size > 1 {
+				cell.SetCW(m.cells[(x+1)%size][y][0])
+				cell.SetCCW(m.cells[(x-1+size)%size][y][0])
+			}
+
+			parent := m.cells[x/ratio][y-1][0]
+			cell.SetInward(parent)
+			parent.AddOutward(cell)
+		}
+	}
+}
+
+// polarOrigin returns the pixel coordinates of the center of the polar grid
+func (c *Cell) polarOrigin() float64 {
+	return float64(c.config.GetRows()*c.width + c.wallWidth)
+}
+
+// polarBounds returns the inner and outer radius and the counter-clockwise and clockwise angles of a polar cell
+func (c *Cell) polarBounds() (inner, outer, thetaCCW, thetaCW float64) {
+	theta := 2 * math.Pi / float64(c.ringSize)
+
+	inner = float64(c.y * c.width)
+	outer = float64((c.y + 1) * c.width)
+
+	return inner, outer, float64(c.x) * theta, float64(c.x+1) * theta
+}
+
+// polarCenter returns the pixel coordinates of the center of a polar cell
+func (c *Cell) polarCenter() (float64, float64) {
+	origin := c.polarOrigin()
+	if c.y == 0 {
+		return origin, origin
+	}
+
+	inner, outer, thetaCCW, thetaCW := c.polarBounds()
+	radius, theta := (inner+outer)/2, (thetaCCW+thetaCW)/2
+
+	return origin + radius*math.Cos(theta), origin + radius*math.Sin(theta)
+}
+
+// polarArc returns points along the arc of radius between the two angles
+func polarArc(origin, radius, from, to float64) (xs, ys []float64) {
+	steps := int(math.Abs(to-from)*radius/4) + 1
+
+	for i := 0; i <= steps; i++ {
+		theta := from + (to-from)*float64(i)/float64(steps)
+		xs = append(xs, origin+radius*math.Cos(theta))
+		ys = append(ys, origin+radius*math.Sin(theta))
+	}
+	return xs, ys
+}
+
+// drawPolarLine draws a thick line made up of the points in xs, ys
+func drawPolarLine(r render.Renderer, xs, ys []float64, width int32, color colors.Color) {
+	for i := 0; i < len(xs)-1; i++ {
+		r.ThickLine(int32(math.Round(xs[i])), int32(math.Round(ys[i])),
+			int32(math.Round(xs[i+1])), int32(math.Round(ys[i+1])), width, color)
+	}
+}
+
+// drawPolar draws one polar cell on renderer
+func (c *Cell) drawPolar(r render.Renderer, bg colors.Color) render.Renderer {
+	origin := c.polarOrigin()
+	inner, outer, thetaCCW, thetaCW := c.polarBounds()
+
+	// background
+	if c.y == 0 {
+		r.FilledCircle(int32(origin), int32(origin), int32(outer), bg)
+	} else {
+		xs, ys := polarArc(origin, outer, thetaCCW, thetaCW)
+		ixs, iys := polarArc(origin, inner, thetaCW, thetaCCW)
+		xs, ys = append(xs, ixs...), append(ys, iys...)
+
+		var vx, vy []int16
+		for i := range xs {
+			vx = append(vx, int16(math.Round(xs[i])))
+			vy = append(vy, int16(math.Round(ys[i])))
+		}
+		r.FilledPolygon(vx, vy, bg)
+	}
+
+	// walls
+	wallWidth := int32(c.wallWidth)
+	if wallWidth < 1 {
+		wallWidth = 1
+	}
+
+	if c.y > 0 && !c.Linked(c.Inward()) {
+		xs, ys := polarArc(origin, inner, thetaCCW, thetaCW)
+		drawPolarLine(r, xs, ys, wallWidth, c.wallColor)
+	}
+
+	outward := c.Outward()
+	if len(outward) == 0 {
+		// outer edge of the maze
+		xs, ys := polarArc(origin, outer, thetaCCW, thetaCW)
+		drawPolarLine(r, xs, ys, wallWidth, c.wallColor)
+	}
+	for _, o := range outward {
+		if c.Linked(o) {
+			continue
+		}
+		_, _, oCCW, oCW := o.polarBounds()
+		xs, ys := polarArc(origin, outer, oCCW, oCW)
+		drawPolarLine(r, xs, ys, wallWidth, c.wallColor)
+	}
+
+	if c.ringSize > 1 {
+		if !c.Linked(c.CW()) {
+			xs := []float64{origin + inner*math.Cos(thetaCW), origin + outer*math.Cos(thetaCW)}
+			ys := []float64{origin + inner*math.Sin(thetaCW), origin + outer*math.Sin(thetaCW)}
+			drawPolarLine(r, xs, ys, wallWidth, c.wallColor)
+		}
+		if !c.Linked(c.CCW()) {
+			xs := []float64{origin + inner*math.Cos(thetaCCW), origin + outer*math.Cos(thetaCCW)}
+			ys := []float64{origin + inner*math.Sin(thetaCCW), origin + outer*math.Sin(thetaCCW)}
+			drawPolarLine(r, xs, ys, wallWidth, c.wallColor)
+		}
+	}
+
+	cx, cy := c.polarCenter()
+	c.drawValues(r, int64(cx)-c.width/4, int64(cy))
+
+	return r
+}
@@ -0,0 +1,230 @@
+package maze
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+
+	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/DanTulovsky/mazes/render"
+)
+
+// Proto returns the full structure of the maze: config, every cell with its passages and the clients with their paths
+func (m *Maze) Proto() *pb.Maze {
+	pm := &pb.Maze{
+		MazeId: m.config.GetId(),
+		Config: m.config,
+	}
+
+	for z := int64(0); z < m.levels; z++ {
+		for y := int64(0); y < m.rows; y++ {
+			for x := int64(0); x < m.columns; x++ {
+				cell, err := m.Cell(x, y, z)
+				if err != nil {
+					continue
+				}
+				pc := cell.proto()
+				if below := cell.Below(); below != nil {
+					pc.Under = below.proto()
+					pc.Under.Location = pc.Location
+				}
+				pm.Cells = append(pm.Cells, pc)
+			}
+		}
+	}
+
+	for _, c := range m.ClientsSorted() {
+		pm.ClientIds = append(pm.ClientIds, c.id)
+
+		mc := &pb.MazeClient{ClientId: c.id, Config: c.config}
+		if cell := c.FromCell(); cell != nil {
+			mc.FromCell, _ = m.locate(cell)
+		}
+		if cell := c.ToCell(); cell != nil {
+			mc.ToCell, _ = m.locate(cell)
+		}
+		if cell := c.CurrentLocation(); cell != nil {
+			mc.CurrentLocation, mc.CurrentLocationUnder = m.locate(cell)
+		}
+		for _, s := range c.TravelPath.Segments() {
+			l, under := m.locate(s.Cell())
+			mc.TravelPath = append(mc.TravelPath, &pb.PathSegment{Location: l, Under: under, Facing: s.Facing(), Solution: s.Solution()})
+		}
+		pm.Clients = append(pm.Clients, mc)
+	}
+	return pm
+}
+
+// proto returns the cell with its passages
+func (c *Cell) proto() *pb.Cell {
+	pc := &pb.Cell{
+		Location: c.Location(),
+		Weight:   int64(c.Weight()),
+		Orphan:   c.IsOrphan(),
+		Visited:  c.visits(),
+	}
+	for _, d := range c.Directions() {
+		if n := c.Neighbor(d); n != nil && c.Linked(n) {
+			pc.Links = append(pc.Links, d)
+		}
+	}
+	return pc
+}
+
+// locate returns the location of cell, under is true if it is the tunnel under the cell at that location
+func (m *Maze) locate(cell *Cell) (l *pb.MazeLocation, under bool) {
+	if c, err := m.Cell(cell.x, cell.y, cell.z); err == nil && c == cell {
+		return cell.Location(), false
+	}
+	return &pb.MazeLocation{X: cell.x, Y: cell.y, Z: cell.z + 1}, true
+}
+
+// cellAt returns the cell at l, or the tunnel under it
+func (m *Maze) cellAt(l *pb.MazeLocation, under bool) (*Cell, error) {
+	cell, err := m.CellFromLocation(l)
+	if err != nil {
+		return nil, err
+	}
+	if under {
+		if cell.Below() == nil {
+			return nil, fmt.Errorf("no tunnel under %v", cell)
+		}
+		return cell.Below(), nil
+	}
+	return cell, nil
+}
+
+// NewMazeFromProto returns a copy of the maze returned by Proto(), clients are not added
+func NewMazeFromProto(pm *pb.Maze, r render.Renderer) (*Maze, error) {
+	if pm.GetConfig() == nil {
+		return nil, fmt.Errorf("maze %v has no config", pm.GetMazeId())
+	}
+	config := proto.Clone(pm.GetConfig()).(*pb.MazeConfig)
+	// the passages come from the cells, nothing is recorded
+	config.Record = nil
+
+	m, err := NewMaze(config, r)
+	if err != nil {
+		return nil, err
+	}
+
+	// tunnels first, the cells on either end of a tunnel have it as their neighbor once it exists
+	for _, pc := range pm.GetCells() {
+		if pc.GetUnder() == nil {
+			continue
+		}
+		cell, err := m.CellFromLocation(pc.GetLocation())
+		if err != nil {
+			return nil, err
+		}
+		if err := m.linkTunnel(cell, pc.GetUnder().GetLinks()); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, pc := range pm.GetCells() {
+		cell, err := m.CellFromLocation(pc.GetLocation())
+		if err != nil {
+			return nil, err
+		}
+		if pc.GetOrphan() && !cell.IsOrphan() {
+			cell.Orphan()
+		}
+		cell.SetWeight(int(pc.GetWeight()))
+
+		for _, d := range pc.GetLinks() {
+			n := cell.Neighbor(d)
+			if n == nil {
+				return nil, fmt.Errorf("cell %v has a passage %v, but no neighbor there", cell, d)
+			}
+			if !cell.Linked(n) {
+				if err := cell.Link(n); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// linkTunnel links the cells on either side of cell with a tunnel under it
+// links are the directions out of the tunnel, e.g. north and south.
+func (m *Maze) linkTunnel(cell *Cell, links []string) error {
+	if len(links) != 2 {
+		return fmt.Errorf("tunnel under %v has %v ends, expected 2", cell, len(links))
+	}
+
+	c1, c2 := cell.Neighbor(links[0]), cell.Neighbor(links[1])
+	if c1 == nil || c2 == nil {
+		return fmt.Errorf("tunnel under %v leads outside the maze", cell)
+	}
+	m.Link(c1, c2)
+	return nil
+}
+
+// RestoreClients adds the clients in pm, as returned by Proto(), with their paths and visited cells
+// The maze must have been created from the same pm with NewMazeFromProto. Clients are not recorded.
+func (m *Maze) RestoreClients(pm *pb.Maze) error {
+	for _, mc := range pm.GetClients() {
+		from, err := m.cellAt(mc.GetFromCell(), false)
+		if err != nil {
+			return err
+		}
+		to, err := m.cellAt(mc.GetToCell(), false)
+		if err != nil {
+			return err
+		}
+
+		config := &pb.ClientConfig{}
+		if mc.GetConfig() != nil {
+			config = proto.Clone(mc.GetConfig()).(*pb.ClientConfig)
+		}
+		config.Record = nil
+		config.FromCell = fmt.Sprintf("%d,%d,%d", from.x, from.y, from.z)
+		config.ToCell = fmt.Sprintf("%d,%d,%d", to.x, to.y, to.z)
+		if _, _, err := m.AddClient(mc.GetClientId(), config); err != nil {
+			return err
+		}
+		client, err := m.Client(mc.GetClientId())
+		if err != nil {
+			return err
+		}
+
+		for _, s := range mc.GetTravelPath() {
+			cell, err := m.cellAt(s.GetLocation(), s.GetUnder())
+			if err != nil {
+				return err
+			}
+			client.TravelPath.AddSegement(NewSegment(cell, s.GetFacing(), s.GetSolution()))
+		}
+
+		if mc.GetCurrentLocation() != nil {
+			cell, err := m.cellAt(mc.GetCurrentLocation(), mc.GetCurrentLocationUnder())
+			if err != nil {
+				return err
+			}
+			client.SetCurrentLocation(cell)
+		}
+	}
+
+	for _, pc := range pm.GetCells() {
+		if len(pc.GetVisited()) == 0 && len(pc.GetUnder().GetVisited()) == 0 {
+			continue
+		}
+		cell, err := m.CellFromLocation(pc.GetLocation())
+		if err != nil {
+			return err
+		}
+		cell.setVisits(pc.GetVisited())
+		if below := cell.Below(); below != nil {
+			below.setVisits(pc.GetUnder().GetVisited())
+		}
+	}
+
+	for _, client := range m.ClientsSorted() {
+		if client.TravelPath.Length() > 0 {
+			m.SetClientPath(client)
+		}
+	}
+	return nil
+}
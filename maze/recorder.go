@@ -0,0 +1,185 @@
+package maze
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/DanTulovsky/mazes/colors"
+	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/DanTulovsky/mazes/render"
+
+	"github.com/sasha-s/go-deadlock"
+)
+
+const (
+	defaultRecordMaxFrames  = 500
+	defaultRecordFrameDelay = 50 * time.Millisecond
+)
+
+// recorder draws a frame of the maze every few steps, without a window, and saves them as an animation
+type recorder struct {
+	file      string
+	skip      int64
+	maxFrames int64
+	animation render.Animation
+	steps     int64
+	saved     bool
+
+	deadlock.Mutex
+}
+
+// recordFormat returns the animation format of the recording
+func recordFormat(c *pb.RecordConfig) string {
+	if c.GetFormat() != "" {
+		return c.GetFormat()
+	}
+	switch strings.ToLower(filepath.Ext(c.GetFile())) {
+	case ".png", ".apng":
+		return render.AnimationAPNG
+	}
+	return render.AnimationGIF
+}
+
+// newRecorder returns a recorder as configured in c, nil if recording is off
+func newRecorder(c *pb.RecordConfig) (*recorder, error) {
+	if c.GetFile() == "" {
+		return nil, nil
+	}
+	if c.GetFrameSkip() < 0 || c.GetMaxFrames() < 0 {
+		return nil, fmt.Errorf("invalid record frame skip (%v) or max frames (%v)", c.GetFrameSkip(), c.GetMaxFrames())
+	}
+
+	delay := defaultRecordFrameDelay
+	if c.GetFrameDelay() != "" {
+		var err error
+		if delay, err = time.ParseDuration(c.GetFrameDelay()); err != nil {
+			return nil, fmt.Errorf("invalid record frame delay: %v", err)
+		}
+	}
+
+	animation, err := render.NewAnimation(recordFormat(c), delay)
+	if err != nil {
+		return nil, err
+	}
+
+	maxFrames := c.GetMaxFrames()
+	if maxFrames == 0 {
+		maxFrames = defaultRecordMaxFrames
+	}
+
+	return &recorder{
+		file:      c.GetFile(),
+		skip:      c.GetFrameSkip(),
+		maxFrames: maxFrames,
+		animation: animation,
+	}, nil
+}
+
+// step records a frame drawn by draw on every frame skip + 1 steps, the animation is saved once it is full
+func (rec *recorder) step(m *Maze, draw func(r render.Renderer)) {
+	rec.Lock()
+	defer rec.Unlock()
+
+	if rec.saved {
+		return
+	}
+	rec.steps++
+	if (rec.steps-1)%(rec.skip+1) != 0 {
+		return
+	}
+
+	rec.addFrame(m, draw)
+	if int64(rec.animation.Frames()) >= rec.maxFrames {
+		if err := rec.save(); err != nil {
+			log.Printf("error saving recording: %v", err)
+		}
+	}
+}
+
+// finish records a final frame, so the end state is always shown, and saves the animation
+func (rec *recorder) finish(m *Maze, draw func(r render.Renderer)) error {
+	rec.Lock()
+	defer rec.Unlock()
+
+	if rec.saved {
+		return nil
+	}
+	rec.addFrame(m, draw)
+	return rec.save()
+}
+
+// addFrame draws one frame
+func (rec *recorder) addFrame(m *Maze, draw func(r render.Renderer)) {
+	r := render.NewImage(m.winWidth, m.winHeight)
+	r.SetDrawColor(colors.GetColor("white"))
+	r.Clear()
+	draw(r)
+
+	if err := rec.animation.AddFrame(r.RGBA()); err != nil {
+		log.Printf("error recording frame: %v", err)
+	}
+}
+
+// save writes the animation to the file, only the first call does anything
+func (rec *recorder) save() error {
+	if rec.saved {
+		return nil
+	}
+	rec.saved = true
+
+	f, err := os.Create(rec.file)
+	if err != nil {
+		return err
+	}
+	if err := rec.animation.Encode(f); err != nil {
+		f.Close()
+		return err
+	}
+	log.Printf("saved %v frame recording to %v", rec.animation.Frames(), rec.file)
+	return f.Close()
+}
+
+// drawGenFrame draws the maze as seen while generating it
+func (m *Maze) drawGenFrame(r render.Renderer) {
+	m.drawGenCurrentLocation(r)
+	m.DrawMazeBackground(r)
+}
+
+// recordGenStep records a step of the generator, if enabled
+func (m *Maze) recordGenStep() {
+	if m.genRecorder != nil {
+		m.genRecorder.step(m, m.drawGenFrame)
+	}
+}
+
+// SaveGenRecording saves the recording of the generator, call it once the maze is generated
+func (m *Maze) SaveGenRecording() error {
+	if m.genRecorder == nil {
+		return nil
+	}
+	return m.genRecorder.finish(m, m.drawGenFrame)
+}
+
+// recordClientStep records a move of the client, if enabled; the recording is saved when the client is solved
+func (m *Maze) recordClientStep(client *client) {
+	if client.recorder == nil {
+		return
+	}
+
+	draw := func(r render.Renderer) {
+		m.DrawMazeBackground(r)
+		m.drawClient(r, client)
+	}
+
+	if client.CurrentLocation() == client.ToCell() {
+		if err := client.recorder.finish(m, draw); err != nil {
+			log.Printf("error saving recording of client %v: %v", client.id, err)
+		}
+		return
+	}
+	client.recorder.step(m, draw)
+}
@@ -1,6 +1,10 @@
 package maze
 
-import deadlock "github.com/sasha-s/go-deadlock"
+import (
+	"sort"
+
+	deadlock "github.com/sasha-s/go-deadlock"
+)
 
 type safeMap2 struct {
 	deadlock.RWMutex
@@ -18,25 +22,32 @@ func NewSafeMap2() *safeMap2 {
 	}
 }
 
+// Keys returns the keys ordered by their location, so random picks among them only depend on the random numbers
 func (sm *safeMap2) Keys() []*Cell {
 	sm.RLock()
 	defer sm.RUnlock()
 
+	return sm.keys()
+}
+
+func (sm *safeMap2) keys() []*Cell {
 	var keys []*Cell
 	for k := range sm.data {
 		keys = append(keys, k)
 	}
+	sortCells(keys)
 	return keys
 }
 
+// Iter returns the items in the order of Keys
 func (sm *safeMap2) Iter() <-chan safeMapItem {
 	c := make(chan safeMapItem, 10)
 
 	f := func() {
 		sm.RLock()
 		defer sm.RUnlock()
-		for k, v := range sm.data {
-			c <- safeMapItem{k, v}
+		for _, k := range sm.keys() {
+			c <- safeMapItem{k, sm.data[k]}
 		}
 		close(c)
 	}
@@ -75,3 +86,19 @@ func (sm *safeMap2) Update(key *Cell, value interface{}) {
 	defer sm.Unlock()
 	sm.data[key] = value
 }
+
+// sortCells sorts cells by level, row and column; a cell comes before the cell under it (weaving)
+func sortCells(cells []*Cell) {
+	sort.Slice(cells, func(i, j int) bool {
+		a, b := cells[i], cells[j]
+		switch {
+		case a.z != b.z:
+			return a.z < b.z
+		case a.y != b.y:
+			return a.y < b.y
+		case a.x != b.x:
+			return a.x < b.x
+		}
+		return a.Below() == b
+	})
+}
@@ -0,0 +1,103 @@
+package maze
+
+import (
+	"errors"
+	"fmt"
+	"image/png"
+	"io"
+
+	"github.com/DanTulovsky/mazes/colors"
+	"github.com/DanTulovsky/mazes/render"
+)
+
+const (
+	// RenderFormatPNG renders the maze as a PNG image
+	RenderFormatPNG = "png"
+	// RenderFormatSVG renders the maze as an SVG document
+	RenderFormatSVG = "svg"
+)
+
+// RenderOptions controls what RenderPNG and RenderSVG draw on top of the walls
+// Distance and weight values are drawn when enabled in the maze config (ShowDistanceValues, ShowWeightValues).
+type RenderOptions struct {
+	// ClientID is the client whose from/to cells and travel path are drawn, none if empty
+	ClientID string
+	// DistanceColors shades every cell by its distance from the client's from cell
+	DistanceColors bool
+}
+
+// Render writes a snapshot of the maze to w in the given format
+func (m *Maze) Render(w io.Writer, format string, opts RenderOptions) error {
+	switch format {
+	case RenderFormatPNG, "":
+		return m.RenderPNG(w, opts)
+	case RenderFormatSVG:
+		return m.RenderSVG(w, opts)
+	}
+	return fmt.Errorf("invalid render format: %v", format)
+}
+
+// RenderPNG writes a snapshot of the maze to w as a PNG image, no display is needed
+func (m *Maze) RenderPNG(w io.Writer, opts RenderOptions) error {
+	r := render.NewImage(m.winWidth, m.winHeight)
+	if err := m.drawSnapshot(r, opts); err != nil {
+		return err
+	}
+	return png.Encode(w, r.RGBA())
+}
+
+// RenderSVG writes a snapshot of the maze to w as an SVG document, no display is needed
+func (m *Maze) RenderSVG(w io.Writer, opts RenderOptions) error {
+	r := render.NewSVG(m.winWidth, m.winHeight)
+	if err := m.drawSnapshot(r, opts); err != nil {
+		return err
+	}
+	return r.Encode(w)
+}
+
+// drawSnapshot draws the maze, and optionally one client, on r
+func (m *Maze) drawSnapshot(r render.Renderer, opts RenderOptions) error {
+	var client *client
+	if opts.ClientID != "" {
+		var err error
+		if client, err = m.Client(opts.ClientID); err != nil {
+			return err
+		}
+	}
+
+	var bgColors map[*Cell]colors.Color
+	if opts.DistanceColors {
+		if client == nil {
+			return errors.New("distance colors need a client to measure the distance from")
+		}
+
+		// the colors are only for the snapshot, the cells (and the display) keep theirs
+		bgColors = m.distanceColors(client)
+	}
+
+	r.SetDrawColor(colors.GetColor("white"))
+	if err := r.Clear(); err != nil {
+		return err
+	}
+	m.drawBackground(r, bgColors)
+
+	if client != nil {
+		m.drawClient(r, client)
+	}
+	return nil
+}
+
+// distanceColors returns the background color of every cell by its distance from the from cell of client
+// The distances are worked out again, the cached ones are stale once the passages change (origin shift, dynamics).
+func (m *Maze) distanceColors(client *client) map[*Cell]colors.Color {
+	distances := client.FromCell().newDistances()
+	_, longest := distances.Furthest()
+
+	bgColors := make(map[*Cell]colors.Color)
+	for cell := range m.Cells() {
+		if d, err := distances.Get(cell); err == nil {
+			bgColors[cell] = m.distanceColor(d, longest)
+		}
+	}
+	return bgColors
+}
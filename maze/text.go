@@ -0,0 +1,274 @@
+package maze
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TextOptions controls what Text draws inside the cells
+// When more than one value applies to a cell only the first one in this order is shown:
+// client positions, visited counts, distances, the solution path, passages to other levels.
+type TextOptions struct {
+	// ClientID is the client whose travel path and visited counts are drawn, none if empty
+	ClientID string
+	// Path marks the cells (and passages) in the client's solution path with a dot
+	Path bool
+	// Visited shows how many times the client visited each cell
+	Visited bool
+	// Distances shows the distance value of each cell, see Cell.Distance()
+	Distances bool
+	// Clients marks the current location of every client in the maze with an @
+	Clients bool
+	// ASCII draws the walls with +, - and | instead of box-drawing characters
+	ASCII bool
+	// CellWidth is the number of characters across each cell, 3 if not set
+	// Values that do not fit are shown as *.
+	CellWidth int
+}
+
+// cellWidth returns the width of each cell in characters
+func (o TextOptions) cellWidth() int {
+	if o.CellWidth <= 0 {
+		return defaultTextCellWidth
+	}
+	return o.CellWidth
+}
+
+// box-drawing characters for a wall corner, indexed by the walls going up (1), down (2), left (4) and right (8)
+var (
+	boxCorners   = []rune(" ╵╷│╴┘┐┤╶└┌├─┴┬┼")
+	asciiCorners = []rune(" +++++++++++++++")
+)
+
+const (
+	defaultTextCellWidth = 3
+	textPathMark         = "·"
+	textOrphan           = "░"
+)
+
+// textGrid is the maze drawn as characters, every cell is one line high
+type textGrid struct {
+	lines [][]string
+}
+
+func (g *textGrid) set(x, y int, s string) {
+	g.lines[y][x] = s
+}
+
+func (g *textGrid) get(x, y int) string {
+	return g.lines[y][x]
+}
+
+func (g *textGrid) String() string {
+	var b strings.Builder
+	for _, line := range g.lines {
+		b.WriteString(strings.TrimRight(strings.Join(line, ""), " "))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Text returns the maze drawn with box-drawing characters, one level after another
+// Orphaned cells are shaded and have no walls of their own. Only square grids can be drawn as text.
+func (m *Maze) Text(opts TextOptions) (string, error) {
+	if m.GridType() != GridSquare {
+		return "", fmt.Errorf("%v grids cannot be drawn as text", m.GridType())
+	}
+
+	var client *client
+	if opts.ClientID != "" {
+		var err error
+		if client, err = m.Client(opts.ClientID); err != nil {
+			return "", err
+		}
+	}
+	if client == nil && (opts.Path || opts.Visited) {
+		return "", fmt.Errorf("path and visited counts need a client")
+	}
+
+	var output []string
+	for z := int64(0); z < m.levels; z++ {
+		level := m.textLevel(z, client, opts)
+		if m.levels > 1 {
+			level = fmt.Sprintf("level %v\n%v", z, level)
+		}
+		output = append(output, level)
+	}
+	return strings.Join(output, "\n"), nil
+}
+
+// textCell returns the cell at x, y on level z, nil if it is outside the grid or orphaned
+func (m *Maze) textCell(x, y, z int64) *Cell {
+	cell, err := m.Cell(x, y, z)
+	if err != nil || cell.IsOrphan() {
+		return nil
+	}
+	return cell
+}
+
+// textWallNorth returns true if there is a wall along the top of the cell at x, y
+// Either side of a wall may be outside the maze, wrap-around passages are followed through the neighbors.
+func (m *Maze) textWallNorth(x, y, z int64) bool {
+	below, above := m.textCell(x, y, z), m.textCell(x, y-1, z)
+	return (below != nil && !below.Linked(below.North())) || (above != nil && !above.Linked(above.South()))
+}
+
+// textWallWest returns true if there is a wall along the left of the cell at x, y
+func (m *Maze) textWallWest(x, y, z int64) bool {
+	right, left := m.textCell(x, y, z), m.textCell(x-1, y, z)
+	return (right != nil && !right.Linked(right.West())) || (left != nil && !left.Linked(left.East()))
+}
+
+// textLevel draws one level of the maze
+func (m *Maze) textLevel(z int64, client *client, opts TextOptions) string {
+	cw := opts.cellWidth()
+	width, height := int(m.columns)*(cw+1)+1, int(m.rows)*2+1
+
+	g := &textGrid{lines: make([][]string, height)}
+	for y := range g.lines {
+		g.lines[y] = make([]string, width)
+		for x := range g.lines[y] {
+			g.lines[y][x] = " "
+		}
+	}
+
+	horizontal, vertical, corners := "─", "│", boxCorners
+	if opts.ASCII {
+		horizontal, vertical, corners = "-", "|", asciiCorners
+	}
+
+	// walls, including the ones along the right and bottom edges
+	for y := int64(0); y <= m.rows; y++ {
+		for x := int64(0); x <= m.columns; x++ {
+			gx, gy := int(x)*(cw+1), int(y)*2
+
+			if x < m.columns && m.textWallNorth(x, y, z) {
+				for i := 1; i <= cw; i++ {
+					g.set(gx+i, gy, horizontal)
+				}
+			}
+			if y < m.rows && m.textWallWest(x, y, z) {
+				g.set(gx, gy+1, vertical)
+			}
+
+			corner := 0
+			if y > 0 && m.textWallWest(x, y-1, z) {
+				corner |= 1
+			}
+			if y < m.rows && m.textWallWest(x, y, z) {
+				corner |= 2
+			}
+			if x > 0 && m.textWallNorth(x-1, y, z) {
+				corner |= 4
+			}
+			if x < m.columns && m.textWallNorth(x, y, z) {
+				corner |= 8
+			}
+			g.set(gx, gy, string(corners[corner]))
+		}
+	}
+
+	orphan, pathMark := textOrphan, textPathMark
+	if opts.ASCII {
+		orphan, pathMark = "#", "."
+	}
+
+	// solution path, including the passages between the cells in it
+	if opts.Path {
+		var previous *Cell
+		for _, segment := range client.TravelPath.Segments() {
+			cell := segment.Cell()
+			if !segment.Solution() || cell.z != z {
+				previous = nil
+				continue
+			}
+			gx, gy := int(cell.x)*(cw+1)+1, int(cell.y)*2+1
+			g.set(gx+cw/2, gy, pathMark)
+
+			if previous != nil && previous.z == z && previous.Linked(cell) {
+				switch {
+				case previous == cell.West() && cell.x > 0:
+					g.set(gx-1, gy, pathMark)
+				case previous == cell.East() && cell.x < m.columns-1:
+					g.set(gx+cw, gy, pathMark)
+				case previous == cell.North() && cell.y > 0:
+					g.set(gx+cw/2, gy-1, pathMark)
+				case previous == cell.South() && cell.y < m.rows-1:
+					g.set(gx+cw/2, gy+1, pathMark)
+				}
+			}
+			previous = cell
+		}
+	}
+
+	locations := make(map[*Cell]bool)
+	if opts.Clients {
+		for _, c := range m.ClientsSorted() {
+			locations[c.CurrentLocation()] = true
+		}
+	}
+
+	// cell bodies
+	for y := int64(0); y < m.rows; y++ {
+		for x := int64(0); x < m.columns; x++ {
+			cell, _ := m.Cell(x, y, z)
+			gx, gy := int(x)*(cw+1)+1, int(y)*2+1
+
+			body := ""
+			switch {
+			case cell.IsOrphan():
+				body = strings.Repeat(orphan, cw)
+			case locations[cell]:
+				body = textMark("@", cw)
+			case opts.Visited && cell.VisitedTimes(client.id) > 0:
+				body = textValue(cell.VisitedTimes(client.id), cw)
+			case opts.Distances:
+				body = textValue(int64(cell.Distance()), cw)
+			case g.get(gx+cw/2, gy) == " ":
+				if passage := textPassage(cell, opts.ASCII); passage != "" {
+					body = textMark(passage, cw)
+				}
+			}
+			if body == "" {
+				continue
+			}
+
+			for i, r := range []rune(body) {
+				g.set(gx+i, gy, string(r))
+			}
+		}
+	}
+
+	return g.String()
+}
+
+// textPassage returns the mark for the passages from cell to the levels above and below, empty if there are none
+func textPassage(cell *Cell, ascii bool) string {
+	marks := []string{"", "↑", "↓", "↕"}
+	if ascii {
+		marks = []string{"", "^", "v", "x"}
+	}
+
+	i := 0
+	if cell.Linked(cell.Up()) {
+		i |= 1
+	}
+	if cell.Linked(cell.Down()) {
+		i |= 2
+	}
+	return marks[i]
+}
+
+// textMark returns mark in the middle of a cell of the given width
+func textMark(mark string, width int) string {
+	return strings.Repeat(" ", width/2) + mark + strings.Repeat(" ", width-width/2-1)
+}
+
+// textValue formats v to fit in a cell of the given width, values that do not fit are shown as *
+func textValue(v int64, width int) string {
+	s := fmt.Sprintf("%*v", width, v)
+	if len(s) > width {
+		return strings.Repeat("*", width)
+	}
+	return s
+}
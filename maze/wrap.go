@@ -0,0 +1,127 @@
+package maze
+
+import (
+	"fmt"
+
+	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/DanTulovsky/mazes/render"
+)
+
+const (
+	// WrapNone is the default, edge cells have no neighbors past the edge
+	WrapNone = ""
+	// WrapHorizontal links the east edge to the west edge (cylinder)
+	WrapHorizontal = "horizontal"
+	// WrapVertical links the north edge to the south edge (cylinder)
+	WrapVertical = "vertical"
+	// WrapBoth links both pairs of opposite edges (torus)
+	WrapBoth = "both"
+)
+
+// wrapsX returns true if the grid wraps around from east to west
+func wrapsX(c *pb.MazeConfig) bool {
+	return c.GetWrap() == WrapHorizontal || c.GetWrap() == WrapBoth
+}
+
+// wrapsY returns true if the grid wraps around from south to north
+func wrapsY(c *pb.MazeConfig) bool {
+	return c.GetWrap() == WrapVertical || c.GetWrap() == WrapBoth
+}
+
+// checkWrapConfig validates the wrap related parts of the config
+func checkWrapConfig(c *pb.MazeConfig) error {
+	switch c.GetWrap() {
+	case WrapNone:
+		return nil
+	case WrapHorizontal, WrapVertical, WrapBoth:
+	default:
+		return fmt.Errorf("invalid wrap: %v", c.GetWrap())
+	}
+
+	if gridType(c) != GridSquare {
+		return fmt.Errorf("wrap is only supported on %v grids", GridSquare)
+	}
+	if c.GetAllowWeaving() {
+		return fmt.Errorf("weaving is not supported on mazes that wrap around")
+	}
+
+	// with fewer cells the neighbors on both sides are the same cell
+	if wrapsX(c) && c.GetColumns() < 3 {
+		return fmt.Errorf("wrapping horizontally requires at least 3 columns, have %v", c.GetColumns())
+	}
+	if wrapsY(c) && c.GetRows() < 3 {
+		return fmt.Errorf("wrapping vertically requires at least 3 rows, have %v", c.GetRows())
+	}
+	return nil
+}
+
+// wrappedCell returns the cell at column, row on level z, wrapping around the edges as configured
+func (m *Maze) wrappedCell(column, row, z int64) (*Cell, error) {
+	if wrapsX(m.config) {
+		column = (column + m.columns) % m.columns
+	}
+	if wrapsY(m.config) {
+		row = (row + m.rows) % m.rows
+	}
+	return m.Cell(column, row, z)
+}
+
+// Wraps returns true if the passage from c in direction d goes around the edge of the grid
+func (c *Cell) Wraps(d string) bool {
+	n := c.Neighbor(d)
+	if n == nil {
+		return false
+	}
+
+	switch d {
+	case "north":
+		return n.y > c.y
+	case "south":
+		return n.y < c.y
+	case "east":
+		return n.x < c.x
+	case "west":
+		return n.x > c.x
+	}
+	return false
+}
+
+// NeighborNoWrap returns the neighbor in direction d, nil if there isn't one or it is across the edge of the grid
+// Used by algorithms that rely on the grid having edges.
+func (c *Cell) NeighborNoWrap(d string) *Cell {
+	if c.Wraps(d) {
+		return nil
+	}
+	return c.Neighbor(d)
+}
+
+// drawWraps marks passages that wrap around the edge of the grid with a small triangle pointing out of the maze
+func (c *Cell) drawWraps(r render.Renderer) {
+	size := int32(c.width / 4)
+	if size < 2 {
+		return
+	}
+	left := int32(c.left() + c.wallWidth)
+	top := int32(c.y*c.width + c.wallWidth)
+	cx, cy := left+int32(c.width/2), top+int32(c.width/2)
+	right, bottom := left+int32(c.width), top+int32(c.width)
+
+	color := c.wallColor
+
+	for _, d := range squareDirections {
+		if !c.Wraps(d) || !c.Linked(c.Neighbor(d)) {
+			continue
+		}
+
+		switch d {
+		case "north":
+			r.FilledTrigon(cx-size/2, top+size/2, cx+size/2, top+size/2, cx, top, color)
+		case "south":
+			r.FilledTrigon(cx-size/2, bottom-size/2, cx+size/2, bottom-size/2, cx, bottom, color)
+		case "east":
+			r.FilledTrigon(right-size/2, cy-size/2, right-size/2, cy+size/2, right, cy, color)
+		case "west":
+			r.FilledTrigon(left+size/2, cy-size/2, left+size/2, cy+size/2, left, cy, color)
+		}
+	}
+}
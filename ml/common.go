@@ -209,7 +209,7 @@ func NextState(m *maze.Maze, endCell *pb.MazeLocation, state, action int) (nextS
 			reward = invalidActionReward
 		case action == North:
 			if cell.Linked(cell.North()) {
-				nextState, err = utils.StateFromLocation(m.Config().Rows, m.Config().Columns, cell.North().Location())
+				nextState, err = stepState(m, cell, 0, -1)
 				if err != nil {
 					return nextState, reward, valid, err
 				}
@@ -220,7 +220,7 @@ func NextState(m *maze.Maze, endCell *pb.MazeLocation, state, action int) (nextS
 			}
 		case action == South:
 			if cell.Linked(cell.South()) {
-				nextState, err = utils.StateFromLocation(m.Config().Rows, m.Config().Columns, cell.South().Location())
+				nextState, err = stepState(m, cell, 0, 1)
 				if err != nil {
 					return nextState, reward, valid, err
 				}
@@ -231,7 +231,7 @@ func NextState(m *maze.Maze, endCell *pb.MazeLocation, state, action int) (nextS
 			}
 		case action == East:
 			if cell.Linked(cell.East()) {
-				nextState, err = utils.StateFromLocation(m.Config().Rows, m.Config().Columns, cell.East().Location())
+				nextState, err = stepState(m, cell, 1, 0)
 				if err != nil {
 					return nextState, reward, valid, err
 				}
@@ -242,7 +242,7 @@ func NextState(m *maze.Maze, endCell *pb.MazeLocation, state, action int) (nextS
 			}
 		case action == West:
 			if cell.Linked(cell.West()) {
-				nextState, err = utils.StateFromLocation(m.Config().Rows, m.Config().Columns, cell.West().Location())
+				nextState, err = stepState(m, cell, -1, 0)
 				if err != nil {
 					return nextState, reward, valid, err
 				}
@@ -256,6 +256,14 @@ func NextState(m *maze.Maze, endCell *pb.MazeLocation, state, action int) (nextS
 	return nextState, reward, valid, nil
 }
 
+// stepState returns the state of the cell dx columns and dy rows away from cell
+// On grids that wrap around, the steps past an edge continue on the opposite edge (see utils.StateFromWrappedLocation).
+func stepState(m *maze.Maze, cell *maze.Cell, dx, dy int64) (int, error) {
+	l := cell.Location()
+	return utils.StateFromWrappedLocation(m.Config().Rows, m.Config().Columns, m.Config().GetWrap(),
+		&pb.MazeLocation{X: l.GetX() + dx, Y: l.GetY() + dy, Z: l.GetZ()})
+}
+
 // CellFromState returns the cell given the state number
 func CellFromState(m *maze.Maze, state int) (*maze.Cell, error) {
 	// get cell from state; the state is simply an integer that counts the cells in the maze
@@ -0,0 +1,42 @@
+package ml
+
+import (
+	"testing"
+
+	"github.com/DanTulovsky/mazes/maze"
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+func TestNextStateTorus(t *testing.T) {
+	config := &pb.MazeConfig{Columns: 3, Rows: 3, Wrap: maze.WrapBoth}
+	m, err := maze.NewMaze(config, nil)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	// every passage is open, including the ones around the edges
+	for cell := range m.Cells() {
+		m.Link(cell, cell.East())
+		m.Link(cell, cell.South())
+	}
+	end := &pb.MazeLocation{X: 1, Y: 1} // the middle, no move starts there
+
+	// states count the cells row by row: 0 1 2 / 3 4 5 / 6 7 8
+	for _, tt := range []struct {
+		state, action, want int
+	}{
+		{state: 0, action: North, want: 6},
+		{state: 0, action: West, want: 2},
+		{state: 2, action: East, want: 0},
+		{state: 7, action: South, want: 1},
+		{state: 5, action: East, want: 3},
+		{state: 3, action: East, want: 4}, // no wrap
+	} {
+		next, _, valid, err := NextState(m, end, tt.state, tt.action)
+		if err != nil {
+			t.Fatalf("NextState(%v, %v) = %v", tt.state, ActionToText[tt.action], err)
+		}
+		if !valid || next != tt.want {
+			t.Errorf("NextState(%v, %v) = %v (valid: %v), want %v", tt.state, ActionToText[tt.action], next, valid, tt.want)
+		}
+	}
+}
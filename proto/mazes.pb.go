@@ -1,835 +1,2214 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        (unknown)
 // source: mazes.proto
 
 package proto
 
 import (
 	context "context"
-	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	grpc "google.golang.org/grpc"
-	math "math"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
 )
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ = proto.Marshal
-var _ = fmt.Errorf
-var _ = math.Inf
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the proto package it is being compiled against.
-// A compilation error at this line likely means your copy of the
-// proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
 
 type ResetClientRequest struct {
-	MazeId               string   `protobuf:"bytes,1,opt,name=maze_id,json=mazeId,proto3" json:"maze_id,omitempty"`
-	ClientId             string   `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *ResetClientRequest) Reset()         { *m = ResetClientRequest{} }
-func (m *ResetClientRequest) String() string { return proto.CompactTextString(m) }
-func (*ResetClientRequest) ProtoMessage()    {}
-func (*ResetClientRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{0}
+	MazeId   string `protobuf:"bytes,1,opt,name=maze_id,json=mazeId,proto3" json:"maze_id,omitempty"`
+	ClientId string `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
 }
 
-func (m *ResetClientRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ResetClientRequest.Unmarshal(m, b)
-}
-func (m *ResetClientRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ResetClientRequest.Marshal(b, m, deterministic)
-}
-func (m *ResetClientRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResetClientRequest.Merge(m, src)
+func (x *ResetClientRequest) Reset() {
+	*x = ResetClientRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *ResetClientRequest) XXX_Size() int {
-	return xxx_messageInfo_ResetClientRequest.Size(m)
+
+func (x *ResetClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *ResetClientRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResetClientRequest.DiscardUnknown(m)
+
+func (*ResetClientRequest) ProtoMessage() {}
+
+func (x *ResetClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_ResetClientRequest proto.InternalMessageInfo
+// Deprecated: Use ResetClientRequest.ProtoReflect.Descriptor instead.
+func (*ResetClientRequest) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{0}
+}
 
-func (m *ResetClientRequest) GetMazeId() string {
-	if m != nil {
-		return m.MazeId
+func (x *ResetClientRequest) GetMazeId() string {
+	if x != nil {
+		return x.MazeId
 	}
 	return ""
 }
 
-func (m *ResetClientRequest) GetClientId() string {
-	if m != nil {
-		return m.ClientId
+func (x *ResetClientRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
 	}
 	return ""
 }
 
 type ResetClientReply struct {
-	Success              bool          `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message              string        `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	CurrentLocation      *MazeLocation `protobuf:"bytes,3,opt,name=current_location,json=currentLocation,proto3" json:"current_location,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *ResetClientReply) Reset()         { *m = ResetClientReply{} }
-func (m *ResetClientReply) String() string { return proto.CompactTextString(m) }
-func (*ResetClientReply) ProtoMessage()    {}
-func (*ResetClientReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{1}
+	Success         bool          `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message         string        `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	CurrentLocation *MazeLocation `protobuf:"bytes,3,opt,name=current_location,json=currentLocation,proto3" json:"current_location,omitempty"`
 }
 
-func (m *ResetClientReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ResetClientReply.Unmarshal(m, b)
-}
-func (m *ResetClientReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ResetClientReply.Marshal(b, m, deterministic)
-}
-func (m *ResetClientReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResetClientReply.Merge(m, src)
+func (x *ResetClientReply) Reset() {
+	*x = ResetClientReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *ResetClientReply) XXX_Size() int {
-	return xxx_messageInfo_ResetClientReply.Size(m)
+
+func (x *ResetClientReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *ResetClientReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResetClientReply.DiscardUnknown(m)
+
+func (*ResetClientReply) ProtoMessage() {}
+
+func (x *ResetClientReply) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_ResetClientReply proto.InternalMessageInfo
+// Deprecated: Use ResetClientReply.ProtoReflect.Descriptor instead.
+func (*ResetClientReply) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{1}
+}
 
-func (m *ResetClientReply) GetSuccess() bool {
-	if m != nil {
-		return m.Success
+func (x *ResetClientReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
 	}
 	return false
 }
 
-func (m *ResetClientReply) GetMessage() string {
-	if m != nil {
-		return m.Message
+func (x *ResetClientReply) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (m *ResetClientReply) GetCurrentLocation() *MazeLocation {
-	if m != nil {
-		return m.CurrentLocation
+func (x *ResetClientReply) GetCurrentLocation() *MazeLocation {
+	if x != nil {
+		return x.CurrentLocation
 	}
 	return nil
 }
 
 type ExportMazeRequest struct {
-	MazeId               string   `protobuf:"bytes,1,opt,name=maze_id,json=mazeId,proto3" json:"maze_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *ExportMazeRequest) Reset()         { *m = ExportMazeRequest{} }
-func (m *ExportMazeRequest) String() string { return proto.CompactTextString(m) }
-func (*ExportMazeRequest) ProtoMessage()    {}
-func (*ExportMazeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{2}
+	MazeId string `protobuf:"bytes,1,opt,name=maze_id,json=mazeId,proto3" json:"maze_id,omitempty"`
 }
 
-func (m *ExportMazeRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ExportMazeRequest.Unmarshal(m, b)
-}
-func (m *ExportMazeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ExportMazeRequest.Marshal(b, m, deterministic)
-}
-func (m *ExportMazeRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ExportMazeRequest.Merge(m, src)
+func (x *ExportMazeRequest) Reset() {
+	*x = ExportMazeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *ExportMazeRequest) XXX_Size() int {
-	return xxx_messageInfo_ExportMazeRequest.Size(m)
+
+func (x *ExportMazeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *ExportMazeRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ExportMazeRequest.DiscardUnknown(m)
+
+func (*ExportMazeRequest) ProtoMessage() {}
+
+func (x *ExportMazeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_ExportMazeRequest proto.InternalMessageInfo
+// Deprecated: Use ExportMazeRequest.ProtoReflect.Descriptor instead.
+func (*ExportMazeRequest) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{2}
+}
 
-func (m *ExportMazeRequest) GetMazeId() string {
-	if m != nil {
-		return m.MazeId
+func (x *ExportMazeRequest) GetMazeId() string {
+	if x != nil {
+		return x.MazeId
 	}
 	return ""
 }
 
 type ExportMazeReply struct {
-	Success              bool     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *ExportMazeReply) Reset()         { *m = ExportMazeReply{} }
-func (m *ExportMazeReply) String() string { return proto.CompactTextString(m) }
-func (*ExportMazeReply) ProtoMessage()    {}
-func (*ExportMazeReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{3}
+	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 }
 
-func (m *ExportMazeReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ExportMazeReply.Unmarshal(m, b)
-}
-func (m *ExportMazeReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ExportMazeReply.Marshal(b, m, deterministic)
-}
-func (m *ExportMazeReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ExportMazeReply.Merge(m, src)
+func (x *ExportMazeReply) Reset() {
+	*x = ExportMazeReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *ExportMazeReply) XXX_Size() int {
-	return xxx_messageInfo_ExportMazeReply.Size(m)
+
+func (x *ExportMazeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *ExportMazeReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_ExportMazeReply.DiscardUnknown(m)
+
+func (*ExportMazeReply) ProtoMessage() {}
+
+func (x *ExportMazeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_ExportMazeReply proto.InternalMessageInfo
+// Deprecated: Use ExportMazeReply.ProtoReflect.Descriptor instead.
+func (*ExportMazeReply) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{3}
+}
 
-func (m *ExportMazeReply) GetSuccess() bool {
-	if m != nil {
-		return m.Success
+func (x *ExportMazeReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
 	}
 	return false
 }
 
-func (m *ExportMazeReply) GetMessage() string {
-	if m != nil {
-		return m.Message
+func (x *ExportMazeReply) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-type RegisterClientRequest struct {
-	MazeId               string        `protobuf:"bytes,1,opt,name=maze_id,json=mazeId,proto3" json:"maze_id,omitempty"`
-	ClientConfig         *ClientConfig `protobuf:"bytes,2,opt,name=client_config,json=clientConfig,proto3" json:"client_config,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
-}
+type RenderMazeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *RegisterClientRequest) Reset()         { *m = RegisterClientRequest{} }
-func (m *RegisterClientRequest) String() string { return proto.CompactTextString(m) }
-func (*RegisterClientRequest) ProtoMessage()    {}
-func (*RegisterClientRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{4}
+	MazeId             string `protobuf:"bytes,1,opt,name=maze_id,json=mazeId,proto3" json:"maze_id,omitempty"`
+	ClientId           string `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`                                  // draw the path and from/to cells of this client, none if empty
+	Format             string `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`                                                      // "png" (default) or "svg"
+	ShowDistanceColors bool   `protobuf:"varint,4,opt,name=show_distance_colors,json=showDistanceColors,proto3" json:"show_distance_colors,omitempty"` // shade cells by distance from the client's from cell
 }
 
-func (m *RegisterClientRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RegisterClientRequest.Unmarshal(m, b)
-}
-func (m *RegisterClientRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RegisterClientRequest.Marshal(b, m, deterministic)
-}
-func (m *RegisterClientRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RegisterClientRequest.Merge(m, src)
+func (x *RenderMazeRequest) Reset() {
+	*x = RenderMazeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *RegisterClientRequest) XXX_Size() int {
-	return xxx_messageInfo_RegisterClientRequest.Size(m)
+
+func (x *RenderMazeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *RegisterClientRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_RegisterClientRequest.DiscardUnknown(m)
+
+func (*RenderMazeRequest) ProtoMessage() {}
+
+func (x *RenderMazeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_RegisterClientRequest proto.InternalMessageInfo
+// Deprecated: Use RenderMazeRequest.ProtoReflect.Descriptor instead.
+func (*RenderMazeRequest) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{4}
+}
 
-func (m *RegisterClientRequest) GetMazeId() string {
-	if m != nil {
-		return m.MazeId
+func (x *RenderMazeRequest) GetMazeId() string {
+	if x != nil {
+		return x.MazeId
 	}
 	return ""
 }
 
-func (m *RegisterClientRequest) GetClientConfig() *ClientConfig {
-	if m != nil {
-		return m.ClientConfig
+func (x *RenderMazeRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
 	}
-	return nil
+	return ""
 }
 
-type RegisterClientReply struct {
-	Success              bool          `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message              string        `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	ClientId             string        `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	FromCell             *MazeLocation `protobuf:"bytes,4,opt,name=from_cell,json=fromCell,proto3" json:"from_cell,omitempty"`
-	ToCell               *MazeLocation `protobuf:"bytes,5,opt,name=to_cell,json=toCell,proto3" json:"to_cell,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
-}
-
-func (m *RegisterClientReply) Reset()         { *m = RegisterClientReply{} }
-func (m *RegisterClientReply) String() string { return proto.CompactTextString(m) }
-func (*RegisterClientReply) ProtoMessage()    {}
-func (*RegisterClientReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{5}
+func (x *RenderMazeRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
 }
 
-func (m *RegisterClientReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RegisterClientReply.Unmarshal(m, b)
-}
-func (m *RegisterClientReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RegisterClientReply.Marshal(b, m, deterministic)
+func (x *RenderMazeRequest) GetShowDistanceColors() bool {
+	if x != nil {
+		return x.ShowDistanceColors
+	}
+	return false
 }
-func (m *RegisterClientReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RegisterClientReply.Merge(m, src)
+
+type RenderMazeReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Image   []byte `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
 }
-func (m *RegisterClientReply) XXX_Size() int {
-	return xxx_messageInfo_RegisterClientReply.Size(m)
+
+func (x *RenderMazeReply) Reset() {
+	*x = RenderMazeReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *RegisterClientReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_RegisterClientReply.DiscardUnknown(m)
+
+func (x *RenderMazeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_RegisterClientReply proto.InternalMessageInfo
+func (*RenderMazeReply) ProtoMessage() {}
 
-func (m *RegisterClientReply) GetSuccess() bool {
-	if m != nil {
-		return m.Success
+func (x *RenderMazeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (m *RegisterClientReply) GetMessage() string {
-	if m != nil {
-		return m.Message
-	}
-	return ""
+// Deprecated: Use RenderMazeReply.ProtoReflect.Descriptor instead.
+func (*RenderMazeReply) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{5}
 }
 
-func (m *RegisterClientReply) GetClientId() string {
-	if m != nil {
-		return m.ClientId
+func (x *RenderMazeReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (m *RegisterClientReply) GetFromCell() *MazeLocation {
-	if m != nil {
-		return m.FromCell
+func (x *RenderMazeReply) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
-func (m *RegisterClientReply) GetToCell() *MazeLocation {
-	if m != nil {
-		return m.ToCell
+func (x *RenderMazeReply) GetImage() []byte {
+	if x != nil {
+		return x.Image
 	}
 	return nil
 }
 
-// SolveMazeRequest is a message sent from the client trying to solve a maze
-type SolveMazeRequest struct {
-	MazeId    string `protobuf:"bytes,1,opt,name=mazeId,proto3" json:"mazeId,omitempty"`
-	ClientId  string `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	Direction string `protobuf:"bytes,3,opt,name=direction,proto3" json:"direction,omitempty"`
-	// on first connect, this must be set true, the direction field is ignored, the client does not move
-	Initial bool `protobuf:"varint,4,opt,name=initial,proto3" json:"initial,omitempty"`
-	// move client back to previous location, direction is ignored
-	MoveBack             bool     `protobuf:"varint,5,opt,name=move_back,json=moveBack,proto3" json:"move_back,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+type GetMazeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *SolveMazeRequest) Reset()         { *m = SolveMazeRequest{} }
-func (m *SolveMazeRequest) String() string { return proto.CompactTextString(m) }
-func (*SolveMazeRequest) ProtoMessage()    {}
-func (*SolveMazeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{6}
+	MazeId string `protobuf:"bytes,1,opt,name=maze_id,json=mazeId,proto3" json:"maze_id,omitempty"`
 }
 
-func (m *SolveMazeRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SolveMazeRequest.Unmarshal(m, b)
-}
-func (m *SolveMazeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SolveMazeRequest.Marshal(b, m, deterministic)
-}
-func (m *SolveMazeRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SolveMazeRequest.Merge(m, src)
-}
-func (m *SolveMazeRequest) XXX_Size() int {
-	return xxx_messageInfo_SolveMazeRequest.Size(m)
+func (x *GetMazeRequest) Reset() {
+	*x = GetMazeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *SolveMazeRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SolveMazeRequest.DiscardUnknown(m)
+
+func (x *GetMazeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_SolveMazeRequest proto.InternalMessageInfo
+func (*GetMazeRequest) ProtoMessage() {}
 
-func (m *SolveMazeRequest) GetMazeId() string {
-	if m != nil {
-		return m.MazeId
+func (x *GetMazeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (m *SolveMazeRequest) GetClientId() string {
-	if m != nil {
-		return m.ClientId
-	}
-	return ""
+// Deprecated: Use GetMazeRequest.ProtoReflect.Descriptor instead.
+func (*GetMazeRequest) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{6}
 }
 
-func (m *SolveMazeRequest) GetDirection() string {
-	if m != nil {
-		return m.Direction
+func (x *GetMazeRequest) GetMazeId() string {
+	if x != nil {
+		return x.MazeId
 	}
 	return ""
 }
 
-func (m *SolveMazeRequest) GetInitial() bool {
-	if m != nil {
-		return m.Initial
-	}
-	return false
+type GetMazeReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Maze    *Maze  `protobuf:"bytes,3,opt,name=maze,proto3" json:"maze,omitempty"`
 }
 
-func (m *SolveMazeRequest) GetMoveBack() bool {
-	if m != nil {
-		return m.MoveBack
+func (x *GetMazeReply) Reset() {
+	*x = GetMazeReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return false
 }
 
-// SolveMazeResponse is a response sent from the server as the client tries to solve a maze
-type SolveMazeResponse struct {
-	MazeId               string        `protobuf:"bytes,1,opt,name=maze_id,json=mazeId,proto3" json:"maze_id,omitempty"`
-	ClientId             string        `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	AvailableDirections  []*Direction  `protobuf:"bytes,3,rep,name=available_directions,json=availableDirections,proto3" json:"available_directions,omitempty"`
-	Initial              bool          `protobuf:"varint,4,opt,name=initial,proto3" json:"initial,omitempty"`
-	Error                bool          `protobuf:"varint,5,opt,name=error,proto3" json:"error,omitempty"`
-	ErrorMessage         string        `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	CurrentLocation      *MazeLocation `protobuf:"bytes,7,opt,name=current_location,json=currentLocation,proto3" json:"current_location,omitempty"`
-	FromCell             *MazeLocation `protobuf:"bytes,8,opt,name=from_cell,json=fromCell,proto3" json:"from_cell,omitempty"`
-	ToCell               *MazeLocation `protobuf:"bytes,9,opt,name=to_cell,json=toCell,proto3" json:"to_cell,omitempty"`
-	Solved               bool          `protobuf:"varint,10,opt,name=solved,proto3" json:"solved,omitempty"`
-	Reward               float64       `protobuf:"fixed64,11,opt,name=reward,proto3" json:"reward,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
-}
-
-func (m *SolveMazeResponse) Reset()         { *m = SolveMazeResponse{} }
-func (m *SolveMazeResponse) String() string { return proto.CompactTextString(m) }
-func (*SolveMazeResponse) ProtoMessage()    {}
-func (*SolveMazeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{7}
+func (x *GetMazeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *SolveMazeResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SolveMazeResponse.Unmarshal(m, b)
-}
-func (m *SolveMazeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SolveMazeResponse.Marshal(b, m, deterministic)
-}
-func (m *SolveMazeResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SolveMazeResponse.Merge(m, src)
-}
-func (m *SolveMazeResponse) XXX_Size() int {
-	return xxx_messageInfo_SolveMazeResponse.Size(m)
-}
-func (m *SolveMazeResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SolveMazeResponse.DiscardUnknown(m)
+func (*GetMazeReply) ProtoMessage() {}
+
+func (x *GetMazeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_SolveMazeResponse proto.InternalMessageInfo
+// Deprecated: Use GetMazeReply.ProtoReflect.Descriptor instead.
+func (*GetMazeReply) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{7}
+}
 
-func (m *SolveMazeResponse) GetMazeId() string {
-	if m != nil {
-		return m.MazeId
+func (x *GetMazeReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (m *SolveMazeResponse) GetClientId() string {
-	if m != nil {
-		return m.ClientId
+func (x *GetMazeReply) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (m *SolveMazeResponse) GetAvailableDirections() []*Direction {
-	if m != nil {
-		return m.AvailableDirections
+func (x *GetMazeReply) GetMaze() *Maze {
+	if x != nil {
+		return x.Maze
 	}
 	return nil
 }
 
-func (m *SolveMazeResponse) GetInitial() bool {
-	if m != nil {
-		return m.Initial
+type DeleteMazeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MazeId string `protobuf:"bytes,1,opt,name=maze_id,json=mazeId,proto3" json:"maze_id,omitempty"`
+}
+
+func (x *DeleteMazeRequest) Reset() {
+	*x = DeleteMazeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return false
 }
 
-func (m *SolveMazeResponse) GetError() bool {
-	if m != nil {
-		return m.Error
+func (x *DeleteMazeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMazeRequest) ProtoMessage() {}
+
+func (x *DeleteMazeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMazeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMazeRequest) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{8}
 }
 
-func (m *SolveMazeResponse) GetErrorMessage() string {
-	if m != nil {
-		return m.ErrorMessage
+func (x *DeleteMazeRequest) GetMazeId() string {
+	if x != nil {
+		return x.MazeId
 	}
 	return ""
 }
 
-func (m *SolveMazeResponse) GetCurrentLocation() *MazeLocation {
-	if m != nil {
-		return m.CurrentLocation
-	}
-	return nil
+type DeleteMazeReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 }
 
-func (m *SolveMazeResponse) GetFromCell() *MazeLocation {
-	if m != nil {
-		return m.FromCell
+func (x *DeleteMazeReply) Reset() {
+	*x = DeleteMazeReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (m *SolveMazeResponse) GetToCell() *MazeLocation {
-	if m != nil {
-		return m.ToCell
+func (x *DeleteMazeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMazeReply) ProtoMessage() {}
+
+func (x *DeleteMazeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (m *SolveMazeResponse) GetSolved() bool {
-	if m != nil {
-		return m.Solved
+// Deprecated: Use DeleteMazeReply.ProtoReflect.Descriptor instead.
+func (*DeleteMazeReply) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteMazeReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
 	}
 	return false
 }
 
-func (m *SolveMazeResponse) GetReward() float64 {
-	if m != nil {
-		return m.Reward
+func (x *DeleteMazeReply) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-type Direction struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Visited              bool     `protobuf:"varint,2,opt,name=visited,proto3" json:"visited,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+type ImportMazeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *Direction) Reset()         { *m = Direction{} }
-func (m *Direction) String() string { return proto.CompactTextString(m) }
-func (*Direction) ProtoMessage()    {}
-func (*Direction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{8}
+	// display config; grid_type must match the encoded maze, rows, columns and levels are
+	// taken from it and must match if set
+	Config            *MazeConfig `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	EncodedMaze       string      `protobuf:"bytes,2,opt,name=encoded_maze,json=encodedMaze,proto3" json:"encoded_maze,omitempty"`                      // as returned by CreateMaze (return_maze) or written by ExportMaze
+	CheckSpanningTree bool        `protobuf:"varint,3,opt,name=check_spanning_tree,json=checkSpanningTree,proto3" json:"check_spanning_tree,omitempty"` // also require a perfect maze: no loops and every cell reachable
 }
 
-func (m *Direction) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Direction.Unmarshal(m, b)
-}
-func (m *Direction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Direction.Marshal(b, m, deterministic)
+func (x *ImportMazeRequest) Reset() {
+	*x = ImportMazeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *Direction) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Direction.Merge(m, src)
+
+func (x *ImportMazeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *Direction) XXX_Size() int {
-	return xxx_messageInfo_Direction.Size(m)
+
+func (*ImportMazeRequest) ProtoMessage() {}
+
+func (x *ImportMazeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-func (m *Direction) XXX_DiscardUnknown() {
-	xxx_messageInfo_Direction.DiscardUnknown(m)
+
+// Deprecated: Use ImportMazeRequest.ProtoReflect.Descriptor instead.
+func (*ImportMazeRequest) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{10}
 }
 
-var xxx_messageInfo_Direction proto.InternalMessageInfo
+func (x *ImportMazeRequest) GetConfig() *MazeConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
 
-func (m *Direction) GetName() string {
-	if m != nil {
-		return m.Name
+func (x *ImportMazeRequest) GetEncodedMaze() string {
+	if x != nil {
+		return x.EncodedMaze
 	}
 	return ""
 }
 
-func (m *Direction) GetVisited() bool {
-	if m != nil {
-		return m.Visited
+func (x *ImportMazeRequest) GetCheckSpanningTree() bool {
+	if x != nil {
+		return x.CheckSpanningTree
 	}
 	return false
 }
 
-// Maze defines a maze and its clients
-type Maze struct {
-	MazeId               string   `protobuf:"bytes,1,opt,name=mazeId,proto3" json:"mazeId,omitempty"`
-	Cells                []*Cell  `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty"`
-	ClientIds            []string `protobuf:"bytes,3,rep,name=clientIds,proto3" json:"clientIds,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+type ImportMazeReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *Maze) Reset()         { *m = Maze{} }
-func (m *Maze) String() string { return proto.CompactTextString(m) }
-func (*Maze) ProtoMessage()    {}
-func (*Maze) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{9}
+	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	MazeId  string `protobuf:"bytes,3,opt,name=maze_id,json=mazeId,proto3" json:"maze_id,omitempty"`
 }
 
-func (m *Maze) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Maze.Unmarshal(m, b)
-}
-func (m *Maze) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Maze.Marshal(b, m, deterministic)
+func (x *ImportMazeReply) Reset() {
+	*x = ImportMazeReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *Maze) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Maze.Merge(m, src)
+
+func (x *ImportMazeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *Maze) XXX_Size() int {
-	return xxx_messageInfo_Maze.Size(m)
+
+func (*ImportMazeReply) ProtoMessage() {}
+
+func (x *ImportMazeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-func (m *Maze) XXX_DiscardUnknown() {
-	xxx_messageInfo_Maze.DiscardUnknown(m)
+
+// Deprecated: Use ImportMazeReply.ProtoReflect.Descriptor instead.
+func (*ImportMazeReply) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{11}
 }
 
-var xxx_messageInfo_Maze proto.InternalMessageInfo
+func (x *ImportMazeReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
 
-func (m *Maze) GetMazeId() string {
-	if m != nil {
-		return m.MazeId
+func (x *ImportMazeReply) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (m *Maze) GetCells() []*Cell {
-	if m != nil {
-		return m.Cells
+func (x *ImportMazeReply) GetMazeId() string {
+	if x != nil {
+		return x.MazeId
 	}
-	return nil
+	return ""
 }
 
-func (m *Maze) GetClientIds() []string {
-	if m != nil {
-		return m.ClientIds
-	}
-	return nil
+type WatchMazeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MazeId string `protobuf:"bytes,1,opt,name=maze_id,json=mazeId,proto3" json:"maze_id,omitempty"` // empty watches all mazes, including ones created later (e.g. to see them generated)
 }
 
-type Cell struct {
-	Location             *MazeLocation `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+func (x *WatchMazeRequest) Reset() {
+	*x = WatchMazeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (m *Cell) Reset()         { *m = Cell{} }
-func (m *Cell) String() string { return proto.CompactTextString(m) }
-func (*Cell) ProtoMessage()    {}
-func (*Cell) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{10}
+func (x *WatchMazeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *Cell) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Cell.Unmarshal(m, b)
+func (*WatchMazeRequest) ProtoMessage() {}
+
+func (x *WatchMazeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-func (m *Cell) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Cell.Marshal(b, m, deterministic)
+
+// Deprecated: Use WatchMazeRequest.ProtoReflect.Descriptor instead.
+func (*WatchMazeRequest) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{12}
 }
-func (m *Cell) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Cell.Merge(m, src)
+
+func (x *WatchMazeRequest) GetMazeId() string {
+	if x != nil {
+		return x.MazeId
+	}
+	return ""
 }
-func (m *Cell) XXX_Size() int {
-	return xxx_messageInfo_Cell.Size(m)
+
+// MazeEvent is something that happened in a maze, sent to its watchers
+type MazeEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// generator-step, client-registered, client-reset, client-moved, client-solved, maze-changed or maze-deleted
+	Type      string          `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	MazeId    string          `protobuf:"bytes,2,opt,name=maze_id,json=mazeId,proto3" json:"maze_id,omitempty"`
+	ClientId  string          `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`  // client events only
+	Location  *MazeLocation   `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`                  // where the generator or the client is after the event
+	Direction string          `protobuf:"bytes,5,opt,name=direction,proto3" json:"direction,omitempty"`                // client-moved: the direction of the move
+	MoveBack  bool            `protobuf:"varint,6,opt,name=move_back,json=moveBack,proto3" json:"move_back,omitempty"` // client-moved: the client moved back to its previous location
+	Reward    float64         `protobuf:"fixed64,7,opt,name=reward,proto3" json:"reward,omitempty"`                    // client-moved: the reward sent to the client for the move
+	ToCell    *MazeLocation   `protobuf:"bytes,8,opt,name=to_cell,json=toCell,proto3" json:"to_cell,omitempty"`        // client-registered: the cell the client is trying to reach
+	Time      int64           `protobuf:"varint,9,opt,name=time,proto3" json:"time,omitempty"`                         // unix nanoseconds
+	Dropped   int64           `protobuf:"varint,10,opt,name=dropped,proto3" json:"dropped,omitempty"`                  // events this watcher missed right before this one, because it did not keep up
+	Cells     []*MazeLocation `protobuf:"bytes,11,rep,name=cells,proto3" json:"cells,omitempty"`                       // maze-changed: the cells whose passages changed
+}
+
+func (x *MazeEvent) Reset() {
+	*x = MazeEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *Cell) XXX_DiscardUnknown() {
-	xxx_messageInfo_Cell.DiscardUnknown(m)
+
+func (x *MazeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_Cell proto.InternalMessageInfo
+func (*MazeEvent) ProtoMessage() {}
 
-func (m *Cell) GetLocation() *MazeLocation {
-	if m != nil {
-		return m.Location
+func (x *MazeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type ListMazeRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// Deprecated: Use MazeEvent.ProtoReflect.Descriptor instead.
+func (*MazeEvent) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{13}
 }
 
-func (m *ListMazeRequest) Reset()         { *m = ListMazeRequest{} }
-func (m *ListMazeRequest) String() string { return proto.CompactTextString(m) }
-func (*ListMazeRequest) ProtoMessage()    {}
-func (*ListMazeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{11}
+func (x *MazeEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
 }
 
-func (m *ListMazeRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListMazeRequest.Unmarshal(m, b)
+func (x *MazeEvent) GetMazeId() string {
+	if x != nil {
+		return x.MazeId
+	}
+	return ""
 }
-func (m *ListMazeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListMazeRequest.Marshal(b, m, deterministic)
+
+func (x *MazeEvent) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
 }
-func (m *ListMazeRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListMazeRequest.Merge(m, src)
+
+func (x *MazeEvent) GetLocation() *MazeLocation {
+	if x != nil {
+		return x.Location
+	}
+	return nil
 }
-func (m *ListMazeRequest) XXX_Size() int {
-	return xxx_messageInfo_ListMazeRequest.Size(m)
+
+func (x *MazeEvent) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
 }
-func (m *ListMazeRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListMazeRequest.DiscardUnknown(m)
+
+func (x *MazeEvent) GetMoveBack() bool {
+	if x != nil {
+		return x.MoveBack
+	}
+	return false
 }
 
-var xxx_messageInfo_ListMazeRequest proto.InternalMessageInfo
+func (x *MazeEvent) GetReward() float64 {
+	if x != nil {
+		return x.Reward
+	}
+	return 0
+}
 
-type ListMazeReply struct {
-	Mazes                []*Maze  `protobuf:"bytes,1,rep,name=mazes,proto3" json:"mazes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *MazeEvent) GetToCell() *MazeLocation {
+	if x != nil {
+		return x.ToCell
+	}
+	return nil
 }
 
-func (m *ListMazeReply) Reset()         { *m = ListMazeReply{} }
-func (m *ListMazeReply) String() string { return proto.CompactTextString(m) }
-func (*ListMazeReply) ProtoMessage()    {}
-func (*ListMazeReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{12}
+func (x *MazeEvent) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
 }
 
-func (m *ListMazeReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListMazeReply.Unmarshal(m, b)
+func (x *MazeEvent) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
 }
-func (m *ListMazeReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListMazeReply.Marshal(b, m, deterministic)
+
+func (x *MazeEvent) GetCells() []*MazeLocation {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
 }
-func (m *ListMazeReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListMazeReply.Merge(m, src)
+
+type RegisterClientRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MazeId       string        `protobuf:"bytes,1,opt,name=maze_id,json=mazeId,proto3" json:"maze_id,omitempty"`
+	ClientConfig *ClientConfig `protobuf:"bytes,2,opt,name=client_config,json=clientConfig,proto3" json:"client_config,omitempty"`
 }
-func (m *ListMazeReply) XXX_Size() int {
-	return xxx_messageInfo_ListMazeReply.Size(m)
+
+func (x *RegisterClientRequest) Reset() {
+	*x = RegisterClientRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *ListMazeReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListMazeReply.DiscardUnknown(m)
+
+func (x *RegisterClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_ListMazeReply proto.InternalMessageInfo
+func (*RegisterClientRequest) ProtoMessage() {}
 
-func (m *ListMazeReply) GetMazes() []*Maze {
-	if m != nil {
-		return m.Mazes
+func (x *RegisterClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type CreateMazeRequest struct {
-	Config               *MazeConfig `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
-	ReturnMaze           bool        `protobuf:"varint,2,opt,name=return_maze,json=returnMaze,proto3" json:"return_maze,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+// Deprecated: Use RegisterClientRequest.ProtoReflect.Descriptor instead.
+func (*RegisterClientRequest) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{14}
 }
 
-func (m *CreateMazeRequest) Reset()         { *m = CreateMazeRequest{} }
-func (m *CreateMazeRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateMazeRequest) ProtoMessage()    {}
-func (*CreateMazeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{13}
+func (x *RegisterClientRequest) GetMazeId() string {
+	if x != nil {
+		return x.MazeId
+	}
+	return ""
 }
 
-func (m *CreateMazeRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateMazeRequest.Unmarshal(m, b)
-}
-func (m *CreateMazeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateMazeRequest.Marshal(b, m, deterministic)
-}
-func (m *CreateMazeRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateMazeRequest.Merge(m, src)
+func (x *RegisterClientRequest) GetClientConfig() *ClientConfig {
+	if x != nil {
+		return x.ClientConfig
+	}
+	return nil
 }
-func (m *CreateMazeRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateMazeRequest.Size(m)
+
+type RegisterClientReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success  bool          `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message  string        `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ClientId string        `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	FromCell *MazeLocation `protobuf:"bytes,4,opt,name=from_cell,json=fromCell,proto3" json:"from_cell,omitempty"`
+	ToCell   *MazeLocation `protobuf:"bytes,5,opt,name=to_cell,json=toCell,proto3" json:"to_cell,omitempty"`
+}
+
+func (x *RegisterClientReply) Reset() {
+	*x = RegisterClientReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *CreateMazeRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateMazeRequest.DiscardUnknown(m)
+
+func (x *RegisterClientReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_CreateMazeRequest proto.InternalMessageInfo
+func (*RegisterClientReply) ProtoMessage() {}
 
-func (m *CreateMazeRequest) GetConfig() *MazeConfig {
-	if m != nil {
-		return m.Config
+func (x *RegisterClientReply) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterClientReply.ProtoReflect.Descriptor instead.
+func (*RegisterClientReply) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{15}
 }
 
-func (m *CreateMazeRequest) GetReturnMaze() bool {
-	if m != nil {
-		return m.ReturnMaze
+func (x *RegisterClientReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
 	}
 	return false
 }
 
-type CreateMazeReply struct {
-	MazeId               string   `protobuf:"bytes,1,opt,name=MazeId,proto3" json:"MazeId,omitempty"`
-	EncodedMaze          string   `protobuf:"bytes,2,opt,name=encoded_maze,json=encodedMaze,proto3" json:"encoded_maze,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *RegisterClientReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
 }
 
-func (m *CreateMazeReply) Reset()         { *m = CreateMazeReply{} }
-func (m *CreateMazeReply) String() string { return proto.CompactTextString(m) }
-func (*CreateMazeReply) ProtoMessage()    {}
-func (*CreateMazeReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{14}
+func (x *RegisterClientReply) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
 }
 
-func (m *CreateMazeReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateMazeReply.Unmarshal(m, b)
+func (x *RegisterClientReply) GetFromCell() *MazeLocation {
+	if x != nil {
+		return x.FromCell
+	}
+	return nil
 }
-func (m *CreateMazeReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateMazeReply.Marshal(b, m, deterministic)
+
+func (x *RegisterClientReply) GetToCell() *MazeLocation {
+	if x != nil {
+		return x.ToCell
+	}
+	return nil
 }
-func (m *CreateMazeReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateMazeReply.Merge(m, src)
+
+// SolveMazeRequest is a message sent from the client trying to solve a maze
+type SolveMazeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MazeId    string `protobuf:"bytes,1,opt,name=mazeId,proto3" json:"mazeId,omitempty"`                     // the id of the maze we are solving
+	ClientId  string `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"` // the client id
+	Direction string `protobuf:"bytes,3,opt,name=direction,proto3" json:"direction,omitempty"`               // where the client wants to move next
+	// on first connect, this must be set true, the direction field is ignored, the client does not move
+	Initial bool `protobuf:"varint,4,opt,name=initial,proto3" json:"initial,omitempty"`
+	// move client back to previous location, direction is ignored
+	MoveBack bool `protobuf:"varint,5,opt,name=move_back,json=moveBack,proto3" json:"move_back,omitempty"`
 }
-func (m *CreateMazeReply) XXX_Size() int {
-	return xxx_messageInfo_CreateMazeReply.Size(m)
+
+func (x *SolveMazeRequest) Reset() {
+	*x = SolveMazeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *CreateMazeReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateMazeReply.DiscardUnknown(m)
+
+func (x *SolveMazeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_CreateMazeReply proto.InternalMessageInfo
+func (*SolveMazeRequest) ProtoMessage() {}
 
-func (m *CreateMazeReply) GetMazeId() string {
-	if m != nil {
-		return m.MazeId
+func (x *SolveMazeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (m *CreateMazeReply) GetEncodedMaze() string {
-	if m != nil {
-		return m.EncodedMaze
-	}
+// Deprecated: Use SolveMazeRequest.ProtoReflect.Descriptor instead.
+func (*SolveMazeRequest) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SolveMazeRequest) GetMazeId() string {
+	if x != nil {
+		return x.MazeId
+	}
+	return ""
+}
+
+func (x *SolveMazeRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *SolveMazeRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *SolveMazeRequest) GetInitial() bool {
+	if x != nil {
+		return x.Initial
+	}
+	return false
+}
+
+func (x *SolveMazeRequest) GetMoveBack() bool {
+	if x != nil {
+		return x.MoveBack
+	}
+	return false
+}
+
+type RunSolverRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MazeId       string        `protobuf:"bytes,1,opt,name=maze_id,json=mazeId,proto3" json:"maze_id,omitempty"`
+	ClientConfig *ClientConfig `protobuf:"bytes,2,opt,name=client_config,json=clientConfig,proto3" json:"client_config,omitempty"` // SolveAlgo is the solver to run (any but manual and follow-policy)
+	DrawDelay    string        `protobuf:"bytes,3,opt,name=draw_delay,json=drawDelay,proto3" json:"draw_delay,omitempty"`          // time string, e.g. 50ms: wait this long after each move, to watch it in the server GUI
+}
+
+func (x *RunSolverRequest) Reset() {
+	*x = RunSolverRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunSolverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunSolverRequest) ProtoMessage() {}
+
+func (x *RunSolverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunSolverRequest.ProtoReflect.Descriptor instead.
+func (*RunSolverRequest) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RunSolverRequest) GetMazeId() string {
+	if x != nil {
+		return x.MazeId
+	}
+	return ""
+}
+
+func (x *RunSolverRequest) GetClientConfig() *ClientConfig {
+	if x != nil {
+		return x.ClientConfig
+	}
+	return nil
+}
+
+func (x *RunSolverRequest) GetDrawDelay() string {
+	if x != nil {
+		return x.DrawDelay
+	}
+	return ""
+}
+
+type RunSolverReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success    bool           `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message    string         `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ClientId   string         `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"` // the client registered to solve the maze, it stays in the maze
+	Solved     bool           `protobuf:"varint,4,opt,name=solved,proto3" json:"solved,omitempty"`
+	Steps      int64          `protobuf:"varint,5,opt,name=steps,proto3" json:"steps,omitempty"`                            // moves made, including moves back
+	SolvePath  []*PathSegment `protobuf:"bytes,6,rep,name=solve_path,json=solvePath,proto3" json:"solve_path,omitempty"`    // the moves from from_cell to to_cell, without dead ends the solver backed out of
+	TravelPath []*PathSegment `protobuf:"bytes,7,rep,name=travel_path,json=travelPath,proto3" json:"travel_path,omitempty"` // every move, oldest first
+	SolveTime  int64          `protobuf:"varint,8,opt,name=solve_time,json=solveTime,proto3" json:"solve_time,omitempty"`   // nanoseconds the solver ran for
+	FromCell   *MazeLocation  `protobuf:"bytes,9,opt,name=from_cell,json=fromCell,proto3" json:"from_cell,omitempty"`
+	ToCell     *MazeLocation  `protobuf:"bytes,10,opt,name=to_cell,json=toCell,proto3" json:"to_cell,omitempty"`
+}
+
+func (x *RunSolverReply) Reset() {
+	*x = RunSolverReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunSolverReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunSolverReply) ProtoMessage() {}
+
+func (x *RunSolverReply) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunSolverReply.ProtoReflect.Descriptor instead.
+func (*RunSolverReply) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RunSolverReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RunSolverReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RunSolverReply) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *RunSolverReply) GetSolved() bool {
+	if x != nil {
+		return x.Solved
+	}
+	return false
+}
+
+func (x *RunSolverReply) GetSteps() int64 {
+	if x != nil {
+		return x.Steps
+	}
+	return 0
+}
+
+func (x *RunSolverReply) GetSolvePath() []*PathSegment {
+	if x != nil {
+		return x.SolvePath
+	}
+	return nil
+}
+
+func (x *RunSolverReply) GetTravelPath() []*PathSegment {
+	if x != nil {
+		return x.TravelPath
+	}
+	return nil
+}
+
+func (x *RunSolverReply) GetSolveTime() int64 {
+	if x != nil {
+		return x.SolveTime
+	}
+	return 0
+}
+
+func (x *RunSolverReply) GetFromCell() *MazeLocation {
+	if x != nil {
+		return x.FromCell
+	}
+	return nil
+}
+
+func (x *RunSolverReply) GetToCell() *MazeLocation {
+	if x != nil {
+		return x.ToCell
+	}
+	return nil
+}
+
+type CreateMazesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Configs []*MazeConfig `protobuf:"bytes,1,rep,name=configs,proto3" json:"configs,omitempty"` // one maze per config
+	Config  *MazeConfig   `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`   // or count mazes with this config, if configs is empty
+	Count   int64         `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// maze i is generated from seed seed_start+i (see MazeConfig.Seed); 0 keeps the seeds in the configs,
+	// and picks distinct ones for the mazes without one
+	SeedStart   int64 `protobuf:"varint,4,opt,name=seed_start,json=seedStart,proto3" json:"seed_start,omitempty"`
+	ReturnMazes bool  `protobuf:"varint,5,opt,name=return_mazes,json=returnMazes,proto3" json:"return_mazes,omitempty"` // also return the mazes, ascii encoded
+}
+
+func (x *CreateMazesRequest) Reset() {
+	*x = CreateMazesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateMazesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMazesRequest) ProtoMessage() {}
+
+func (x *CreateMazesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMazesRequest.ProtoReflect.Descriptor instead.
+func (*CreateMazesRequest) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateMazesRequest) GetConfigs() []*MazeConfig {
+	if x != nil {
+		return x.Configs
+	}
+	return nil
+}
+
+func (x *CreateMazesRequest) GetConfig() *MazeConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *CreateMazesRequest) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CreateMazesRequest) GetSeedStart() int64 {
+	if x != nil {
+		return x.SeedStart
+	}
+	return 0
+}
+
+func (x *CreateMazesRequest) GetReturnMazes() bool {
+	if x != nil {
+		return x.ReturnMazes
+	}
+	return false
+}
+
+type CreateMazesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success      bool     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message      string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	MazeIds      []string `protobuf:"bytes,3,rep,name=maze_ids,json=mazeIds,proto3" json:"maze_ids,omitempty"`                // in the order of the configs, empty for mazes that failed
+	EncodedMazes []string `protobuf:"bytes,4,rep,name=encoded_mazes,json=encodedMazes,proto3" json:"encoded_mazes,omitempty"` // if return_mazes is set
+	Seeds        []int64  `protobuf:"varint,5,rep,packed,name=seeds,proto3" json:"seeds,omitempty"`                           // the seed of every maze, to make it again
+}
+
+func (x *CreateMazesReply) Reset() {
+	*x = CreateMazesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateMazesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMazesReply) ProtoMessage() {}
+
+func (x *CreateMazesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMazesReply.ProtoReflect.Descriptor instead.
+func (*CreateMazesReply) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateMazesReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateMazesReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateMazesReply) GetMazeIds() []string {
+	if x != nil {
+		return x.MazeIds
+	}
+	return nil
+}
+
+func (x *CreateMazesReply) GetEncodedMazes() []string {
+	if x != nil {
+		return x.EncodedMazes
+	}
+	return nil
+}
+
+func (x *CreateMazesReply) GetSeeds() []int64 {
+	if x != nil {
+		return x.Seeds
+	}
+	return nil
+}
+
+// SolveMazeResponse is a response sent from the server as the client tries to solve a maze
+type SolveMazeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MazeId              string        `protobuf:"bytes,1,opt,name=maze_id,json=mazeId,proto3" json:"maze_id,omitempty"`                                        // the id of the maze we are solving
+	ClientId            string        `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`                                  // the client id
+	AvailableDirections []*Direction  `protobuf:"bytes,3,rep,name=available_directions,json=availableDirections,proto3" json:"available_directions,omitempty"` // available directions the client can move from here
+	Initial             bool          `protobuf:"varint,4,opt,name=initial,proto3" json:"initial,omitempty"`                                                   // set true on initial response
+	Error               bool          `protobuf:"varint,5,opt,name=error,proto3" json:"error,omitempty"`                                                       // set if error occured
+	ErrorMessage        string        `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	CurrentLocation     *MazeLocation `protobuf:"bytes,7,opt,name=current_location,json=currentLocation,proto3" json:"current_location,omitempty"`
+	FromCell            *MazeLocation `protobuf:"bytes,8,opt,name=from_cell,json=fromCell,proto3" json:"from_cell,omitempty"`
+	ToCell              *MazeLocation `protobuf:"bytes,9,opt,name=to_cell,json=toCell,proto3" json:"to_cell,omitempty"`
+	Solved              bool          `protobuf:"varint,10,opt,name=solved,proto3" json:"solved,omitempty"`                              // set to true when client reaches the target cell
+	Reward              float64       `protobuf:"fixed64,11,opt,name=reward,proto3" json:"reward,omitempty"`                             // used in ML, reward for this move
+	MazeVersion         int64         `protobuf:"varint,12,opt,name=maze_version,json=mazeVersion,proto3" json:"maze_version,omitempty"` // goes up every time the passages of the maze change (see MazeConfig.Dynamics)
+	MazeChanged         bool          `protobuf:"varint,13,opt,name=maze_changed,json=mazeChanged,proto3" json:"maze_changed,omitempty"` // set if the maze changed since the last response to this client, plans made on it may be wrong
+}
+
+func (x *SolveMazeResponse) Reset() {
+	*x = SolveMazeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SolveMazeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SolveMazeResponse) ProtoMessage() {}
+
+func (x *SolveMazeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SolveMazeResponse.ProtoReflect.Descriptor instead.
+func (*SolveMazeResponse) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SolveMazeResponse) GetMazeId() string {
+	if x != nil {
+		return x.MazeId
+	}
+	return ""
+}
+
+func (x *SolveMazeResponse) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *SolveMazeResponse) GetAvailableDirections() []*Direction {
+	if x != nil {
+		return x.AvailableDirections
+	}
+	return nil
+}
+
+func (x *SolveMazeResponse) GetInitial() bool {
+	if x != nil {
+		return x.Initial
+	}
+	return false
+}
+
+func (x *SolveMazeResponse) GetError() bool {
+	if x != nil {
+		return x.Error
+	}
+	return false
+}
+
+func (x *SolveMazeResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *SolveMazeResponse) GetCurrentLocation() *MazeLocation {
+	if x != nil {
+		return x.CurrentLocation
+	}
+	return nil
+}
+
+func (x *SolveMazeResponse) GetFromCell() *MazeLocation {
+	if x != nil {
+		return x.FromCell
+	}
+	return nil
+}
+
+func (x *SolveMazeResponse) GetToCell() *MazeLocation {
+	if x != nil {
+		return x.ToCell
+	}
+	return nil
+}
+
+func (x *SolveMazeResponse) GetSolved() bool {
+	if x != nil {
+		return x.Solved
+	}
+	return false
+}
+
+func (x *SolveMazeResponse) GetReward() float64 {
+	if x != nil {
+		return x.Reward
+	}
+	return 0
+}
+
+func (x *SolveMazeResponse) GetMazeVersion() int64 {
+	if x != nil {
+		return x.MazeVersion
+	}
+	return 0
+}
+
+func (x *SolveMazeResponse) GetMazeChanged() bool {
+	if x != nil {
+		return x.MazeChanged
+	}
+	return false
+}
+
+type Direction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`        // e.g. north, south, east, west
+	Visited bool   `protobuf:"varint,2,opt,name=visited,proto3" json:"visited,omitempty"` // set to true if the client has already visited the cell in that direction
+}
+
+func (x *Direction) Reset() {
+	*x = Direction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Direction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Direction) ProtoMessage() {}
+
+func (x *Direction) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Direction.ProtoReflect.Descriptor instead.
+func (*Direction) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Direction) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Direction) GetVisited() bool {
+	if x != nil {
+		return x.Visited
+	}
+	return false
+}
+
+// Maze defines a maze and its clients
+// ListMazes only fills in the ids, GetMaze returns the whole maze.
+type Maze struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MazeId    string        `protobuf:"bytes,1,opt,name=mazeId,proto3" json:"mazeId,omitempty"`
+	Cells     []*Cell       `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty"` // every cell in the grid, including orphans
+	ClientIds []string      `protobuf:"bytes,3,rep,name=clientIds,proto3" json:"clientIds,omitempty"`
+	Config    *MazeConfig   `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"` // has the dimensions of the maze (Columns, Rows, Levels)
+	Clients   []*MazeClient `protobuf:"bytes,5,rep,name=clients,proto3" json:"clients,omitempty"`
+	Owner     string        `protobuf:"bytes,6,opt,name=owner,proto3" json:"owner,omitempty"` // hash of the auth token that created the maze, only it can use the maze (only set in the store)
+}
+
+func (x *Maze) Reset() {
+	*x = Maze{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Maze) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Maze) ProtoMessage() {}
+
+func (x *Maze) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Maze.ProtoReflect.Descriptor instead.
+func (*Maze) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Maze) GetMazeId() string {
+	if x != nil {
+		return x.MazeId
+	}
+	return ""
+}
+
+func (x *Maze) GetCells() []*Cell {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+func (x *Maze) GetClientIds() []string {
+	if x != nil {
+		return x.ClientIds
+	}
+	return nil
+}
+
+func (x *Maze) GetConfig() *MazeConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *Maze) GetClients() []*MazeClient {
+	if x != nil {
+		return x.Clients
+	}
+	return nil
+}
+
+func (x *Maze) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+type Cell struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location *MazeLocation    `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Links    []string         `protobuf:"bytes,2,rep,name=links,proto3" json:"links,omitempty"` // directions with a passage out of this cell, e.g. north, up, outward-1
+	Weight   int64            `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	Orphan   bool             `protobuf:"varint,4,opt,name=orphan,proto3" json:"orphan,omitempty"`
+	Under    *Cell            `protobuf:"bytes,5,opt,name=under,proto3" json:"under,omitempty"`                                                                                              // weave tunnel passing under this cell (same location), if any
+	Visited  map[string]int64 `protobuf:"bytes,6,rep,name=visited,proto3" json:"visited,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"` // client id -> number of times the client visited the cell
+}
+
+func (x *Cell) Reset() {
+	*x = Cell{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cell) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cell) ProtoMessage() {}
+
+func (x *Cell) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cell.ProtoReflect.Descriptor instead.
+func (*Cell) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Cell) GetLocation() *MazeLocation {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Cell) GetLinks() []string {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *Cell) GetWeight() int64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Cell) GetOrphan() bool {
+	if x != nil {
+		return x.Orphan
+	}
+	return false
+}
+
+func (x *Cell) GetUnder() *Cell {
+	if x != nil {
+		return x.Under
+	}
+	return nil
+}
+
+func (x *Cell) GetVisited() map[string]int64 {
+	if x != nil {
+		return x.Visited
+	}
+	return nil
+}
+
+// MazeClient is a client registered with a maze
+type MazeClient struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId             string         `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	FromCell             *MazeLocation  `protobuf:"bytes,2,opt,name=from_cell,json=fromCell,proto3" json:"from_cell,omitempty"`
+	ToCell               *MazeLocation  `protobuf:"bytes,3,opt,name=to_cell,json=toCell,proto3" json:"to_cell,omitempty"`
+	CurrentLocation      *MazeLocation  `protobuf:"bytes,4,opt,name=current_location,json=currentLocation,proto3" json:"current_location,omitempty"`
+	Config               *ClientConfig  `protobuf:"bytes,5,opt,name=config,proto3" json:"config,omitempty"`
+	TravelPath           []*PathSegment `protobuf:"bytes,6,rep,name=travel_path,json=travelPath,proto3" json:"travel_path,omitempty"`                                  // every move of the client, oldest first
+	CurrentLocationUnder bool           `protobuf:"varint,7,opt,name=current_location_under,json=currentLocationUnder,proto3" json:"current_location_under,omitempty"` // the client is in the tunnel under current_location
+	Owner                string         `protobuf:"bytes,8,opt,name=owner,proto3" json:"owner,omitempty"`                                                              // hash of the auth token that registered the client, only it can move the client (only set in the store)
+}
+
+func (x *MazeClient) Reset() {
+	*x = MazeClient{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MazeClient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MazeClient) ProtoMessage() {}
+
+func (x *MazeClient) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MazeClient.ProtoReflect.Descriptor instead.
+func (*MazeClient) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *MazeClient) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *MazeClient) GetFromCell() *MazeLocation {
+	if x != nil {
+		return x.FromCell
+	}
+	return nil
+}
+
+func (x *MazeClient) GetToCell() *MazeLocation {
+	if x != nil {
+		return x.ToCell
+	}
+	return nil
+}
+
+func (x *MazeClient) GetCurrentLocation() *MazeLocation {
+	if x != nil {
+		return x.CurrentLocation
+	}
+	return nil
+}
+
+func (x *MazeClient) GetConfig() *ClientConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *MazeClient) GetTravelPath() []*PathSegment {
+	if x != nil {
+		return x.TravelPath
+	}
+	return nil
+}
+
+func (x *MazeClient) GetCurrentLocationUnder() bool {
+	if x != nil {
+		return x.CurrentLocationUnder
+	}
+	return false
+}
+
+func (x *MazeClient) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+// PathSegment is one step of a client's path through the maze
+type PathSegment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location *MazeLocation `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Under    bool          `protobuf:"varint,2,opt,name=under,proto3" json:"under,omitempty"`       // the step is in the tunnel under location
+	Facing   string        `protobuf:"bytes,3,opt,name=facing,proto3" json:"facing,omitempty"`      // direction the client moved in to get here
+	Solution bool          `protobuf:"varint,4,opt,name=solution,proto3" json:"solution,omitempty"` // the step is part of the path to the solution
+}
+
+func (x *PathSegment) Reset() {
+	*x = PathSegment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PathSegment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathSegment) ProtoMessage() {}
+
+func (x *PathSegment) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathSegment.ProtoReflect.Descriptor instead.
+func (*PathSegment) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PathSegment) GetLocation() *MazeLocation {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *PathSegment) GetUnder() bool {
+	if x != nil {
+		return x.Under
+	}
+	return false
+}
+
+func (x *PathSegment) GetFacing() string {
+	if x != nil {
+		return x.Facing
+	}
+	return ""
+}
+
+func (x *PathSegment) GetSolution() bool {
+	if x != nil {
+		return x.Solution
+	}
+	return false
+}
+
+type ListMazeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListMazeRequest) Reset() {
+	*x = ListMazeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListMazeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMazeRequest) ProtoMessage() {}
+
+func (x *ListMazeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMazeRequest.ProtoReflect.Descriptor instead.
+func (*ListMazeRequest) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{27}
+}
+
+type ListMazeReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mazes []*Maze `protobuf:"bytes,1,rep,name=mazes,proto3" json:"mazes,omitempty"`
+}
+
+func (x *ListMazeReply) Reset() {
+	*x = ListMazeReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListMazeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMazeReply) ProtoMessage() {}
+
+func (x *ListMazeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMazeReply.ProtoReflect.Descriptor instead.
+func (*ListMazeReply) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListMazeReply) GetMazes() []*Maze {
+	if x != nil {
+		return x.Mazes
+	}
+	return nil
+}
+
+type CreateMazeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Config     *MazeConfig `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	ReturnMaze bool        `protobuf:"varint,2,opt,name=return_maze,json=returnMaze,proto3" json:"return_maze,omitempty"` // if set to true, the maze itself is return, ascii encoded
+}
+
+func (x *CreateMazeRequest) Reset() {
+	*x = CreateMazeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateMazeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMazeRequest) ProtoMessage() {}
+
+func (x *CreateMazeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMazeRequest.ProtoReflect.Descriptor instead.
+func (*CreateMazeRequest) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateMazeRequest) GetConfig() *MazeConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *CreateMazeRequest) GetReturnMaze() bool {
+	if x != nil {
+		return x.ReturnMaze
+	}
+	return false
+}
+
+type CreateMazeReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MazeId      string `protobuf:"bytes,1,opt,name=MazeId,proto3" json:"MazeId,omitempty"`
+	EncodedMaze string `protobuf:"bytes,2,opt,name=encoded_maze,json=encodedMaze,proto3" json:"encoded_maze,omitempty"`
+}
+
+func (x *CreateMazeReply) Reset() {
+	*x = CreateMazeReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateMazeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMazeReply) ProtoMessage() {}
+
+func (x *CreateMazeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMazeReply.ProtoReflect.Descriptor instead.
+func (*CreateMazeReply) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateMazeReply) GetMazeId() string {
+	if x != nil {
+		return x.MazeId
+	}
+	return ""
+}
+
+func (x *CreateMazeReply) GetEncodedMaze() string {
+	if x != nil {
+		return x.EncodedMaze
+	}
 	return ""
 }
 
 // MazeConfig is the full config for a maze
 type MazeConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
 	Rows                 int64           `protobuf:"varint,1,opt,name=Rows,proto3" json:"Rows,omitempty"`
 	Columns              int64           `protobuf:"varint,2,opt,name=Columns,proto3" json:"Columns,omitempty"`
 	AllowWeaving         bool            `protobuf:"varint,3,opt,name=AllowWeaving,proto3" json:"AllowWeaving,omitempty"`
@@ -847,536 +2226,1762 @@ type MazeConfig struct {
 	BorderColor          string          `protobuf:"bytes,17,opt,name=BorderColor,proto3" json:"BorderColor,omitempty"`
 	WallColor            string          `protobuf:"bytes,18,opt,name=WallColor,proto3" json:"WallColor,omitempty"`
 	CurrentLocationColor string          `protobuf:"bytes,20,opt,name=CurrentLocationColor,proto3" json:"CurrentLocationColor,omitempty"`
-	GenDrawDelay         string          `protobuf:"bytes,25,opt,name=GenDrawDelay,proto3" json:"GenDrawDelay,omitempty"`
+	GenDrawDelay         string          `protobuf:"bytes,25,opt,name=GenDrawDelay,proto3" json:"GenDrawDelay,omitempty"` // time string, e.g. 500ms
 	CreateAlgo           string          `protobuf:"bytes,26,opt,name=CreateAlgo,proto3" json:"CreateAlgo,omitempty"`
 	BraidProbability     float64         `protobuf:"fixed64,27,opt,name=BraidProbability,proto3" json:"BraidProbability,omitempty"`
 	Id                   string          `protobuf:"bytes,28,opt,name=Id,proto3" json:"Id,omitempty"`
 	Gui                  bool            `protobuf:"varint,29,opt,name=Gui,proto3" json:"Gui,omitempty"`
 	FromFile             string          `protobuf:"bytes,30,opt,name=FromFile,proto3" json:"FromFile,omitempty"`
-	ReturnMaze           bool            `protobuf:"varint,31,opt,name=return_maze,json=returnMaze,proto3" json:"return_maze,omitempty"`
+	ReturnMaze           bool            `protobuf:"varint,31,opt,name=return_maze,json=returnMaze,proto3" json:"return_maze,omitempty"` // return encoded maze back to the client
 	Title                string          `protobuf:"bytes,32,opt,name=title,proto3" json:"title,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	GridType             string          `protobuf:"bytes,34,opt,name=GridType,proto3" json:"GridType,omitempty"`   // "square" (default), "hex", "delta" or "polar" (Rows are rings, Columns is ignored)
+	Levels               int64           `protobuf:"varint,35,opt,name=Levels,proto3" json:"Levels,omitempty"`      // number of stacked levels, connected by up/down passages (square grid only)
+	LevelView            string          `protobuf:"bytes,36,opt,name=LevelView,proto3" json:"LevelView,omitempty"` // how to draw multiple levels: "side-by-side" (default) or "follow" (one level at a time)
+	Wrap                 string          `protobuf:"bytes,37,opt,name=Wrap,proto3" json:"Wrap,omitempty"`           // link edge cells to the opposite edge: "horizontal" (cylinder), "vertical" or "both" (torus) (square grid only)
+	Record               *RecordConfig   `protobuf:"bytes,38,opt,name=Record,proto3" json:"Record,omitempty"`       // record the generator as an animation
+	// growing-tree only, how the next cell is picked: "newest" (default), "oldest", "random", "middle"
+	// or a weighted mix such as "newest:75,random:25"
+	GrowingTree string `protobuf:"bytes,39,opt,name=GrowingTree,proto3" json:"GrowingTree,omitempty"`
+	// seeds the random numbers of the maze (generator, weights, braiding, random cells), the same seed and
+	// config always give the same maze; 0 picks a seed, which is set here
+	Seed int64 `protobuf:"varint,40,opt,name=Seed,proto3" json:"Seed,omitempty"`
+	// origin-shift only, how many times the root is moved; 0 is 10 times the number of cells
+	OriginShiftSteps int64 `protobuf:"varint,41,opt,name=OriginShiftSteps,proto3" json:"OriginShiftSteps,omitempty"`
+	// origin shifts per second on the server while the maze is live (see genalgos/origin_shift), the maze
+	// changes under its solvers but stays a perfect maze; 0 never changes it, the maze must be perfect
+	OriginShiftRate float64 `protobuf:"fixed64,42,opt,name=OriginShiftRate,proto3" json:"OriginShiftRate,omitempty"`
+	// changes the passages of the maze on the server while it is live, the maze stays connected
+	Dynamics *DynamicsConfig `protobuf:"bytes,43,opt,name=Dynamics,proto3" json:"Dynamics,omitempty"` // next num: 44
+}
+
+func (x *MazeConfig) Reset() {
+	*x = MazeConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MazeConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MazeConfig) ProtoMessage() {}
+
+func (x *MazeConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *MazeConfig) Reset()         { *m = MazeConfig{} }
-func (m *MazeConfig) String() string { return proto.CompactTextString(m) }
-func (*MazeConfig) ProtoMessage()    {}
+// Deprecated: Use MazeConfig.ProtoReflect.Descriptor instead.
 func (*MazeConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{15}
+	return file_mazes_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *MazeConfig) GetRows() int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *MazeConfig) GetColumns() int64 {
+	if x != nil {
+		return x.Columns
+	}
+	return 0
+}
+
+func (x *MazeConfig) GetAllowWeaving() bool {
+	if x != nil {
+		return x.AllowWeaving
+	}
+	return false
+}
+
+func (x *MazeConfig) GetWeavingProbability() float64 {
+	if x != nil {
+		return x.WeavingProbability
+	}
+	return 0
+}
+
+func (x *MazeConfig) GetCellWidth() int64 {
+	if x != nil {
+		return x.CellWidth
+	}
+	return 0
+}
+
+func (x *MazeConfig) GetWallWidth() int64 {
+	if x != nil {
+		return x.WallWidth
+	}
+	return 0
+}
+
+func (x *MazeConfig) GetWallSpace() int64 {
+	if x != nil {
+		return x.WallSpace
+	}
+	return 0
+}
+
+func (x *MazeConfig) GetPathWidth() int64 {
+	if x != nil {
+		return x.PathWidth
+	}
+	return 0
+}
+
+func (x *MazeConfig) GetShowDistanceValues() bool {
+	if x != nil {
+		return x.ShowDistanceValues
+	}
+	return false
+}
+
+func (x *MazeConfig) GetShowDistanceColors() bool {
+	if x != nil {
+		return x.ShowDistanceColors
+	}
+	return false
+}
+
+func (x *MazeConfig) GetShowWeightValues() bool {
+	if x != nil {
+		return x.ShowWeightValues
+	}
+	return false
+}
+
+func (x *MazeConfig) GetSkipGridCheck() bool {
+	if x != nil {
+		return x.SkipGridCheck
+	}
+	return false
+}
+
+func (x *MazeConfig) GetOrphanMask() []*MazeLocation {
+	if x != nil {
+		return x.OrphanMask
+	}
+	return nil
+}
+
+func (x *MazeConfig) GetBgColor() string {
+	if x != nil {
+		return x.BgColor
+	}
+	return ""
+}
+
+func (x *MazeConfig) GetBorderColor() string {
+	if x != nil {
+		return x.BorderColor
+	}
+	return ""
+}
+
+func (x *MazeConfig) GetWallColor() string {
+	if x != nil {
+		return x.WallColor
+	}
+	return ""
+}
+
+func (x *MazeConfig) GetCurrentLocationColor() string {
+	if x != nil {
+		return x.CurrentLocationColor
+	}
+	return ""
+}
+
+func (x *MazeConfig) GetGenDrawDelay() string {
+	if x != nil {
+		return x.GenDrawDelay
+	}
+	return ""
+}
+
+func (x *MazeConfig) GetCreateAlgo() string {
+	if x != nil {
+		return x.CreateAlgo
+	}
+	return ""
+}
+
+func (x *MazeConfig) GetBraidProbability() float64 {
+	if x != nil {
+		return x.BraidProbability
+	}
+	return 0
+}
+
+func (x *MazeConfig) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MazeConfig) GetGui() bool {
+	if x != nil {
+		return x.Gui
+	}
+	return false
+}
+
+func (x *MazeConfig) GetFromFile() string {
+	if x != nil {
+		return x.FromFile
+	}
+	return ""
+}
+
+func (x *MazeConfig) GetReturnMaze() bool {
+	if x != nil {
+		return x.ReturnMaze
+	}
+	return false
+}
+
+func (x *MazeConfig) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *MazeConfig) GetGridType() string {
+	if x != nil {
+		return x.GridType
+	}
+	return ""
 }
 
-func (m *MazeConfig) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MazeConfig.Unmarshal(m, b)
+func (x *MazeConfig) GetLevels() int64 {
+	if x != nil {
+		return x.Levels
+	}
+	return 0
 }
-func (m *MazeConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MazeConfig.Marshal(b, m, deterministic)
+
+func (x *MazeConfig) GetLevelView() string {
+	if x != nil {
+		return x.LevelView
+	}
+	return ""
 }
-func (m *MazeConfig) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MazeConfig.Merge(m, src)
+
+func (x *MazeConfig) GetWrap() string {
+	if x != nil {
+		return x.Wrap
+	}
+	return ""
 }
-func (m *MazeConfig) XXX_Size() int {
-	return xxx_messageInfo_MazeConfig.Size(m)
+
+func (x *MazeConfig) GetRecord() *RecordConfig {
+	if x != nil {
+		return x.Record
+	}
+	return nil
 }
-func (m *MazeConfig) XXX_DiscardUnknown() {
-	xxx_messageInfo_MazeConfig.DiscardUnknown(m)
+
+func (x *MazeConfig) GetGrowingTree() string {
+	if x != nil {
+		return x.GrowingTree
+	}
+	return ""
 }
 
-var xxx_messageInfo_MazeConfig proto.InternalMessageInfo
-
-func (m *MazeConfig) GetRows() int64 {
-	if m != nil {
-		return m.Rows
+func (x *MazeConfig) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
 	}
 	return 0
 }
 
-func (m *MazeConfig) GetColumns() int64 {
-	if m != nil {
-		return m.Columns
+func (x *MazeConfig) GetOriginShiftSteps() int64 {
+	if x != nil {
+		return x.OriginShiftSteps
 	}
 	return 0
 }
 
-func (m *MazeConfig) GetAllowWeaving() bool {
-	if m != nil {
-		return m.AllowWeaving
+func (x *MazeConfig) GetOriginShiftRate() float64 {
+	if x != nil {
+		return x.OriginShiftRate
 	}
-	return false
+	return 0
 }
 
-func (m *MazeConfig) GetWeavingProbability() float64 {
-	if m != nil {
-		return m.WeavingProbability
+func (x *MazeConfig) GetDynamics() *DynamicsConfig {
+	if x != nil {
+		return x.Dynamics
 	}
-	return 0
+	return nil
 }
 
-func (m *MazeConfig) GetCellWidth() int64 {
-	if m != nil {
-		return m.CellWidth
+// ClientConfig has all the per-client config settings in it
+type ClientConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SolveAlgo              string        `protobuf:"bytes,1,opt,name=SolveAlgo,proto3" json:"SolveAlgo,omitempty"`
+	DisableDrawOffset      bool          `protobuf:"varint,2,opt,name=DisableDrawOffset,proto3" json:"DisableDrawOffset,omitempty"`            // used to disable the path draw offset
+	DrawPathLength         int64         `protobuf:"varint,3,opt,name=DrawPathLength,proto3" json:"DrawPathLength,omitempty"`                  // length of path to draw, set to 0 for no path, set to -1 for all of it
+	MarkVisitedCells       bool          `protobuf:"varint,9,opt,name=MarkVisitedCells,proto3" json:"MarkVisitedCells,omitempty"`              // marks visited cells with squares of varying sizes
+	NumberMarkVisitedCells bool          `protobuf:"varint,10,opt,name=NumberMarkVisitedCells,proto3" json:"NumberMarkVisitedCells,omitempty"` // marks visited cells with numbers
+	AvatarImage            string        `protobuf:"bytes,14,opt,name=AvatarImage,proto3" json:"AvatarImage,omitempty"`
+	VisitedCellColor       string        `protobuf:"bytes,15,opt,name=VisitedCellColor,proto3" json:"VisitedCellColor,omitempty"`
+	CurrentLocationColor   string        `protobuf:"bytes,16,opt,name=CurrentLocationColor,proto3" json:"CurrentLocationColor,omitempty"`
+	PathColor              string        `protobuf:"bytes,19,opt,name=PathColor,proto3" json:"PathColor,omitempty"`
+	FromCellColor          string        `protobuf:"bytes,21,opt,name=FromCellColor,proto3" json:"FromCellColor,omitempty"`
+	ToCellColor            string        `protobuf:"bytes,22,opt,name=ToCellColor,proto3" json:"ToCellColor,omitempty"`
+	FromCell               string        `protobuf:"bytes,23,opt,name=FromCell,proto3" json:"FromCell,omitempty"` // "min", "max", "random" or "x,y"
+	ToCell                 string        `protobuf:"bytes,24,opt,name=ToCell,proto3" json:"ToCell,omitempty"`     // "min", "max", "random" or "x,y"
+	ShowFromToColors       bool          `protobuf:"varint,27,opt,name=ShowFromToColors,proto3" json:"ShowFromToColors,omitempty"`
+	Record                 *RecordConfig `protobuf:"bytes,28,opt,name=Record,proto3" json:"Record,omitempty"` // record the client solving the maze as an animation
+}
+
+func (x *ClientConfig) Reset() {
+	*x = ClientConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (m *MazeConfig) GetWallWidth() int64 {
-	if m != nil {
-		return m.WallWidth
-	}
-	return 0
+func (x *ClientConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *MazeConfig) GetWallSpace() int64 {
-	if m != nil {
-		return m.WallSpace
+func (*ClientConfig) ProtoMessage() {}
+
+func (x *ClientConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (m *MazeConfig) GetPathWidth() int64 {
-	if m != nil {
-		return m.PathWidth
-	}
-	return 0
+// Deprecated: Use ClientConfig.ProtoReflect.Descriptor instead.
+func (*ClientConfig) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{32}
 }
 
-func (m *MazeConfig) GetShowDistanceValues() bool {
-	if m != nil {
-		return m.ShowDistanceValues
+func (x *ClientConfig) GetSolveAlgo() string {
+	if x != nil {
+		return x.SolveAlgo
 	}
-	return false
+	return ""
 }
 
-func (m *MazeConfig) GetShowDistanceColors() bool {
-	if m != nil {
-		return m.ShowDistanceColors
+func (x *ClientConfig) GetDisableDrawOffset() bool {
+	if x != nil {
+		return x.DisableDrawOffset
 	}
 	return false
 }
 
-func (m *MazeConfig) GetShowWeightValues() bool {
-	if m != nil {
-		return m.ShowWeightValues
+func (x *ClientConfig) GetDrawPathLength() int64 {
+	if x != nil {
+		return x.DrawPathLength
 	}
-	return false
+	return 0
 }
 
-func (m *MazeConfig) GetSkipGridCheck() bool {
-	if m != nil {
-		return m.SkipGridCheck
+func (x *ClientConfig) GetMarkVisitedCells() bool {
+	if x != nil {
+		return x.MarkVisitedCells
 	}
 	return false
 }
 
-func (m *MazeConfig) GetOrphanMask() []*MazeLocation {
-	if m != nil {
-		return m.OrphanMask
+func (x *ClientConfig) GetNumberMarkVisitedCells() bool {
+	if x != nil {
+		return x.NumberMarkVisitedCells
 	}
-	return nil
+	return false
 }
 
-func (m *MazeConfig) GetBgColor() string {
-	if m != nil {
-		return m.BgColor
+func (x *ClientConfig) GetAvatarImage() string {
+	if x != nil {
+		return x.AvatarImage
 	}
 	return ""
 }
 
-func (m *MazeConfig) GetBorderColor() string {
-	if m != nil {
-		return m.BorderColor
+func (x *ClientConfig) GetVisitedCellColor() string {
+	if x != nil {
+		return x.VisitedCellColor
 	}
 	return ""
 }
 
-func (m *MazeConfig) GetWallColor() string {
-	if m != nil {
-		return m.WallColor
+func (x *ClientConfig) GetCurrentLocationColor() string {
+	if x != nil {
+		return x.CurrentLocationColor
 	}
 	return ""
 }
 
-func (m *MazeConfig) GetCurrentLocationColor() string {
-	if m != nil {
-		return m.CurrentLocationColor
+func (x *ClientConfig) GetPathColor() string {
+	if x != nil {
+		return x.PathColor
 	}
 	return ""
 }
 
-func (m *MazeConfig) GetGenDrawDelay() string {
-	if m != nil {
-		return m.GenDrawDelay
+func (x *ClientConfig) GetFromCellColor() string {
+	if x != nil {
+		return x.FromCellColor
 	}
 	return ""
 }
 
-func (m *MazeConfig) GetCreateAlgo() string {
-	if m != nil {
-		return m.CreateAlgo
+func (x *ClientConfig) GetToCellColor() string {
+	if x != nil {
+		return x.ToCellColor
 	}
 	return ""
 }
 
-func (m *MazeConfig) GetBraidProbability() float64 {
-	if m != nil {
-		return m.BraidProbability
+func (x *ClientConfig) GetFromCell() string {
+	if x != nil {
+		return x.FromCell
 	}
-	return 0
+	return ""
 }
 
-func (m *MazeConfig) GetId() string {
-	if m != nil {
-		return m.Id
+func (x *ClientConfig) GetToCell() string {
+	if x != nil {
+		return x.ToCell
 	}
 	return ""
 }
 
-func (m *MazeConfig) GetGui() bool {
-	if m != nil {
-		return m.Gui
+func (x *ClientConfig) GetShowFromToColors() bool {
+	if x != nil {
+		return x.ShowFromToColors
 	}
 	return false
 }
 
-func (m *MazeConfig) GetFromFile() string {
-	if m != nil {
-		return m.FromFile
+func (x *ClientConfig) GetRecord() *RecordConfig {
+	if x != nil {
+		return x.Record
 	}
-	return ""
+	return nil
 }
 
-func (m *MazeConfig) GetReturnMaze() bool {
-	if m != nil {
-		return m.ReturnMaze
-	}
-	return false
+// RecordConfig controls recording an animation of the maze, frames are drawn without a window
+type RecordConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	File       string `protobuf:"bytes,1,opt,name=File,proto3" json:"File,omitempty"`             // file (on the server) to write the animation to, recording is off if empty
+	Format     string `protobuf:"bytes,2,opt,name=Format,proto3" json:"Format,omitempty"`         // "gif" or "apng", based on the file extension if empty (.png and .apng are apng)
+	FrameSkip  int64  `protobuf:"varint,3,opt,name=FrameSkip,proto3" json:"FrameSkip,omitempty"`  // number of steps skipped between recorded frames
+	MaxFrames  int64  `protobuf:"varint,4,opt,name=MaxFrames,proto3" json:"MaxFrames,omitempty"`  // stop recording after this many frames, 0 for the default (500)
+	FrameDelay string `protobuf:"bytes,5,opt,name=FrameDelay,proto3" json:"FrameDelay,omitempty"` // how long each frame is shown, "50ms" if empty
 }
 
-func (m *MazeConfig) GetTitle() string {
-	if m != nil {
-		return m.Title
+func (x *RecordConfig) Reset() {
+	*x = RecordConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-// ClientConfig has all the per-client config settings in it
-type ClientConfig struct {
-	SolveAlgo              string   `protobuf:"bytes,1,opt,name=SolveAlgo,proto3" json:"SolveAlgo,omitempty"`
-	DisableDrawOffset      bool     `protobuf:"varint,2,opt,name=DisableDrawOffset,proto3" json:"DisableDrawOffset,omitempty"`
-	DrawPathLength         int64    `protobuf:"varint,3,opt,name=DrawPathLength,proto3" json:"DrawPathLength,omitempty"`
-	MarkVisitedCells       bool     `protobuf:"varint,9,opt,name=MarkVisitedCells,proto3" json:"MarkVisitedCells,omitempty"`
-	NumberMarkVisitedCells bool     `protobuf:"varint,10,opt,name=NumberMarkVisitedCells,proto3" json:"NumberMarkVisitedCells,omitempty"`
-	AvatarImage            string   `protobuf:"bytes,14,opt,name=AvatarImage,proto3" json:"AvatarImage,omitempty"`
-	VisitedCellColor       string   `protobuf:"bytes,15,opt,name=VisitedCellColor,proto3" json:"VisitedCellColor,omitempty"`
-	CurrentLocationColor   string   `protobuf:"bytes,16,opt,name=CurrentLocationColor,proto3" json:"CurrentLocationColor,omitempty"`
-	PathColor              string   `protobuf:"bytes,19,opt,name=PathColor,proto3" json:"PathColor,omitempty"`
-	FromCellColor          string   `protobuf:"bytes,21,opt,name=FromCellColor,proto3" json:"FromCellColor,omitempty"`
-	ToCellColor            string   `protobuf:"bytes,22,opt,name=ToCellColor,proto3" json:"ToCellColor,omitempty"`
-	FromCell               string   `protobuf:"bytes,23,opt,name=FromCell,proto3" json:"FromCell,omitempty"`
-	ToCell                 string   `protobuf:"bytes,24,opt,name=ToCell,proto3" json:"ToCell,omitempty"`
-	ShowFromToColors       bool     `protobuf:"varint,27,opt,name=ShowFromToColors,proto3" json:"ShowFromToColors,omitempty"`
-	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
-	XXX_unrecognized       []byte   `json:"-"`
-	XXX_sizecache          int32    `json:"-"`
-}
-
-func (m *ClientConfig) Reset()         { *m = ClientConfig{} }
-func (m *ClientConfig) String() string { return proto.CompactTextString(m) }
-func (*ClientConfig) ProtoMessage()    {}
-func (*ClientConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{16}
+func (x *RecordConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *ClientConfig) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ClientConfig.Unmarshal(m, b)
-}
-func (m *ClientConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ClientConfig.Marshal(b, m, deterministic)
-}
-func (m *ClientConfig) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ClientConfig.Merge(m, src)
-}
-func (m *ClientConfig) XXX_Size() int {
-	return xxx_messageInfo_ClientConfig.Size(m)
-}
-func (m *ClientConfig) XXX_DiscardUnknown() {
-	xxx_messageInfo_ClientConfig.DiscardUnknown(m)
+func (*RecordConfig) ProtoMessage() {}
+
+func (x *RecordConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_ClientConfig proto.InternalMessageInfo
+// Deprecated: Use RecordConfig.ProtoReflect.Descriptor instead.
+func (*RecordConfig) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{33}
+}
 
-func (m *ClientConfig) GetSolveAlgo() string {
-	if m != nil {
-		return m.SolveAlgo
+func (x *RecordConfig) GetFile() string {
+	if x != nil {
+		return x.File
 	}
 	return ""
 }
 
-func (m *ClientConfig) GetDisableDrawOffset() bool {
-	if m != nil {
-		return m.DisableDrawOffset
+func (x *RecordConfig) GetFormat() string {
+	if x != nil {
+		return x.Format
 	}
-	return false
+	return ""
 }
 
-func (m *ClientConfig) GetDrawPathLength() int64 {
-	if m != nil {
-		return m.DrawPathLength
+func (x *RecordConfig) GetFrameSkip() int64 {
+	if x != nil {
+		return x.FrameSkip
 	}
 	return 0
 }
 
-func (m *ClientConfig) GetMarkVisitedCells() bool {
-	if m != nil {
-		return m.MarkVisitedCells
+func (x *RecordConfig) GetMaxFrames() int64 {
+	if x != nil {
+		return x.MaxFrames
 	}
-	return false
+	return 0
 }
 
-func (m *ClientConfig) GetNumberMarkVisitedCells() bool {
-	if m != nil {
-		return m.NumberMarkVisitedCells
+func (x *RecordConfig) GetFrameDelay() string {
+	if x != nil {
+		return x.FrameDelay
 	}
-	return false
+	return ""
 }
 
-func (m *ClientConfig) GetAvatarImage() string {
-	if m != nil {
-		return m.AvatarImage
-	}
-	return ""
+// DynamicsConfig changes the passages of a live maze (see dynamics.Dynamics), the maze never changes if Policy is empty
+type DynamicsConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Policy          string  `protobuf:"bytes,1,opt,name=Policy,proto3" json:"Policy,omitempty"`                   // "toggle" (random passages open and close), "doors" (rotating doors) or "closures" (passages close for a while)
+	Rate            float64 `protobuf:"fixed64,2,opt,name=Rate,proto3" json:"Rate,omitempty"`                     // changes per second
+	Doors           int64   `protobuf:"varint,3,opt,name=Doors,proto3" json:"Doors,omitempty"`                    // doors: number of doors, 0 for one per 50 cells
+	ClosureDuration string  `protobuf:"bytes,4,opt,name=ClosureDuration,proto3" json:"ClosureDuration,omitempty"` // closures: how long a passage stays closed, "10s" if empty
 }
 
-func (m *ClientConfig) GetVisitedCellColor() string {
-	if m != nil {
-		return m.VisitedCellColor
+func (x *DynamicsConfig) Reset() {
+	*x = DynamicsConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (m *ClientConfig) GetCurrentLocationColor() string {
-	if m != nil {
-		return m.CurrentLocationColor
-	}
-	return ""
+func (x *DynamicsConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *ClientConfig) GetPathColor() string {
-	if m != nil {
-		return m.PathColor
+func (*DynamicsConfig) ProtoMessage() {}
+
+func (x *DynamicsConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (m *ClientConfig) GetFromCellColor() string {
-	if m != nil {
-		return m.FromCellColor
-	}
-	return ""
+// Deprecated: Use DynamicsConfig.ProtoReflect.Descriptor instead.
+func (*DynamicsConfig) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{34}
 }
 
-func (m *ClientConfig) GetToCellColor() string {
-	if m != nil {
-		return m.ToCellColor
+func (x *DynamicsConfig) GetPolicy() string {
+	if x != nil {
+		return x.Policy
 	}
 	return ""
 }
 
-func (m *ClientConfig) GetFromCell() string {
-	if m != nil {
-		return m.FromCell
+func (x *DynamicsConfig) GetRate() float64 {
+	if x != nil {
+		return x.Rate
 	}
-	return ""
+	return 0
 }
 
-func (m *ClientConfig) GetToCell() string {
-	if m != nil {
-		return m.ToCell
+func (x *DynamicsConfig) GetDoors() int64 {
+	if x != nil {
+		return x.Doors
 	}
-	return ""
+	return 0
 }
 
-func (m *ClientConfig) GetShowFromToColors() bool {
-	if m != nil {
-		return m.ShowFromToColors
+func (x *DynamicsConfig) GetClosureDuration() string {
+	if x != nil {
+		return x.ClosureDuration
 	}
-	return false
+	return ""
 }
 
 // MazeLocation is a location in the maze
 type MazeLocation struct {
-	X                    int64    `protobuf:"varint,1,opt,name=X,proto3" json:"X,omitempty"`
-	Y                    int64    `protobuf:"varint,2,opt,name=Y,proto3" json:"Y,omitempty"`
-	Z                    int64    `protobuf:"varint,3,opt,name=Z,proto3" json:"Z,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *MazeLocation) Reset()         { *m = MazeLocation{} }
-func (m *MazeLocation) String() string { return proto.CompactTextString(m) }
-func (*MazeLocation) ProtoMessage()    {}
-func (*MazeLocation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b6d29e3fe1edc626, []int{17}
+	X int64 `protobuf:"varint,1,opt,name=X,proto3" json:"X,omitempty"`
+	Y int64 `protobuf:"varint,2,opt,name=Y,proto3" json:"Y,omitempty"`
+	Z int64 `protobuf:"varint,3,opt,name=Z,proto3" json:"Z,omitempty"`
 }
 
-func (m *MazeLocation) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MazeLocation.Unmarshal(m, b)
-}
-func (m *MazeLocation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MazeLocation.Marshal(b, m, deterministic)
-}
-func (m *MazeLocation) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MazeLocation.Merge(m, src)
+func (x *MazeLocation) Reset() {
+	*x = MazeLocation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mazes_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *MazeLocation) XXX_Size() int {
-	return xxx_messageInfo_MazeLocation.Size(m)
+
+func (x *MazeLocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *MazeLocation) XXX_DiscardUnknown() {
-	xxx_messageInfo_MazeLocation.DiscardUnknown(m)
+
+func (*MazeLocation) ProtoMessage() {}
+
+func (x *MazeLocation) ProtoReflect() protoreflect.Message {
+	mi := &file_mazes_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_MazeLocation proto.InternalMessageInfo
+// Deprecated: Use MazeLocation.ProtoReflect.Descriptor instead.
+func (*MazeLocation) Descriptor() ([]byte, []int) {
+	return file_mazes_proto_rawDescGZIP(), []int{35}
+}
 
-func (m *MazeLocation) GetX() int64 {
-	if m != nil {
-		return m.X
+func (x *MazeLocation) GetX() int64 {
+	if x != nil {
+		return x.X
 	}
 	return 0
 }
 
-func (m *MazeLocation) GetY() int64 {
-	if m != nil {
-		return m.Y
+func (x *MazeLocation) GetY() int64 {
+	if x != nil {
+		return x.Y
 	}
 	return 0
 }
 
-func (m *MazeLocation) GetZ() int64 {
-	if m != nil {
-		return m.Z
+func (x *MazeLocation) GetZ() int64 {
+	if x != nil {
+		return x.Z
 	}
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*ResetClientRequest)(nil), "proto.ResetClientRequest")
-	proto.RegisterType((*ResetClientReply)(nil), "proto.ResetClientReply")
-	proto.RegisterType((*ExportMazeRequest)(nil), "proto.ExportMazeRequest")
-	proto.RegisterType((*ExportMazeReply)(nil), "proto.ExportMazeReply")
-	proto.RegisterType((*RegisterClientRequest)(nil), "proto.RegisterClientRequest")
-	proto.RegisterType((*RegisterClientReply)(nil), "proto.RegisterClientReply")
-	proto.RegisterType((*SolveMazeRequest)(nil), "proto.SolveMazeRequest")
-	proto.RegisterType((*SolveMazeResponse)(nil), "proto.SolveMazeResponse")
-	proto.RegisterType((*Direction)(nil), "proto.Direction")
-	proto.RegisterType((*Maze)(nil), "proto.Maze")
-	proto.RegisterType((*Cell)(nil), "proto.Cell")
-	proto.RegisterType((*ListMazeRequest)(nil), "proto.ListMazeRequest")
-	proto.RegisterType((*ListMazeReply)(nil), "proto.ListMazeReply")
-	proto.RegisterType((*CreateMazeRequest)(nil), "proto.CreateMazeRequest")
-	proto.RegisterType((*CreateMazeReply)(nil), "proto.CreateMazeReply")
-	proto.RegisterType((*MazeConfig)(nil), "proto.MazeConfig")
-	proto.RegisterType((*ClientConfig)(nil), "proto.ClientConfig")
-	proto.RegisterType((*MazeLocation)(nil), "proto.MazeLocation")
-}
-
-func init() { proto.RegisterFile("mazes.proto", fileDescriptor_b6d29e3fe1edc626) }
-
-var fileDescriptor_b6d29e3fe1edc626 = []byte{
-	// 1326 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0xdf, 0x6e, 0xdb, 0xb6,
-	0x17, 0xae, 0xe2, 0xc4, 0xb1, 0x8f, 0x9d, 0xc4, 0x66, 0xd2, 0x44, 0x75, 0xfb, 0x6b, 0x53, 0xfd,
-	0x86, 0x21, 0x1b, 0x8a, 0xae, 0x48, 0x81, 0xad, 0xc3, 0x80, 0xa1, 0x8d, 0xd3, 0x16, 0x19, 0x92,
-	0xb5, 0x50, 0x8a, 0xa6, 0xed, 0x8d, 0xc1, 0x48, 0x8c, 0xcd, 0x45, 0x16, 0x3d, 0x52, 0x4e, 0x96,
-	0x3e, 0xc0, 0x9e, 0x62, 0x37, 0x7b, 0x81, 0xdd, 0xec, 0x1d, 0xf6, 0x1c, 0x7b, 0x94, 0xe1, 0x90,
-	0x94, 0x2c, 0xc9, 0x4e, 0xfa, 0xe7, 0xca, 0x3a, 0xdf, 0xf7, 0x91, 0x3e, 0x3c, 0xff, 0x28, 0x41,
-	0x63, 0x48, 0xdf, 0x33, 0x75, 0x7f, 0x24, 0x45, 0x22, 0xc8, 0x82, 0xfe, 0xf1, 0x7e, 0x02, 0xe2,
-	0x33, 0xc5, 0x92, 0x6e, 0xc4, 0x59, 0x9c, 0xf8, 0xec, 0xd7, 0x31, 0x53, 0x09, 0xd9, 0x80, 0x45,
-	0xd4, 0xf6, 0x78, 0xe8, 0x3a, 0x9b, 0xce, 0x56, 0xdd, 0xaf, 0xa2, 0xb9, 0x17, 0x92, 0x9b, 0x50,
-	0x0f, 0xb4, 0x12, 0xa9, 0x39, 0x4d, 0xd5, 0x0c, 0xb0, 0x17, 0x7a, 0xbf, 0x3b, 0xd0, 0x2a, 0x6c,
-	0x36, 0x8a, 0x2e, 0x88, 0x0b, 0x8b, 0x6a, 0x1c, 0x04, 0x4c, 0x29, 0xbd, 0x55, 0xcd, 0x4f, 0x4d,
-	0x64, 0x86, 0x4c, 0x29, 0xda, 0x67, 0x76, 0xa7, 0xd4, 0x24, 0x3f, 0x42, 0x2b, 0x18, 0x4b, 0x89,
-	0x7f, 0x13, 0x89, 0x80, 0x26, 0x5c, 0xc4, 0x6e, 0x65, 0xd3, 0xd9, 0x6a, 0x6c, 0xaf, 0x1a, 0xef,
-	0xef, 0x1f, 0xd0, 0xf7, 0x6c, 0xdf, 0x52, 0xfe, 0x8a, 0x15, 0xa7, 0x80, 0x77, 0x0f, 0xda, 0x4f,
-	0x7f, 0x1b, 0x09, 0x99, 0xa0, 0xec, 0x43, 0x67, 0xf2, 0x9e, 0xc2, 0x4a, 0x5e, 0xfd, 0x99, 0x4e,
-	0x7b, 0xbf, 0xc0, 0x75, 0x9f, 0xf5, 0xb9, 0x4a, 0x98, 0xfc, 0xc8, 0x60, 0x3e, 0x82, 0x25, 0x1b,
-	0xcc, 0x40, 0xc4, 0x27, 0xbc, 0xaf, 0x77, 0x9c, 0x9c, 0xd1, 0xec, 0xd2, 0xd5, 0x94, 0xdf, 0x0c,
-	0x72, 0x96, 0xf7, 0x8f, 0x03, 0xab, 0xe5, 0x3f, 0xfb, 0xdc, 0x60, 0x17, 0x52, 0x5a, 0x29, 0xa6,
-	0x94, 0x3c, 0x80, 0xfa, 0x89, 0x14, 0xc3, 0x5e, 0xc0, 0xa2, 0xc8, 0x9d, 0xbf, 0x3c, 0x05, 0x35,
-	0x54, 0x75, 0x59, 0x14, 0x91, 0x7b, 0xb0, 0x98, 0x08, 0xa3, 0x5f, 0xb8, 0x5c, 0x5f, 0x4d, 0x04,
-	0xaa, 0xbd, 0x3f, 0x1c, 0x68, 0x1d, 0x8a, 0xe8, 0x8c, 0xe5, 0x33, 0xb5, 0x0e, 0x36, 0x42, 0x9f,
-	0x50, 0x7c, 0xe4, 0x16, 0xd4, 0x43, 0x2e, 0x59, 0x90, 0x15, 0x4b, 0xdd, 0x9f, 0x00, 0x78, 0x7c,
-	0x1e, 0xf3, 0x84, 0x53, 0x73, 0x8a, 0x9a, 0x9f, 0x9a, 0xb8, 0xe9, 0x50, 0x9c, 0xb1, 0xde, 0x31,
-	0x0d, 0x4e, 0xb5, 0xc7, 0x35, 0xbf, 0x86, 0xc0, 0x0e, 0x0d, 0x4e, 0xbd, 0xbf, 0x2b, 0xd0, 0xce,
-	0xb9, 0xa7, 0x46, 0x22, 0x56, 0xec, 0xf3, 0xba, 0x83, 0x74, 0x61, 0x8d, 0x9e, 0x51, 0x1e, 0xd1,
-	0xe3, 0x88, 0xf5, 0x32, 0xcf, 0x94, 0x5b, 0xd9, 0xac, 0x6c, 0x35, 0xb6, 0x5b, 0x36, 0x4a, 0xbb,
-	0x29, 0xe1, 0xaf, 0x66, 0xea, 0x0c, 0x53, 0x57, 0x9c, 0x63, 0x0d, 0x16, 0x98, 0x94, 0x42, 0xda,
-	0x33, 0x18, 0x83, 0xfc, 0x1f, 0x96, 0xf4, 0x43, 0x2f, 0x4d, 0x7e, 0x55, 0x7b, 0xd5, 0xd4, 0xe0,
-	0xc1, 0x15, 0xed, 0xb6, 0xf8, 0xf1, 0xed, 0x56, 0x2c, 0x92, 0xda, 0x27, 0x16, 0x49, 0xfd, 0x83,
-	0x45, 0x82, 0xf5, 0xa0, 0x30, 0x09, 0xa1, 0x0b, 0xfa, 0x6c, 0xd6, 0x42, 0x5c, 0xb2, 0x73, 0x2a,
-	0x43, 0xb7, 0xb1, 0xe9, 0x6c, 0x39, 0xbe, 0xb5, 0xbc, 0xef, 0xa1, 0x9e, 0x85, 0x8c, 0x10, 0x98,
-	0x8f, 0xe9, 0x90, 0xd9, 0x4c, 0xe9, 0x67, 0x8c, 0xe2, 0x19, 0x57, 0x3c, 0x61, 0x26, 0x4b, 0x35,
-	0x3f, 0x35, 0xbd, 0x1e, 0xcc, 0xa3, 0x0b, 0x97, 0x96, 0xe0, 0x5d, 0x58, 0x40, 0xaf, 0x95, 0x3b,
-	0xa7, 0xb3, 0xd6, 0x48, 0x5b, 0x95, 0x45, 0x91, 0x6f, 0x18, 0x2c, 0xc4, 0x34, 0xe7, 0x26, 0xb9,
-	0x75, 0x7f, 0x02, 0x78, 0xdf, 0xc1, 0xbc, 0x3e, 0xd3, 0x37, 0x50, 0xcb, 0x62, 0xed, 0x5c, 0x11,
-	0xb2, 0x54, 0xe4, 0xb5, 0x61, 0x65, 0x9f, 0xab, 0xfc, 0x44, 0xf3, 0xb6, 0x61, 0x69, 0x02, 0x61,
-	0xfb, 0xdf, 0x85, 0x05, 0x3d, 0xe2, 0x5d, 0xa7, 0xe0, 0x9d, 0x16, 0x18, 0xc6, 0xeb, 0x41, 0xbb,
-	0x2b, 0x19, 0x4d, 0x0a, 0x0d, 0xf7, 0x15, 0x54, 0xed, 0x04, 0x32, 0xae, 0xb4, 0x73, 0x0b, 0xed,
-	0xfc, 0xb1, 0x02, 0x72, 0x07, 0x1a, 0x92, 0x25, 0x63, 0x19, 0xf7, 0x70, 0x3f, 0x1b, 0x3e, 0x30,
-	0x10, 0xca, 0xbd, 0x7d, 0x58, 0xc9, 0xff, 0x01, 0xba, 0xb5, 0x0e, 0xd5, 0x83, 0x42, 0x30, 0x0f,
-	0xd2, 0x60, 0x36, 0x59, 0x1c, 0x88, 0x90, 0x85, 0x93, 0xcd, 0xea, 0x7e, 0xc3, 0x62, 0x7a, 0xb7,
-	0x7f, 0xab, 0x00, 0x13, 0x2f, 0x30, 0x99, 0xbe, 0x38, 0x37, 0xc3, 0xad, 0xe2, 0xeb, 0x67, 0x4c,
-	0x66, 0x57, 0x44, 0xe3, 0x61, 0xac, 0xf4, 0x06, 0x15, 0x3f, 0x35, 0x89, 0x07, 0xcd, 0x27, 0x51,
-	0x24, 0xce, 0x8f, 0x18, 0x3d, 0xe3, 0x71, 0x5f, 0x4f, 0x85, 0x9a, 0x5f, 0xc0, 0xc8, 0x7d, 0x20,
-	0xf6, 0xf1, 0xa5, 0x14, 0xc7, 0xf4, 0x98, 0x47, 0x3c, 0xb9, 0xd0, 0xbd, 0xe5, 0xf8, 0x33, 0x18,
-	0xcc, 0x2e, 0xe6, 0xef, 0x88, 0x87, 0xc9, 0x40, 0xb7, 0x5a, 0xc5, 0x9f, 0x00, 0xc8, 0x1e, 0xd1,
-	0x94, 0xad, 0x1a, 0x36, 0x03, 0x52, 0xf6, 0x70, 0x44, 0x03, 0xa6, 0x1b, 0xcc, 0xb2, 0x1a, 0x40,
-	0xf6, 0x25, 0x4d, 0x06, 0x66, 0x6d, 0xcd, 0xb0, 0x19, 0x80, 0x7e, 0x1e, 0x0e, 0xc4, 0xf9, 0x2e,
-	0x57, 0x09, 0x8d, 0x03, 0xf6, 0x9a, 0x46, 0x63, 0xa6, 0x6c, 0x3f, 0xcc, 0x60, 0xca, 0xfa, 0xae,
-	0x88, 0x84, 0x54, 0xba, 0x4f, 0x4a, 0x7a, 0xc3, 0x90, 0xaf, 0xa1, 0x85, 0xe8, 0x11, 0xe3, 0xfd,
-	0x41, 0x62, 0x77, 0xbf, 0xab, 0xd5, 0x53, 0x38, 0xf9, 0x02, 0x96, 0x0e, 0x4f, 0xf9, 0xe8, 0xb9,
-	0xe4, 0x61, 0x77, 0xc0, 0x82, 0x53, 0xb7, 0xa9, 0x85, 0x45, 0x90, 0x3c, 0x04, 0x78, 0x21, 0x47,
-	0x03, 0x1a, 0x1f, 0x50, 0x75, 0xea, 0x2e, 0xe9, 0x8a, 0x9c, 0x59, 0xe3, 0x39, 0x19, 0x26, 0x73,
-	0xa7, 0xaf, 0x5d, 0x72, 0x5b, 0xe6, 0x9a, 0xb2, 0x26, 0xd9, 0x84, 0xc6, 0x8e, 0x90, 0x21, 0x93,
-	0x86, 0x6d, 0x9b, 0x5a, 0xc9, 0x41, 0x69, 0x78, 0x0d, 0x4f, 0xcc, 0x0d, 0x90, 0x01, 0x64, 0x1b,
-	0xd6, 0xba, 0xc5, 0xb9, 0x65, 0x84, 0x6b, 0x5a, 0x38, 0x93, 0xc3, 0x02, 0x7a, 0xce, 0xe2, 0x5d,
-	0x49, 0xcf, 0x77, 0x59, 0x44, 0x2f, 0xdc, 0x1b, 0x66, 0x78, 0xe6, 0x31, 0x72, 0x1b, 0xc0, 0xd4,
-	0xfb, 0x93, 0xa8, 0x2f, 0xdc, 0x8e, 0x56, 0xe4, 0x10, 0x0c, 0xec, 0x8e, 0xa4, 0x3c, 0xcc, 0x97,
-	0xd7, 0x4d, 0x5d, 0x5e, 0x53, 0x38, 0x59, 0x86, 0xb9, 0xbd, 0xd0, 0xbd, 0xa5, 0xf7, 0x98, 0xdb,
-	0x0b, 0x49, 0x0b, 0x2a, 0xcf, 0xc7, 0xdc, 0xfd, 0x9f, 0x0e, 0x2f, 0x3e, 0x92, 0x0e, 0xd4, 0x9e,
-	0x49, 0x31, 0x7c, 0xc6, 0x23, 0xe6, 0xde, 0x36, 0x17, 0x4c, 0x6a, 0x97, 0x5b, 0xf3, 0x4e, 0xb9,
-	0x35, 0xf1, 0x8a, 0x48, 0x78, 0x12, 0x31, 0x77, 0x53, 0xaf, 0x34, 0x86, 0xf7, 0xd7, 0x3c, 0x34,
-	0xf3, 0xaf, 0x1a, 0x18, 0x47, 0x7d, 0xe7, 0xe9, 0x03, 0x99, 0x8e, 0x9d, 0x00, 0xe4, 0x1e, 0xb4,
-	0x77, 0xb9, 0xd2, 0xd7, 0x92, 0xa4, 0xe7, 0x2f, 0x4e, 0x4e, 0x14, 0x4b, 0xec, 0x18, 0x98, 0x26,
-	0xc8, 0x97, 0xb0, 0x8c, 0x16, 0xd6, 0xf1, 0x3e, 0x8b, 0xfb, 0xc9, 0x40, 0x37, 0x61, 0xc5, 0x2f,
-	0xa1, 0x18, 0xa5, 0x03, 0x2a, 0x4f, 0x5f, 0x9b, 0x31, 0xdc, 0xd5, 0x23, 0xb6, 0x6e, 0xca, 0xaf,
-	0x8c, 0x93, 0x6f, 0x61, 0xfd, 0xe7, 0xf1, 0xf0, 0x98, 0xc9, 0xa9, 0x15, 0xa6, 0x1d, 0x2e, 0x61,
-	0xb1, 0x82, 0x9e, 0x9c, 0xd1, 0x84, 0xca, 0xbd, 0x21, 0xde, 0x84, 0xcb, 0xa6, 0x82, 0x72, 0x10,
-	0x7a, 0x91, 0x5b, 0x61, 0xea, 0x63, 0x45, 0xcb, 0xa6, 0xf0, 0x4b, 0xeb, 0xa9, 0x75, 0x45, 0x3d,
-	0xd9, 0x16, 0x37, 0xc2, 0x55, 0x13, 0xd9, 0x0c, 0xc0, 0xb6, 0x7a, 0x66, 0x2f, 0x48, 0xa3, 0xb8,
-	0xae, 0x15, 0x45, 0x10, 0x4f, 0xf1, 0x4a, 0x4c, 0x34, 0xeb, 0xe6, 0x14, 0x39, 0x28, 0xad, 0x11,
-	0x04, 0xdc, 0x8d, 0x49, 0x8d, 0xa4, 0x57, 0xa9, 0x91, 0xba, 0xae, 0x19, 0xc5, 0xc6, 0x4a, 0xdb,
-	0x1f, 0x75, 0xaf, 0x84, 0x1d, 0x16, 0x37, 0x27, 0xed, 0x9f, 0xc7, 0xbd, 0x47, 0xd0, 0xcc, 0xf7,
-	0x2f, 0x69, 0x82, 0xf3, 0xc6, 0x4e, 0x64, 0xe7, 0x0d, 0x5a, 0x6f, 0xed, 0x20, 0x76, 0xde, 0xa2,
-	0xf5, 0xce, 0xa6, 0xdc, 0x79, 0xb7, 0xfd, 0x67, 0x05, 0x16, 0x70, 0xa9, 0x24, 0x8f, 0xd3, 0xae,
-	0xd1, 0x85, 0xe9, 0xa6, 0xd7, 0x68, 0xf9, 0x66, 0xea, 0xac, 0xcf, 0x60, 0x46, 0xd1, 0x85, 0x77,
-	0x8d, 0xfc, 0x00, 0xf5, 0xf4, 0xf2, 0x53, 0x24, 0x95, 0x95, 0x6e, 0xc8, 0xce, 0xda, 0x14, 0x6e,
-	0x16, 0xef, 0xda, 0x12, 0xd7, 0xff, 0xbe, 0x61, 0x45, 0xe5, 0xf7, 0xd0, 0x8e, 0x3b, 0x4d, 0x98,
-	0x37, 0x40, 0xef, 0xda, 0x96, 0xf3, 0xc0, 0x21, 0xfb, 0xb0, 0x5c, 0x7c, 0x09, 0x27, 0xb7, 0xec,
-	0x8a, 0x99, 0x1f, 0x02, 0x9d, 0xce, 0x25, 0xac, 0xf1, 0xa9, 0x0b, 0x8d, 0xdc, 0xc7, 0x13, 0xb9,
-	0x91, 0x89, 0xcb, 0x5f, 0x67, 0x9d, 0x8d, 0x59, 0x94, 0xd9, 0xe4, 0x31, 0xc0, 0xe4, 0x5b, 0x26,
-	0x8b, 0xeb, 0xd4, 0xc7, 0x50, 0x16, 0xd7, 0xd2, 0x87, 0x8f, 0x77, 0xed, 0xb8, 0xaa, 0x89, 0x87,
-	0xff, 0x05, 0x00, 0x00, 0xff, 0xff, 0x2d, 0x2e, 0x46, 0xcb, 0x2d, 0x0e, 0x00, 0x00,
+var File_mazes_proto protoreflect.FileDescriptor
+
+var file_mazes_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x6d, 0x61, 0x7a, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4a, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x65, 0x74, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61,
+	0x7a, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x7a,
+	0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64,
+	0x22, 0x86, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x65, 0x74, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3e, 0x0a, 0x10, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2c, 0x0a, 0x11, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x6d, 0x61, 0x7a, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6d, 0x61, 0x7a, 0x65, 0x49, 0x64, 0x22, 0x45, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x93,
+	0x01, 0x0a, 0x11, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x7a, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x7a, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a,
+	0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x68, 0x6f, 0x77, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x12, 0x73, 0x68, 0x6f, 0x77, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x43, 0x6f,
+	0x6c, 0x6f, 0x72, 0x73, 0x22, 0x5b, 0x0a, 0x0f, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4d, 0x61,
+	0x7a, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x22, 0x29, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x7a, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x7a, 0x65, 0x49, 0x64, 0x22, 0x63, 0x0a, 0x0c,
+	0x47, 0x65, 0x74, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x1f, 0x0a, 0x04, 0x6d, 0x61, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x04, 0x6d, 0x61, 0x7a,
+	0x65, 0x22, 0x2c, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x7a, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x7a, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x7a, 0x65, 0x49, 0x64, 0x22,
+	0x45, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x91, 0x01, 0x0a, 0x11, 0x49, 0x6d, 0x70, 0x6f, 0x72,
+	0x74, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x06,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x6e, 0x63, 0x6f, 0x64,
+	0x65, 0x64, 0x5f, 0x6d, 0x61, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65,
+	0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x4d, 0x61, 0x7a, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x5f, 0x73, 0x70, 0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x72, 0x65,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x70,
+	0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x54, 0x72, 0x65, 0x65, 0x22, 0x5e, 0x0a, 0x0f, 0x49, 0x6d,
+	0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x7a, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x7a, 0x65, 0x49, 0x64, 0x22, 0x2b, 0x0a, 0x10, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x6d, 0x61, 0x7a, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6d, 0x61, 0x7a, 0x65, 0x49, 0x64, 0x22, 0xe0, 0x02, 0x0a, 0x09, 0x4d, 0x61, 0x7a, 0x65,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x7a,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x7a, 0x65,
+	0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12,
+	0x2f, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x4c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b,
+	0x0a, 0x09, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x6d, 0x6f, 0x76, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x77, 0x61, 0x72, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x65, 0x77,
+	0x61, 0x72, 0x64, 0x12, 0x2c, 0x0a, 0x07, 0x74, 0x6f, 0x5f, 0x63, 0x65, 0x6c, 0x6c, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a,
+	0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x74, 0x6f, 0x43, 0x65, 0x6c,
+	0x6c, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12,
+	0x29, 0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x22, 0x6a, 0x0a, 0x15, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x7a, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x7a, 0x65, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x0d,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0xc6, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12,
+	0x30, 0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x63, 0x65, 0x6c, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x43, 0x65, 0x6c,
+	0x6c, 0x12, 0x2c, 0x0a, 0x07, 0x74, 0x6f, 0x5f, 0x63, 0x65, 0x6c, 0x6c, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x74, 0x6f, 0x43, 0x65, 0x6c, 0x6c, 0x22,
+	0x9c, 0x01, 0x0a, 0x10, 0x53, 0x6f, 0x6c, 0x76, 0x65, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x7a, 0x65, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x7a, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61,
+	0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6d, 0x6f, 0x76, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x22, 0x84,
+	0x01, 0x0a, 0x10, 0x52, 0x75, 0x6e, 0x53, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x7a, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x7a, 0x65, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x0d,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x72, 0x61, 0x77, 0x5f, 0x64,
+	0x65, 0x6c, 0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x72, 0x61, 0x77,
+	0x44, 0x65, 0x6c, 0x61, 0x79, 0x22, 0xf6, 0x02, 0x0a, 0x0e, 0x52, 0x75, 0x6e, 0x53, 0x6f, 0x6c,
+	0x76, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x6c,
+	0x76, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x31, 0x0a, 0x0a, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x09, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x33, 0x0a, 0x0b, 0x74, 0x72,
+	0x61, 0x76, 0x65, 0x6c, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x30,
+	0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x63, 0x65, 0x6c, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x4c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x43, 0x65, 0x6c, 0x6c,
+	0x12, 0x2c, 0x0a, 0x07, 0x74, 0x6f, 0x5f, 0x63, 0x65, 0x6c, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x4c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x74, 0x6f, 0x43, 0x65, 0x6c, 0x6c, 0x22, 0xc4,
+	0x01, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x7a, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d,
+	0x61, 0x7a, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x73, 0x12, 0x29, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x65, 0x65, 0x64, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x6d, 0x61, 0x7a,
+	0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e,
+	0x4d, 0x61, 0x7a, 0x65, 0x73, 0x22, 0x9c, 0x01, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x4d, 0x61, 0x7a, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x6d, 0x61, 0x7a, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x61, 0x7a, 0x65, 0x49, 0x64, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x6e, 0x63,
+	0x6f, 0x64, 0x65, 0x64, 0x5f, 0x6d, 0x61, 0x7a, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0c, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x4d, 0x61, 0x7a, 0x65, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x65, 0x65, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x03, 0x52, 0x05, 0x73,
+	0x65, 0x65, 0x64, 0x73, 0x22, 0xf9, 0x03, 0x0a, 0x11, 0x53, 0x6f, 0x6c, 0x76, 0x65, 0x4d, 0x61,
+	0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61,
+	0x7a, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x7a,
+	0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64,
+	0x12, 0x43, 0x0a, 0x14, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x13, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3e, 0x0a, 0x10, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a,
+	0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x09, 0x66, 0x72,
+	0x6f, 0x6d, 0x5f, 0x63, 0x65, 0x6c, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x2c, 0x0a, 0x07,
+	0x74, 0x6f, 0x5f, 0x63, 0x65, 0x6c, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x06, 0x74, 0x6f, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f,
+	0x6c, 0x76, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x6f, 0x6c, 0x76,
+	0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x06, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61,
+	0x7a, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x6d, 0x61, 0x7a, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a,
+	0x0c, 0x6d, 0x61, 0x7a, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x6d, 0x61, 0x7a, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
+	0x22, 0x39, 0x0a, 0x09, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x69, 0x73, 0x69, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x76, 0x69, 0x73, 0x69, 0x74, 0x65, 0x64, 0x22, 0xcd, 0x01, 0x0a, 0x04,
+	0x4d, 0x61, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x7a, 0x65, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x7a, 0x65, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x05,
+	0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x73, 0x12, 0x29, 0x0a,
+	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2b, 0x0a, 0x07, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0x90, 0x02, 0x0a, 0x04,
+	0x43, 0x65, 0x6c, 0x6c, 0x12, 0x2f, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d,
+	0x61, 0x7a, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x77, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x72, 0x70, 0x68, 0x61, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x6f, 0x72, 0x70, 0x68, 0x61, 0x6e, 0x12, 0x21, 0x0a, 0x05, 0x75,
+	0x6e, 0x64, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x05, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x32,
+	0x0a, 0x07, 0x76, 0x69, 0x73, 0x69, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x2e, 0x56, 0x69, 0x73,
+	0x69, 0x74, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x76, 0x69, 0x73, 0x69, 0x74,
+	0x65, 0x64, 0x1a, 0x3a, 0x0a, 0x0c, 0x56, 0x69, 0x73, 0x69, 0x74, 0x65, 0x64, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xf7,
+	0x02, 0x0a, 0x0a, 0x4d, 0x61, 0x7a, 0x65, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x09, 0x66, 0x72,
+	0x6f, 0x6d, 0x5f, 0x63, 0x65, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x2c, 0x0a, 0x07,
+	0x74, 0x6f, 0x5f, 0x63, 0x65, 0x6c, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x06, 0x74, 0x6f, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x3e, 0x0a, 0x10, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a,
+	0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x06, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x33, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x76, 0x65,
+	0x6c, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x0a, 0x74, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x50, 0x61, 0x74, 0x68, 0x12, 0x34, 0x0a, 0x16,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x64,
+	0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0x88, 0x01, 0x0a, 0x0b, 0x50, 0x61, 0x74,
+	0x68, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2f, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x64,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x66, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x66, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6f, 0x6c, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x6f, 0x6c, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x11, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x7a, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x32, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61,
+	0x7a, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x21, 0x0a, 0x05, 0x6d, 0x61, 0x7a, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d,
+	0x61, 0x7a, 0x65, 0x52, 0x05, 0x6d, 0x61, 0x7a, 0x65, 0x73, 0x22, 0x5f, 0x0a, 0x11, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x29, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65,
+	0x74, 0x75, 0x72, 0x6e, 0x5f, 0x6d, 0x61, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0a, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x4d, 0x61, 0x7a, 0x65, 0x22, 0x4c, 0x0a, 0x0f, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16,
+	0x0a, 0x06, 0x4d, 0x61, 0x7a, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x4d, 0x61, 0x7a, 0x65, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65,
+	0x64, 0x5f, 0x6d, 0x61, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x6e,
+	0x63, 0x6f, 0x64, 0x65, 0x64, 0x4d, 0x61, 0x7a, 0x65, 0x22, 0xb2, 0x09, 0x0a, 0x0a, 0x4d, 0x61,
+	0x7a, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x52, 0x6f, 0x77, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x52, 0x6f, 0x77, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x43,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x57,
+	0x65, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x41, 0x6c,
+	0x6c, 0x6f, 0x77, 0x57, 0x65, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x12, 0x2e, 0x0a, 0x12, 0x57, 0x65,
+	0x61, 0x76, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x57, 0x65, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x50,
+	0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x65,
+	0x6c, 0x6c, 0x57, 0x69, 0x64, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43,
+	0x65, 0x6c, 0x6c, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x57, 0x61, 0x6c, 0x6c,
+	0x57, 0x69, 0x64, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x57, 0x61, 0x6c,
+	0x6c, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x57, 0x61, 0x6c, 0x6c, 0x53, 0x70,
+	0x61, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x57, 0x61, 0x6c, 0x6c, 0x53,
+	0x70, 0x61, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x50, 0x61, 0x74, 0x68, 0x57, 0x69, 0x64, 0x74,
+	0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x50, 0x61, 0x74, 0x68, 0x57, 0x69, 0x64,
+	0x74, 0x68, 0x12, 0x2e, 0x0a, 0x12, 0x53, 0x68, 0x6f, 0x77, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12,
+	0x53, 0x68, 0x6f, 0x77, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x53, 0x68, 0x6f, 0x77, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12,
+	0x53, 0x68, 0x6f, 0x77, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x6c, 0x6f,
+	0x72, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x53, 0x68, 0x6f, 0x77, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x21, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x53, 0x68,
+	0x6f, 0x77, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x24,
+	0x0a, 0x0d, 0x53, 0x6b, 0x69, 0x70, 0x47, 0x72, 0x69, 0x64, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x53, 0x6b, 0x69, 0x70, 0x47, 0x72, 0x69, 0x64, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x12, 0x33, 0x0a, 0x0a, 0x4f, 0x72, 0x70, 0x68, 0x61, 0x6e, 0x4d, 0x61,
+	0x73, 0x6b, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x4d, 0x61, 0x7a, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x4f,
+	0x72, 0x70, 0x68, 0x61, 0x6e, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x42, 0x67, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x42, 0x67, 0x43, 0x6f,
+	0x6c, 0x6f, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x42, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6c,
+	0x6f, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x42, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x57, 0x61, 0x6c, 0x6c, 0x43, 0x6f, 0x6c,
+	0x6f, 0x72, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x57, 0x61, 0x6c, 0x6c, 0x43, 0x6f,
+	0x6c, 0x6f, 0x72, 0x12, 0x32, 0x0a, 0x14, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x14, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x14, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x47, 0x65, 0x6e, 0x44, 0x72,
+	0x61, 0x77, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x47,
+	0x65, 0x6e, 0x44, 0x72, 0x61, 0x77, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x67, 0x6f, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x67, 0x6f, 0x12, 0x2a, 0x0a, 0x10, 0x42,
+	0x72, 0x61, 0x69, 0x64, 0x50, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18,
+	0x1b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x42, 0x72, 0x61, 0x69, 0x64, 0x50, 0x72, 0x6f, 0x62,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x1c, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x47, 0x75, 0x69, 0x18, 0x1d,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x47, 0x75, 0x69, 0x12, 0x1a, 0x0a, 0x08, 0x46, 0x72, 0x6f,
+	0x6d, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x46, 0x72, 0x6f,
+	0x6d, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f,
+	0x6d, 0x61, 0x7a, 0x65, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x74, 0x75,
+	0x72, 0x6e, 0x4d, 0x61, 0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
+	0x20, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x47, 0x72, 0x69, 0x64, 0x54, 0x79, 0x70, 0x65, 0x18, 0x22, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x47, 0x72, 0x69, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x73, 0x18, 0x23, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x56, 0x69, 0x65, 0x77, 0x18, 0x24, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x56, 0x69, 0x65, 0x77, 0x12, 0x12,
+	0x0a, 0x04, 0x57, 0x72, 0x61, 0x70, 0x18, 0x25, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x57, 0x72,
+	0x61, 0x70, 0x12, 0x2b, 0x0a, 0x06, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x26, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
+	0x20, 0x0a, 0x0b, 0x47, 0x72, 0x6f, 0x77, 0x69, 0x6e, 0x67, 0x54, 0x72, 0x65, 0x65, 0x18, 0x27,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x47, 0x72, 0x6f, 0x77, 0x69, 0x6e, 0x67, 0x54, 0x72, 0x65,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x65, 0x65, 0x64, 0x18, 0x28, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x53, 0x65, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x53,
+	0x68, 0x69, 0x66, 0x74, 0x53, 0x74, 0x65, 0x70, 0x73, 0x18, 0x29, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x10, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x53, 0x68, 0x69, 0x66, 0x74, 0x53, 0x74, 0x65, 0x70,
+	0x73, 0x12, 0x28, 0x0a, 0x0f, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x53, 0x68, 0x69, 0x66, 0x74,
+	0x52, 0x61, 0x74, 0x65, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x4f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x53, 0x68, 0x69, 0x66, 0x74, 0x52, 0x61, 0x74, 0x65, 0x12, 0x31, 0x0a, 0x08, 0x44,
+	0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x73, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x73, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x08, 0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x73, 0x22, 0xdb,
+	0x04, 0x0a, 0x0c, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x1c, 0x0a, 0x09, 0x53, 0x6f, 0x6c, 0x76, 0x65, 0x41, 0x6c, 0x67, 0x6f, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x53, 0x6f, 0x6c, 0x76, 0x65, 0x41, 0x6c, 0x67, 0x6f, 0x12, 0x2c, 0x0a,
+	0x11, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x72, 0x61, 0x77, 0x4f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c,
+	0x65, 0x44, 0x72, 0x61, 0x77, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x44,
+	0x72, 0x61, 0x77, 0x50, 0x61, 0x74, 0x68, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0e, 0x44, 0x72, 0x61, 0x77, 0x50, 0x61, 0x74, 0x68, 0x4c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x12, 0x2a, 0x0a, 0x10, 0x4d, 0x61, 0x72, 0x6b, 0x56, 0x69, 0x73, 0x69, 0x74,
+	0x65, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x4d,
+	0x61, 0x72, 0x6b, 0x56, 0x69, 0x73, 0x69, 0x74, 0x65, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x12,
+	0x36, 0x0a, 0x16, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4d, 0x61, 0x72, 0x6b, 0x56, 0x69, 0x73,
+	0x69, 0x74, 0x65, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x16, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4d, 0x61, 0x72, 0x6b, 0x56, 0x69, 0x73, 0x69, 0x74,
+	0x65, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x41, 0x76, 0x61, 0x74, 0x61,
+	0x72, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x41, 0x76,
+	0x61, 0x74, 0x61, 0x72, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x56, 0x69, 0x73,
+	0x69, 0x74, 0x65, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x10, 0x56, 0x69, 0x73, 0x69, 0x74, 0x65, 0x64, 0x43, 0x65, 0x6c, 0x6c,
+	0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x32, 0x0a, 0x14, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x14, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x50, 0x61, 0x74,
+	0x68, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x50, 0x61,
+	0x74, 0x68, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x46, 0x72, 0x6f, 0x6d, 0x43,
+	0x65, 0x6c, 0x6c, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x46, 0x72, 0x6f, 0x6d, 0x43, 0x65, 0x6c, 0x6c, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x20, 0x0a,
+	0x0b, 0x54, 0x6f, 0x43, 0x65, 0x6c, 0x6c, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x16, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x54, 0x6f, 0x43, 0x65, 0x6c, 0x6c, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12,
+	0x1a, 0x0a, 0x08, 0x46, 0x72, 0x6f, 0x6d, 0x43, 0x65, 0x6c, 0x6c, 0x18, 0x17, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x46, 0x72, 0x6f, 0x6d, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x54,
+	0x6f, 0x43, 0x65, 0x6c, 0x6c, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54, 0x6f, 0x43,
+	0x65, 0x6c, 0x6c, 0x12, 0x2a, 0x0a, 0x10, 0x53, 0x68, 0x6f, 0x77, 0x46, 0x72, 0x6f, 0x6d, 0x54,
+	0x6f, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x53,
+	0x68, 0x6f, 0x77, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x6f, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x12,
+	0x2b, 0x0a, 0x06, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x96, 0x01, 0x0a,
+	0x0c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a,
+	0x04, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x46, 0x69, 0x6c,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x46, 0x72, 0x61,
+	0x6d, 0x65, 0x53, 0x6b, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x46, 0x72,
+	0x61, 0x6d, 0x65, 0x53, 0x6b, 0x69, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x4d, 0x61, 0x78, 0x46, 0x72,
+	0x61, 0x6d, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x4d, 0x61, 0x78, 0x46,
+	0x72, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x44, 0x65,
+	0x6c, 0x61, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x46, 0x72, 0x61, 0x6d, 0x65,
+	0x44, 0x65, 0x6c, 0x61, 0x79, 0x22, 0x7c, 0x0a, 0x0e, 0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63,
+	0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x52, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x52,
+	0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x44, 0x6f, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x44, 0x6f, 0x6f, 0x72, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x43, 0x6c, 0x6f,
+	0x73, 0x75, 0x72, 0x65, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x43, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x38, 0x0a, 0x0c, 0x4d, 0x61, 0x7a, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x01, 0x58, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x01,
+	0x58, 0x12, 0x0c, 0x0a, 0x01, 0x59, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x01, 0x59, 0x12,
+	0x0c, 0x0a, 0x01, 0x5a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x01, 0x5a, 0x32, 0xe0, 0x06,
+	0x0a, 0x05, 0x4d, 0x61, 0x7a, 0x65, 0x72, 0x12, 0x40, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x4d, 0x61, 0x7a, 0x65, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x61,
+	0x7a, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x09, 0x4c, 0x69, 0x73,
+	0x74, 0x4d, 0x61, 0x7a, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x7a, 0x65, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x09, 0x53, 0x6f, 0x6c, 0x76, 0x65, 0x4d,
+	0x61, 0x7a, 0x65, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6f, 0x6c, 0x76,
+	0x65, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6f, 0x6c, 0x76, 0x65, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x0e,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x1c,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0b, 0x52, 0x65,
+	0x73, 0x65, 0x74, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x74, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73,
+	0x65, 0x74, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x40, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x7a, 0x65, 0x12, 0x18, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x7a, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x40, 0x0a, 0x0a, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4d, 0x61, 0x7a, 0x65, 0x12,
+	0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4d, 0x61,
+	0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x7a, 0x65, 0x12, 0x15,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65,
+	0x74, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0a,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x7a, 0x65, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x40,
+	0x0a, 0x0a, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x7a, 0x65, 0x12, 0x18, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x7a, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49,
+	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x7a, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x3a, 0x0a, 0x09, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x61, 0x7a, 0x65, 0x12, 0x17, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x61, 0x7a, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d,
+	0x61, 0x7a, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x3d, 0x0a, 0x09,
+	0x52, 0x75, 0x6e, 0x53, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x52, 0x75, 0x6e, 0x53, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x75, 0x6e, 0x53, 0x6f,
+	0x6c, 0x76, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0b, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x7a, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x7a, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x7a, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_mazes_proto_rawDescOnce sync.Once
+	file_mazes_proto_rawDescData = file_mazes_proto_rawDesc
+)
+
+func file_mazes_proto_rawDescGZIP() []byte {
+	file_mazes_proto_rawDescOnce.Do(func() {
+		file_mazes_proto_rawDescData = protoimpl.X.CompressGZIP(file_mazes_proto_rawDescData)
+	})
+	return file_mazes_proto_rawDescData
+}
+
+var file_mazes_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
+var file_mazes_proto_goTypes = []interface{}{
+	(*ResetClientRequest)(nil),    // 0: proto.ResetClientRequest
+	(*ResetClientReply)(nil),      // 1: proto.ResetClientReply
+	(*ExportMazeRequest)(nil),     // 2: proto.ExportMazeRequest
+	(*ExportMazeReply)(nil),       // 3: proto.ExportMazeReply
+	(*RenderMazeRequest)(nil),     // 4: proto.RenderMazeRequest
+	(*RenderMazeReply)(nil),       // 5: proto.RenderMazeReply
+	(*GetMazeRequest)(nil),        // 6: proto.GetMazeRequest
+	(*GetMazeReply)(nil),          // 7: proto.GetMazeReply
+	(*DeleteMazeRequest)(nil),     // 8: proto.DeleteMazeRequest
+	(*DeleteMazeReply)(nil),       // 9: proto.DeleteMazeReply
+	(*ImportMazeRequest)(nil),     // 10: proto.ImportMazeRequest
+	(*ImportMazeReply)(nil),       // 11: proto.ImportMazeReply
+	(*WatchMazeRequest)(nil),      // 12: proto.WatchMazeRequest
+	(*MazeEvent)(nil),             // 13: proto.MazeEvent
+	(*RegisterClientRequest)(nil), // 14: proto.RegisterClientRequest
+	(*RegisterClientReply)(nil),   // 15: proto.RegisterClientReply
+	(*SolveMazeRequest)(nil),      // 16: proto.SolveMazeRequest
+	(*RunSolverRequest)(nil),      // 17: proto.RunSolverRequest
+	(*RunSolverReply)(nil),        // 18: proto.RunSolverReply
+	(*CreateMazesRequest)(nil),    // 19: proto.CreateMazesRequest
+	(*CreateMazesReply)(nil),      // 20: proto.CreateMazesReply
+	(*SolveMazeResponse)(nil),     // 21: proto.SolveMazeResponse
+	(*Direction)(nil),             // 22: proto.Direction
+	(*Maze)(nil),                  // 23: proto.Maze
+	(*Cell)(nil),                  // 24: proto.Cell
+	(*MazeClient)(nil),            // 25: proto.MazeClient
+	(*PathSegment)(nil),           // 26: proto.PathSegment
+	(*ListMazeRequest)(nil),       // 27: proto.ListMazeRequest
+	(*ListMazeReply)(nil),         // 28: proto.ListMazeReply
+	(*CreateMazeRequest)(nil),     // 29: proto.CreateMazeRequest
+	(*CreateMazeReply)(nil),       // 30: proto.CreateMazeReply
+	(*MazeConfig)(nil),            // 31: proto.MazeConfig
+	(*ClientConfig)(nil),          // 32: proto.ClientConfig
+	(*RecordConfig)(nil),          // 33: proto.RecordConfig
+	(*DynamicsConfig)(nil),        // 34: proto.DynamicsConfig
+	(*MazeLocation)(nil),          // 35: proto.MazeLocation
+	nil,                           // 36: proto.Cell.VisitedEntry
+}
+var file_mazes_proto_depIdxs = []int32{
+	35, // 0: proto.ResetClientReply.current_location:type_name -> proto.MazeLocation
+	23, // 1: proto.GetMazeReply.maze:type_name -> proto.Maze
+	31, // 2: proto.ImportMazeRequest.config:type_name -> proto.MazeConfig
+	35, // 3: proto.MazeEvent.location:type_name -> proto.MazeLocation
+	35, // 4: proto.MazeEvent.to_cell:type_name -> proto.MazeLocation
+	35, // 5: proto.MazeEvent.cells:type_name -> proto.MazeLocation
+	32, // 6: proto.RegisterClientRequest.client_config:type_name -> proto.ClientConfig
+	35, // 7: proto.RegisterClientReply.from_cell:type_name -> proto.MazeLocation
+	35, // 8: proto.RegisterClientReply.to_cell:type_name -> proto.MazeLocation
+	32, // 9: proto.RunSolverRequest.client_config:type_name -> proto.ClientConfig
+	26, // 10: proto.RunSolverReply.solve_path:type_name -> proto.PathSegment
+	26, // 11: proto.RunSolverReply.travel_path:type_name -> proto.PathSegment
+	35, // 12: proto.RunSolverReply.from_cell:type_name -> proto.MazeLocation
+	35, // 13: proto.RunSolverReply.to_cell:type_name -> proto.MazeLocation
+	31, // 14: proto.CreateMazesRequest.configs:type_name -> proto.MazeConfig
+	31, // 15: proto.CreateMazesRequest.config:type_name -> proto.MazeConfig
+	22, // 16: proto.SolveMazeResponse.available_directions:type_name -> proto.Direction
+	35, // 17: proto.SolveMazeResponse.current_location:type_name -> proto.MazeLocation
+	35, // 18: proto.SolveMazeResponse.from_cell:type_name -> proto.MazeLocation
+	35, // 19: proto.SolveMazeResponse.to_cell:type_name -> proto.MazeLocation
+	24, // 20: proto.Maze.cells:type_name -> proto.Cell
+	31, // 21: proto.Maze.config:type_name -> proto.MazeConfig
+	25, // 22: proto.Maze.clients:type_name -> proto.MazeClient
+	35, // 23: proto.Cell.location:type_name -> proto.MazeLocation
+	24, // 24: proto.Cell.under:type_name -> proto.Cell
+	36, // 25: proto.Cell.visited:type_name -> proto.Cell.VisitedEntry
+	35, // 26: proto.MazeClient.from_cell:type_name -> proto.MazeLocation
+	35, // 27: proto.MazeClient.to_cell:type_name -> proto.MazeLocation
+	35, // 28: proto.MazeClient.current_location:type_name -> proto.MazeLocation
+	32, // 29: proto.MazeClient.config:type_name -> proto.ClientConfig
+	26, // 30: proto.MazeClient.travel_path:type_name -> proto.PathSegment
+	35, // 31: proto.PathSegment.location:type_name -> proto.MazeLocation
+	23, // 32: proto.ListMazeReply.mazes:type_name -> proto.Maze
+	31, // 33: proto.CreateMazeRequest.config:type_name -> proto.MazeConfig
+	35, // 34: proto.MazeConfig.OrphanMask:type_name -> proto.MazeLocation
+	33, // 35: proto.MazeConfig.Record:type_name -> proto.RecordConfig
+	34, // 36: proto.MazeConfig.Dynamics:type_name -> proto.DynamicsConfig
+	33, // 37: proto.ClientConfig.Record:type_name -> proto.RecordConfig
+	29, // 38: proto.Mazer.CreateMaze:input_type -> proto.CreateMazeRequest
+	27, // 39: proto.Mazer.ListMazes:input_type -> proto.ListMazeRequest
+	16, // 40: proto.Mazer.SolveMaze:input_type -> proto.SolveMazeRequest
+	14, // 41: proto.Mazer.RegisterClient:input_type -> proto.RegisterClientRequest
+	0,  // 42: proto.Mazer.ResetClient:input_type -> proto.ResetClientRequest
+	2,  // 43: proto.Mazer.ExportMaze:input_type -> proto.ExportMazeRequest
+	4,  // 44: proto.Mazer.RenderMaze:input_type -> proto.RenderMazeRequest
+	6,  // 45: proto.Mazer.GetMaze:input_type -> proto.GetMazeRequest
+	8,  // 46: proto.Mazer.DeleteMaze:input_type -> proto.DeleteMazeRequest
+	10, // 47: proto.Mazer.ImportMaze:input_type -> proto.ImportMazeRequest
+	12, // 48: proto.Mazer.WatchMaze:input_type -> proto.WatchMazeRequest
+	17, // 49: proto.Mazer.RunSolver:input_type -> proto.RunSolverRequest
+	19, // 50: proto.Mazer.CreateMazes:input_type -> proto.CreateMazesRequest
+	30, // 51: proto.Mazer.CreateMaze:output_type -> proto.CreateMazeReply
+	28, // 52: proto.Mazer.ListMazes:output_type -> proto.ListMazeReply
+	21, // 53: proto.Mazer.SolveMaze:output_type -> proto.SolveMazeResponse
+	15, // 54: proto.Mazer.RegisterClient:output_type -> proto.RegisterClientReply
+	1,  // 55: proto.Mazer.ResetClient:output_type -> proto.ResetClientReply
+	3,  // 56: proto.Mazer.ExportMaze:output_type -> proto.ExportMazeReply
+	5,  // 57: proto.Mazer.RenderMaze:output_type -> proto.RenderMazeReply
+	7,  // 58: proto.Mazer.GetMaze:output_type -> proto.GetMazeReply
+	9,  // 59: proto.Mazer.DeleteMaze:output_type -> proto.DeleteMazeReply
+	11, // 60: proto.Mazer.ImportMaze:output_type -> proto.ImportMazeReply
+	13, // 61: proto.Mazer.WatchMaze:output_type -> proto.MazeEvent
+	18, // 62: proto.Mazer.RunSolver:output_type -> proto.RunSolverReply
+	20, // 63: proto.Mazer.CreateMazes:output_type -> proto.CreateMazesReply
+	51, // [51:64] is the sub-list for method output_type
+	38, // [38:51] is the sub-list for method input_type
+	38, // [38:38] is the sub-list for extension type_name
+	38, // [38:38] is the sub-list for extension extendee
+	0,  // [0:38] is the sub-list for field type_name
+}
+
+func init() { file_mazes_proto_init() }
+func file_mazes_proto_init() {
+	if File_mazes_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_mazes_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResetClientRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResetClientReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportMazeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportMazeReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenderMazeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenderMazeReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMazeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMazeReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteMazeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteMazeReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportMazeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportMazeReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchMazeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MazeEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterClientRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterClientReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SolveMazeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunSolverRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunSolverReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateMazesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateMazesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SolveMazeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Direction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Maze); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cell); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MazeClient); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathSegment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListMazeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListMazeReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateMazeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateMazeReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MazeConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DynamicsConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mazes_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MazeLocation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_mazes_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   37,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mazes_proto_goTypes,
+		DependencyIndexes: file_mazes_proto_depIdxs,
+		MessageInfos:      file_mazes_proto_msgTypes,
+	}.Build()
+	File_mazes_proto = out.File
+	file_mazes_proto_rawDesc = nil
+	file_mazes_proto_goTypes = nil
+	file_mazes_proto_depIdxs = nil
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ context.Context
-var _ grpc.ClientConn
+var _ grpc.ClientConnInterface
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+const _ = grpc.SupportPackageIsVersion6
 
 // MazerClient is the client API for Mazer service.
 //
@@ -1394,13 +3999,27 @@ type MazerClient interface {
 	ResetClient(ctx context.Context, in *ResetClientRequest, opts ...grpc.CallOption) (*ResetClientReply, error)
 	// Export a maze,
 	ExportMaze(ctx context.Context, in *ExportMazeRequest, opts ...grpc.CallOption) (*ExportMazeReply, error)
+	// Render a snapshot of a maze as an image
+	RenderMaze(ctx context.Context, in *RenderMazeRequest, opts ...grpc.CallOption) (*RenderMazeReply, error)
+	// Get the full structure of an existing maze
+	GetMaze(ctx context.Context, in *GetMazeRequest, opts ...grpc.CallOption) (*GetMazeReply, error)
+	// Delete a maze, ends all of its clients
+	DeleteMaze(ctx context.Context, in *DeleteMazeRequest, opts ...grpc.CallOption) (*DeleteMazeReply, error)
+	// Create a maze from an encoded maze sent by the client, e.g. one exported earlier
+	ImportMaze(ctx context.Context, in *ImportMazeRequest, opts ...grpc.CallOption) (*ImportMazeReply, error)
+	// Watch a maze: generator steps, clients and their moves, until the maze is deleted
+	WatchMaze(ctx context.Context, in *WatchMazeRequest, opts ...grpc.CallOption) (Mazer_WatchMazeClient, error)
+	// Register a new client and solve the maze with it on the server, without a round trip per move
+	RunSolver(ctx context.Context, in *RunSolverRequest, opts ...grpc.CallOption) (*RunSolverReply, error)
+	// Create many mazes at once, generated in parallel
+	CreateMazes(ctx context.Context, in *CreateMazesRequest, opts ...grpc.CallOption) (*CreateMazesReply, error)
 }
 
 type mazerClient struct {
-	cc *grpc.ClientConn
+	cc grpc.ClientConnInterface
 }
 
-func NewMazerClient(cc *grpc.ClientConn) MazerClient {
+func NewMazerClient(cc grpc.ClientConnInterface) MazerClient {
 	return &mazerClient{cc}
 }
 
@@ -1480,6 +4099,92 @@ func (c *mazerClient) ExportMaze(ctx context.Context, in *ExportMazeRequest, opt
 	return out, nil
 }
 
+func (c *mazerClient) RenderMaze(ctx context.Context, in *RenderMazeRequest, opts ...grpc.CallOption) (*RenderMazeReply, error) {
+	out := new(RenderMazeReply)
+	err := c.cc.Invoke(ctx, "/proto.Mazer/RenderMaze", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mazerClient) GetMaze(ctx context.Context, in *GetMazeRequest, opts ...grpc.CallOption) (*GetMazeReply, error) {
+	out := new(GetMazeReply)
+	err := c.cc.Invoke(ctx, "/proto.Mazer/GetMaze", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mazerClient) DeleteMaze(ctx context.Context, in *DeleteMazeRequest, opts ...grpc.CallOption) (*DeleteMazeReply, error) {
+	out := new(DeleteMazeReply)
+	err := c.cc.Invoke(ctx, "/proto.Mazer/DeleteMaze", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mazerClient) ImportMaze(ctx context.Context, in *ImportMazeRequest, opts ...grpc.CallOption) (*ImportMazeReply, error) {
+	out := new(ImportMazeReply)
+	err := c.cc.Invoke(ctx, "/proto.Mazer/ImportMaze", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mazerClient) WatchMaze(ctx context.Context, in *WatchMazeRequest, opts ...grpc.CallOption) (Mazer_WatchMazeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Mazer_serviceDesc.Streams[1], "/proto.Mazer/WatchMaze", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mazerWatchMazeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Mazer_WatchMazeClient interface {
+	Recv() (*MazeEvent, error)
+	grpc.ClientStream
+}
+
+type mazerWatchMazeClient struct {
+	grpc.ClientStream
+}
+
+func (x *mazerWatchMazeClient) Recv() (*MazeEvent, error) {
+	m := new(MazeEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mazerClient) RunSolver(ctx context.Context, in *RunSolverRequest, opts ...grpc.CallOption) (*RunSolverReply, error) {
+	out := new(RunSolverReply)
+	err := c.cc.Invoke(ctx, "/proto.Mazer/RunSolver", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mazerClient) CreateMazes(ctx context.Context, in *CreateMazesRequest, opts ...grpc.CallOption) (*CreateMazesReply, error) {
+	out := new(CreateMazesReply)
+	err := c.cc.Invoke(ctx, "/proto.Mazer/CreateMazes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MazerServer is the server API for Mazer service.
 type MazerServer interface {
 	// Create maze
@@ -1494,6 +4199,64 @@ type MazerServer interface {
 	ResetClient(context.Context, *ResetClientRequest) (*ResetClientReply, error)
 	// Export a maze,
 	ExportMaze(context.Context, *ExportMazeRequest) (*ExportMazeReply, error)
+	// Render a snapshot of a maze as an image
+	RenderMaze(context.Context, *RenderMazeRequest) (*RenderMazeReply, error)
+	// Get the full structure of an existing maze
+	GetMaze(context.Context, *GetMazeRequest) (*GetMazeReply, error)
+	// Delete a maze, ends all of its clients
+	DeleteMaze(context.Context, *DeleteMazeRequest) (*DeleteMazeReply, error)
+	// Create a maze from an encoded maze sent by the client, e.g. one exported earlier
+	ImportMaze(context.Context, *ImportMazeRequest) (*ImportMazeReply, error)
+	// Watch a maze: generator steps, clients and their moves, until the maze is deleted
+	WatchMaze(*WatchMazeRequest, Mazer_WatchMazeServer) error
+	// Register a new client and solve the maze with it on the server, without a round trip per move
+	RunSolver(context.Context, *RunSolverRequest) (*RunSolverReply, error)
+	// Create many mazes at once, generated in parallel
+	CreateMazes(context.Context, *CreateMazesRequest) (*CreateMazesReply, error)
+}
+
+// UnimplementedMazerServer can be embedded to have forward compatible implementations.
+type UnimplementedMazerServer struct {
+}
+
+func (*UnimplementedMazerServer) CreateMaze(context.Context, *CreateMazeRequest) (*CreateMazeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMaze not implemented")
+}
+func (*UnimplementedMazerServer) ListMazes(context.Context, *ListMazeRequest) (*ListMazeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMazes not implemented")
+}
+func (*UnimplementedMazerServer) SolveMaze(Mazer_SolveMazeServer) error {
+	return status.Errorf(codes.Unimplemented, "method SolveMaze not implemented")
+}
+func (*UnimplementedMazerServer) RegisterClient(context.Context, *RegisterClientRequest) (*RegisterClientReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterClient not implemented")
+}
+func (*UnimplementedMazerServer) ResetClient(context.Context, *ResetClientRequest) (*ResetClientReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetClient not implemented")
+}
+func (*UnimplementedMazerServer) ExportMaze(context.Context, *ExportMazeRequest) (*ExportMazeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMaze not implemented")
+}
+func (*UnimplementedMazerServer) RenderMaze(context.Context, *RenderMazeRequest) (*RenderMazeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderMaze not implemented")
+}
+func (*UnimplementedMazerServer) GetMaze(context.Context, *GetMazeRequest) (*GetMazeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMaze not implemented")
+}
+func (*UnimplementedMazerServer) DeleteMaze(context.Context, *DeleteMazeRequest) (*DeleteMazeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMaze not implemented")
+}
+func (*UnimplementedMazerServer) ImportMaze(context.Context, *ImportMazeRequest) (*ImportMazeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportMaze not implemented")
+}
+func (*UnimplementedMazerServer) WatchMaze(*WatchMazeRequest, Mazer_WatchMazeServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMaze not implemented")
+}
+func (*UnimplementedMazerServer) RunSolver(context.Context, *RunSolverRequest) (*RunSolverReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunSolver not implemented")
+}
+func (*UnimplementedMazerServer) CreateMazes(context.Context, *CreateMazesRequest) (*CreateMazesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMazes not implemented")
 }
 
 func RegisterMazerServer(s *grpc.Server, srv MazerServer) {
@@ -1616,6 +4379,135 @@ func _Mazer_ExportMaze_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Mazer_RenderMaze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderMazeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MazerServer).RenderMaze(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Mazer/RenderMaze",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MazerServer).RenderMaze(ctx, req.(*RenderMazeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mazer_GetMaze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMazeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MazerServer).GetMaze(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Mazer/GetMaze",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MazerServer).GetMaze(ctx, req.(*GetMazeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mazer_DeleteMaze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMazeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MazerServer).DeleteMaze(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Mazer/DeleteMaze",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MazerServer).DeleteMaze(ctx, req.(*DeleteMazeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mazer_ImportMaze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportMazeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MazerServer).ImportMaze(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Mazer/ImportMaze",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MazerServer).ImportMaze(ctx, req.(*ImportMazeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mazer_WatchMaze_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMazeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MazerServer).WatchMaze(m, &mazerWatchMazeServer{stream})
+}
+
+type Mazer_WatchMazeServer interface {
+	Send(*MazeEvent) error
+	grpc.ServerStream
+}
+
+type mazerWatchMazeServer struct {
+	grpc.ServerStream
+}
+
+func (x *mazerWatchMazeServer) Send(m *MazeEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Mazer_RunSolver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunSolverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MazerServer).RunSolver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Mazer/RunSolver",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MazerServer).RunSolver(ctx, req.(*RunSolverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mazer_CreateMazes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMazesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MazerServer).CreateMazes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Mazer/CreateMazes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MazerServer).CreateMazes(ctx, req.(*CreateMazesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Mazer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.Mazer",
 	HandlerType: (*MazerServer)(nil),
@@ -1640,6 +4532,30 @@ var _Mazer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ExportMaze",
 			Handler:    _Mazer_ExportMaze_Handler,
 		},
+		{
+			MethodName: "RenderMaze",
+			Handler:    _Mazer_RenderMaze_Handler,
+		},
+		{
+			MethodName: "GetMaze",
+			Handler:    _Mazer_GetMaze_Handler,
+		},
+		{
+			MethodName: "DeleteMaze",
+			Handler:    _Mazer_DeleteMaze_Handler,
+		},
+		{
+			MethodName: "ImportMaze",
+			Handler:    _Mazer_ImportMaze_Handler,
+		},
+		{
+			MethodName: "RunSolver",
+			Handler:    _Mazer_RunSolver_Handler,
+		},
+		{
+			MethodName: "CreateMazes",
+			Handler:    _Mazer_CreateMazes_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1648,6 +4564,11 @@ var _Mazer_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "WatchMaze",
+			Handler:       _Mazer_WatchMaze_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "mazes.proto",
 }
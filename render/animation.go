@@ -0,0 +1,196 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"time"
+)
+
+const (
+	// AnimationGIF encodes the frames as an animated GIF
+	AnimationGIF = "gif"
+	// AnimationAPNG encodes the frames as an animated PNG
+	AnimationAPNG = "apng"
+)
+
+// Animation collects frames and encodes them as one animated image that loops forever
+type Animation interface {
+	// AddFrame appends a copy of img, all frames must be the same size
+	AddFrame(img image.Image) error
+	// Frames returns the number of frames added so far
+	Frames() int
+	// Encode writes the animation to w
+	Encode(w io.Writer) error
+}
+
+// NewAnimation returns an empty animation in format that shows each frame for delay
+func NewAnimation(format string, delay time.Duration) (Animation, error) {
+	switch format {
+	case AnimationGIF:
+		return &gifAnimation{delay: delay}, nil
+	case AnimationAPNG:
+		return &apngAnimation{delay: delay}, nil
+	}
+	return nil, fmt.Errorf("invalid animation format: %v", format)
+}
+
+// gifAnimation keeps the frames as paletted images, one byte per pixel
+type gifAnimation struct {
+	delay  time.Duration
+	frames []*image.Paletted
+}
+
+// AddFrame implements Animation, colors are mapped to the closest color in the Plan 9 palette
+func (a *gifAnimation) AddFrame(img image.Image) error {
+	if len(a.frames) > 0 && img.Bounds() != a.frames[0].Bounds() {
+		return fmt.Errorf("frame is %v, expected %v", img.Bounds(), a.frames[0].Bounds())
+	}
+	p := image.NewPaletted(img.Bounds(), palette.Plan9)
+	draw.Draw(p, p.Bounds(), img, img.Bounds().Min, draw.Src)
+	a.frames = append(a.frames, p)
+	return nil
+}
+
+// Frames implements Animation
+func (a *gifAnimation) Frames() int {
+	return len(a.frames)
+}
+
+// Encode implements Animation
+func (a *gifAnimation) Encode(w io.Writer) error {
+	if len(a.frames) == 0 {
+		return errors.New("animation has no frames")
+	}
+
+	g := &gif.GIF{}
+	for _, f := range a.frames {
+		g.Image = append(g.Image, f)
+		g.Delay = append(g.Delay, int(a.delay/(10*time.Millisecond))) // 100ths of a second
+	}
+	return gif.EncodeAll(w, g)
+}
+
+// apngAnimation keeps every frame as compressed PNG image data
+// See https://wiki.mozilla.org/APNG_Specification for the format.
+type apngAnimation struct {
+	delay  time.Duration
+	header []byte     // IHDR chunk data of the first frame, all frames must match
+	frames [][][]byte // IDAT chunk data of every frame
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// AddFrame implements Animation
+func (a *apngAnimation) AddFrame(img image.Image) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+
+	data := buf.Bytes()[len(pngSignature):]
+	var header []byte
+	var idat [][]byte
+	for len(data) >= 12 {
+		length := binary.BigEndian.Uint32(data[:4])
+		typ, body := string(data[4:8]), data[8:8+length]
+		switch typ {
+		case "IHDR":
+			header = body
+		case "IDAT":
+			idat = append(idat, body)
+		}
+		data = data[12+length:]
+	}
+
+	if a.header == nil {
+		a.header = header
+	} else if !bytes.Equal(a.header, header) {
+		return errors.New("frame size or color type differs from the first frame")
+	}
+	a.frames = append(a.frames, idat)
+	return nil
+}
+
+// Frames implements Animation
+func (a *apngAnimation) Frames() int {
+	return len(a.frames)
+}
+
+// writeChunk writes one PNG chunk
+func writeChunk(w io.Writer, typ string, data []byte) error {
+	var b bytes.Buffer
+	binary.Write(&b, binary.BigEndian, uint32(len(data)))
+	b.WriteString(typ)
+	b.Write(data)
+	binary.Write(&b, binary.BigEndian, crc32.ChecksumIEEE(b.Bytes()[4:]))
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// Encode implements Animation
+// The first frame is stored as the regular image, viewers without APNG support show only that one.
+func (a *apngAnimation) Encode(w io.Writer) error {
+	if len(a.frames) == 0 {
+		return errors.New("animation has no frames")
+	}
+
+	delay := a.delay.Milliseconds()
+	if delay > 0xffff {
+		delay = 0xffff
+	}
+
+	if _, err := w.Write(pngSignature); err != nil {
+		return err
+	}
+	if err := writeChunk(w, "IHDR", a.header); err != nil {
+		return err
+	}
+
+	actl := make([]byte, 8)
+	binary.BigEndian.PutUint32(actl[0:], uint32(len(a.frames)))
+	binary.BigEndian.PutUint32(actl[4:], 0) // loop forever
+	if err := writeChunk(w, "acTL", actl); err != nil {
+		return err
+	}
+
+	var seq uint32
+	for i, idat := range a.frames {
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl[0:], seq)
+		copy(fctl[4:12], a.header[0:8]) // width and height
+		binary.BigEndian.PutUint16(fctl[20:], uint16(delay))
+		binary.BigEndian.PutUint16(fctl[22:], 1000)
+		// x and y offset, dispose and blend ops are all 0
+		if err := writeChunk(w, "fcTL", fctl); err != nil {
+			return err
+		}
+		seq++
+
+		for _, data := range idat {
+			if i == 0 {
+				if err := writeChunk(w, "IDAT", data); err != nil {
+					return err
+				}
+				continue
+			}
+
+			fdat := make([]byte, 4+len(data))
+			binary.BigEndian.PutUint32(fdat, seq)
+			copy(fdat[4:], data)
+			if err := writeChunk(w, "fdAT", fdat); err != nil {
+				return err
+			}
+			seq++
+		}
+	}
+	return writeChunk(w, "IEND", nil)
+}
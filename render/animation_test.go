@@ -0,0 +1,109 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/DanTulovsky/mazes/colors"
+)
+
+// frames returns n 10x10 white images with a red pixel moving along the diagonal
+func frames(n int) []image.Image {
+	var r []image.Image
+	for i := 0; i < n; i++ {
+		img := newWhiteImage(10, 10)
+		img.SetDrawColor(colors.GetColor("red"))
+		img.FillRect(&Rect{X: int32(i), Y: int32(i), W: 1, H: 1})
+		r = append(r, img.RGBA())
+	}
+	return r
+}
+
+func TestGIFAnimation(t *testing.T) {
+	a, err := NewAnimation(AnimationGIF, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("error creating animation: %v", err)
+	}
+	for _, f := range frames(3) {
+		if err := a.AddFrame(f); err != nil {
+			t.Fatalf("error adding frame: %v", err)
+		}
+	}
+	if err := a.AddFrame(image.NewRGBA(image.Rect(0, 0, 5, 5))); err == nil {
+		t.Errorf("expected error adding a frame of a different size")
+	}
+
+	var buf bytes.Buffer
+	if err := a.Encode(&buf); err != nil {
+		t.Fatalf("error encoding: %v", err)
+	}
+	g, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("invalid gif: %v", err)
+	}
+	if len(g.Image) != 3 {
+		t.Errorf("expected 3 frames, but have %v", len(g.Image))
+	}
+	if g.Delay[0] != 5 {
+		t.Errorf("expected a delay of 5, but have %v", g.Delay[0])
+	}
+	if r, g, b, _ := g.Image[2].At(2, 2).RGBA(); r>>8 != 255 || g != 0 || b != 0 {
+		t.Errorf("expected red pixel at (2, 2) in the last frame")
+	}
+}
+
+func TestAPNGAnimation(t *testing.T) {
+	a, err := NewAnimation(AnimationAPNG, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("error creating animation: %v", err)
+	}
+	for _, f := range frames(3) {
+		if err := a.AddFrame(f); err != nil {
+			t.Fatalf("error adding frame: %v", err)
+		}
+	}
+	if a.Frames() != 3 {
+		t.Errorf("expected 3 frames, but have %v", a.Frames())
+	}
+
+	var buf bytes.Buffer
+	if err := a.Encode(&buf); err != nil {
+		t.Fatalf("error encoding: %v", err)
+	}
+	data := buf.Bytes()
+
+	// decoders without APNG support see the first frame, which also checks all the CRCs
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("invalid png: %v", err)
+	}
+	if r, g, b, _ := img.At(0, 0).RGBA(); r>>8 != 255 || g != 0 || b != 0 {
+		t.Errorf("expected red pixel at (0, 0) in the first frame")
+	}
+
+	if n := bytes.Count(data, []byte("acTL")); n != 1 {
+		t.Errorf("expected one acTL chunk, but have %v", n)
+	}
+	if n := bytes.Count(data, []byte("fcTL")); n != 3 {
+		t.Errorf("expected 3 fcTL chunks, but have %v", n)
+	}
+	if n := bytes.Count(data, []byte("fdAT")); n != 2 {
+		t.Errorf("expected 2 fdAT chunks, but have %v", n)
+	}
+}
+
+func TestInvalidAnimation(t *testing.T) {
+	if _, err := NewAnimation("mp4", time.Second); err == nil {
+		t.Errorf("expected error creating an animation in an unknown format")
+	}
+
+	a, _ := NewAnimation(AnimationGIF, time.Second)
+	var buf bytes.Buffer
+	if err := a.Encode(&buf); err == nil {
+		t.Errorf("expected error encoding an animation without frames")
+	}
+}
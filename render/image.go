@@ -0,0 +1,309 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"sort"
+
+	"github.com/DanTulovsky/mazes/colors"
+)
+
+// Image is a Renderer that draws on an image.RGBA, it does not need a display or SDL
+type Image struct {
+	screen *image.RGBA
+	target *image.RGBA
+	color  color.NRGBA
+}
+
+// imageTexture is a texture created by Image
+type imageTexture struct {
+	img *image.RGBA
+}
+
+// Destroy implements Texture, the memory is reclaimed by the garbage collector
+func (t *imageTexture) Destroy() error {
+	return nil
+}
+
+// NewImage returns a renderer that draws on a w by h image
+func NewImage(w, h int) *Image {
+	screen := image.NewRGBA(image.Rect(0, 0, w, h))
+	return &Image{
+		screen: screen,
+		target: screen,
+		color:  color.NRGBA{A: 255},
+	}
+}
+
+// RGBA returns the image drawn so far
+func (i *Image) RGBA() *image.RGBA {
+	return i.screen
+}
+
+// toNRGBA converts c to a color the image package understands, SDL colors are not alpha-premultiplied
+func toNRGBA(c colors.Color) color.NRGBA {
+	return color.NRGBA{R: c.R, G: c.G, B: c.B, A: c.A}
+}
+
+// fill blends c over the rectangle r of the current target
+func (i *Image) fill(r image.Rectangle, c color.Color) {
+	draw.Draw(i.target, r, &image.Uniform{C: c}, image.Point{}, draw.Over)
+}
+
+// fillPolygon fills the polygon with vertices at xs, ys; vertices are at the center of the pixels
+// A pixel is filled if its center is inside the polygon or on its edge.
+func (i *Image) fillPolygon(xs, ys []float64, c color.Color) {
+	if len(xs) < 3 || len(xs) != len(ys) {
+		return
+	}
+
+	minY, maxY := ys[0], ys[0]
+	for _, y := range ys {
+		minY = math.Min(minY, y)
+		maxY = math.Max(maxY, y)
+	}
+
+	for y := int(math.Ceil(minY)); y <= int(math.Floor(maxY)); y++ {
+		// scan just above the bottom vertices so the last row is filled as well
+		yc := math.Min(float64(y), maxY-1e-6)
+
+		var nodes []float64
+		for j := range xs {
+			k := (j + 1) % len(xs)
+			x1, y1, x2, y2 := xs[j], ys[j], xs[k], ys[k]
+			if (y1 <= yc && yc < y2) || (y2 <= yc && yc < y1) {
+				nodes = append(nodes, x1+(yc-y1)*(x2-x1)/(y2-y1))
+			}
+		}
+		sort.Float64s(nodes)
+
+		for n := 0; n+1 < len(nodes); n += 2 {
+			x1, x2 := int(math.Ceil(nodes[n]-0.5)), int(math.Floor(nodes[n+1]+0.5))
+			i.fill(image.Rect(x1, y, x2+1, y+1), c)
+		}
+	}
+}
+
+// SetDrawColor implements Renderer
+func (i *Image) SetDrawColor(c colors.Color) error {
+	i.color = toNRGBA(c)
+	return nil
+}
+
+// Clear implements Renderer
+func (i *Image) Clear() error {
+	draw.Draw(i.target, i.target.Bounds(), &image.Uniform{C: i.color}, image.Point{}, draw.Src)
+	return nil
+}
+
+// FillRect implements Renderer
+func (i *Image) FillRect(rect *Rect) error {
+	if rect == nil {
+		i.fill(i.target.Bounds(), i.color)
+		return nil
+	}
+	i.fill(image.Rect(int(rect.X), int(rect.Y), int(rect.X+rect.W), int(rect.Y+rect.H)), i.color)
+	return nil
+}
+
+// FillRects implements Renderer
+func (i *Image) FillRects(rects []Rect) error {
+	for n := range rects {
+		i.FillRect(&rects[n])
+	}
+	return nil
+}
+
+// ThickLine implements Renderer
+func (i *Image) ThickLine(x1, y1, x2, y2, width int32, c colors.Color) error {
+	if width < 1 {
+		width = 1
+	}
+	half := float64(width) / 2
+	fx1, fy1, fx2, fy2 := float64(x1), float64(y1), float64(x2), float64(y2)
+
+	length := math.Hypot(fx2-fx1, fy2-fy1)
+	if length == 0 {
+		i.fill(image.Rect(int(x1)-int(width)/2, int(y1)-int(width)/2, int(x1)-int(width)/2+int(width), int(y1)-int(width)/2+int(width)), toNRGBA(c))
+		return nil
+	}
+
+	// offset perpendicular to the line; the ends are square, like SDL_gfx
+	nx, ny := -(fy2-fy1)/length*half, (fx2-fx1)/length*half
+	xs := []float64{fx1 + nx, fx2 + nx, fx2 - nx, fx1 - nx}
+	ys := []float64{fy1 + ny, fy2 + ny, fy2 - ny, fy1 - ny}
+	i.fillPolygon(xs, ys, toNRGBA(c))
+	return nil
+}
+
+// FilledPolygon implements Renderer
+func (i *Image) FilledPolygon(vx, vy []int16, c colors.Color) error {
+	if len(vx) != len(vy) {
+		return fmt.Errorf("polygon has %v x and %v y coordinates", len(vx), len(vy))
+	}
+	xs, ys := make([]float64, len(vx)), make([]float64, len(vy))
+	for n := range vx {
+		xs[n], ys[n] = float64(vx[n]), float64(vy[n])
+	}
+	i.fillPolygon(xs, ys, toNRGBA(c))
+	return nil
+}
+
+// FilledTrigon implements Renderer
+func (i *Image) FilledTrigon(x1, y1, x2, y2, x3, y3 int32, c colors.Color) error {
+	xs := []float64{float64(x1), float64(x2), float64(x3)}
+	ys := []float64{float64(y1), float64(y2), float64(y3)}
+	i.fillPolygon(xs, ys, toNRGBA(c))
+	return nil
+}
+
+// FilledCircle implements Renderer
+func (i *Image) FilledCircle(x, y, rad int32, c colors.Color) error {
+	clr := toNRGBA(c)
+	for dy := -rad; dy <= rad; dy++ {
+		dx := int32(math.Sqrt(float64(rad*rad - dy*dy)))
+		i.fill(image.Rect(int(x-dx), int(y+dy), int(x+dx+1), int(y+dy+1)), clr)
+	}
+	return nil
+}
+
+// String implements Renderer
+// Only digits, '-' and '.' are drawn, other characters are left blank; enough for distances, weights and counts.
+func (i *Image) String(x, y int32, s string, c colors.Color) error {
+	clr := toNRGBA(c)
+	for n, ch := range []rune(s) {
+		glyph, ok := font[ch]
+		if !ok {
+			continue
+		}
+		left := int(x) + n*glyphAdvance
+		for row, bits := range glyph {
+			for col := 0; col < glyphWidth; col++ {
+				if bits&(1<<uint(glyphWidth-1-col)) != 0 {
+					px := left + col
+					i.fill(image.Rect(px, int(y)+row, px+1, int(y)+row+1), clr)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// NewTexture implements Renderer
+func (i *Image) NewTexture(w, h int32) (Texture, error) {
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("invalid texture size: %vx%v", w, h)
+	}
+	return &imageTexture{img: image.NewRGBA(image.Rect(0, 0, int(w), int(h)))}, nil
+}
+
+// texture returns the image behind t, t must have been created by an Image
+func texture(t Texture) (*image.RGBA, error) {
+	it, ok := t.(*imageTexture)
+	if !ok || it == nil {
+		return nil, errors.New("texture was not created by an image renderer")
+	}
+	return it.img, nil
+}
+
+// SetTarget implements Renderer
+func (i *Image) SetTarget(t Texture) error {
+	if t == nil {
+		i.target = i.screen
+		return nil
+	}
+	img, err := texture(t)
+	if err != nil {
+		return err
+	}
+	i.target = img
+	return nil
+}
+
+// Copy implements Renderer
+func (i *Image) Copy(t Texture, dst *Rect) error {
+	return i.CopyEx(t, dst, 0, FlipNone)
+}
+
+// CopyEx implements Renderer
+// Nothing is drawn outside dst, so only rotations by multiples of 90 degrees of square textures keep all the pixels.
+func (i *Image) CopyEx(t Texture, dst *Rect, angle float64, flip Flip) error {
+	src, err := texture(t)
+	if err != nil {
+		return err
+	}
+
+	d := i.target.Bounds()
+	if dst != nil {
+		d = image.Rect(int(dst.X), int(dst.Y), int(dst.X+dst.W), int(dst.Y+dst.H))
+	}
+	if d.Empty() {
+		return nil
+	}
+
+	if angle == 0 && flip == FlipNone && d.Size() == src.Bounds().Size() {
+		draw.Draw(i.target, d, src, image.Point{}, draw.Over)
+		return nil
+	}
+
+	// map every destination pixel back onto the source
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := d.Dx(), d.Dy()
+	cx, cy := float64(dw)/2, float64(dh)/2
+	sin, cos := math.Sincos(-angle * math.Pi / 180)
+
+	scaled := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for py := 0; py < dh; py++ {
+		for px := 0; px < dw; px++ {
+			fx, fy := float64(px)+0.5-cx, float64(py)+0.5-cy
+			ux, uy := fx*cos-fy*sin+cx, fx*sin+fy*cos+cy
+
+			sx, sy := int(ux*float64(sw)/float64(dw)), int(uy*float64(sh)/float64(dh))
+			if ux < 0 || uy < 0 || sx >= sw || sy >= sh {
+				continue
+			}
+			switch flip {
+			case FlipHorizontal:
+				sx = sw - 1 - sx
+			case FlipVertical:
+				sy = sh - 1 - sy
+			}
+			scaled.SetRGBA(px, py, src.RGBAAt(sx, sy))
+		}
+	}
+	draw.Draw(i.target, d, scaled, image.Point{}, draw.Over)
+	return nil
+}
+
+// Present implements Renderer, the image is always up to date
+func (i *Image) Present() {}
+
+// Do implements Renderer, an image can be drawn on from any goroutine
+func (i *Image) Do(f func()) {
+	f()
+}
+
+const (
+	glyphWidth   = 5
+	glyphAdvance = 8 // same as the SDL_gfx default 8x8 font
+)
+
+// font is a minimal 5x7 bitmap font, one byte per row with the leftmost pixel in bit 4
+var font = map[rune][7]uint8{
+	'0': {0b01110, 0b10001, 0b10011, 0b10101, 0b11001, 0b10001, 0b01110},
+	'1': {0b00100, 0b01100, 0b00100, 0b00100, 0b00100, 0b00100, 0b01110},
+	'2': {0b01110, 0b10001, 0b00001, 0b00010, 0b00100, 0b01000, 0b11111},
+	'3': {0b11111, 0b00010, 0b00100, 0b00010, 0b00001, 0b10001, 0b01110},
+	'4': {0b00010, 0b00110, 0b01010, 0b10010, 0b11111, 0b00010, 0b00010},
+	'5': {0b11111, 0b10000, 0b11110, 0b00001, 0b00001, 0b10001, 0b01110},
+	'6': {0b00110, 0b01000, 0b10000, 0b11110, 0b10001, 0b10001, 0b01110},
+	'7': {0b11111, 0b00001, 0b00010, 0b00100, 0b01000, 0b01000, 0b01000},
+	'8': {0b01110, 0b10001, 0b10001, 0b01110, 0b10001, 0b10001, 0b01110},
+	'9': {0b01110, 0b10001, 0b10001, 0b01111, 0b00001, 0b00010, 0b01100},
+	'-': {0, 0, 0, 0b11111, 0, 0, 0},
+	'.': {0, 0, 0, 0, 0, 0b01100, 0b01100},
+}
@@ -0,0 +1,197 @@
+package render
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/DanTulovsky/mazes/colors"
+)
+
+var (
+	white = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	black = color.RGBA{A: 255}
+	red   = color.RGBA{R: 255, A: 255}
+)
+
+func newWhiteImage(w, h int) *Image {
+	i := NewImage(w, h)
+	i.SetDrawColor(colors.GetColor("white"))
+	i.Clear()
+	return i
+}
+
+var filltests = []struct {
+	name string
+	draw func(i *Image)
+	in   [][2]int // pixels that must be red
+	out  [][2]int // pixels that must still be white
+}{
+	{
+		name: "rect",
+		draw: func(i *Image) {
+			i.SetDrawColor(colors.GetColor("red"))
+			i.FillRect(&Rect{X: 2, Y: 3, W: 4, H: 5})
+		},
+		in:  [][2]int{{2, 3}, {5, 7}},
+		out: [][2]int{{1, 3}, {6, 7}, {2, 8}},
+	},
+	{
+		name: "rects",
+		draw: func(i *Image) {
+			i.SetDrawColor(colors.GetColor("red"))
+			i.FillRects([]Rect{{X: 0, Y: 0, W: 2, H: 2}, {X: 8, Y: 8, W: 2, H: 2}})
+		},
+		in:  [][2]int{{0, 0}, {9, 9}},
+		out: [][2]int{{5, 5}},
+	},
+	{
+		name: "thick line",
+		draw: func(i *Image) {
+			i.ThickLine(1, 5, 8, 5, 3, colors.GetColor("red"))
+		},
+		in:  [][2]int{{1, 4}, {5, 5}, {8, 6}},
+		out: [][2]int{{5, 2}, {5, 8}, {0, 5}},
+	},
+	{
+		name: "polygon",
+		draw: func(i *Image) {
+			i.FilledPolygon([]int16{2, 7, 7, 2}, []int16{2, 2, 7, 7}, colors.GetColor("red"))
+		},
+		in:  [][2]int{{2, 2}, {7, 7}, {4, 5}},
+		out: [][2]int{{1, 1}, {8, 8}},
+	},
+	{
+		name: "trigon",
+		draw: func(i *Image) {
+			i.FilledTrigon(0, 9, 9, 9, 0, 0, colors.GetColor("red"))
+		},
+		in:  [][2]int{{0, 0}, {1, 8}, {9, 9}},
+		out: [][2]int{{9, 0}, {8, 2}},
+	},
+	{
+		name: "circle",
+		draw: func(i *Image) {
+			i.FilledCircle(5, 5, 3, colors.GetColor("red"))
+		},
+		in:  [][2]int{{5, 5}, {5, 2}, {8, 5}},
+		out: [][2]int{{2, 2}, {8, 8}, {5, 9}},
+	},
+}
+
+func TestFill(t *testing.T) {
+	for _, tt := range filltests {
+		i := newWhiteImage(10, 10)
+		tt.draw(i)
+
+		for _, p := range tt.in {
+			if got := i.RGBA().RGBAAt(p[0], p[1]); got != red {
+				t.Errorf("%v: pixel %v is %v, expected %v", tt.name, p, got, red)
+			}
+		}
+		for _, p := range tt.out {
+			if got := i.RGBA().RGBAAt(p[0], p[1]); got != white {
+				t.Errorf("%v: pixel %v is %v, expected %v", tt.name, p, got, white)
+			}
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	i := newWhiteImage(40, 10)
+	i.String(0, 0, "1 2", colors.GetColor("black"))
+
+	count := func(left, right int) (n int) {
+		for x := left; x < right; x++ {
+			for y := 0; y < 10; y++ {
+				if i.RGBA().RGBAAt(x, y) == black {
+					n++
+				}
+			}
+		}
+		return n
+	}
+
+	if count(0, glyphAdvance) == 0 {
+		t.Errorf("first character not drawn")
+	}
+	if count(glyphAdvance, 2*glyphAdvance) != 0 {
+		t.Errorf("space should not be drawn")
+	}
+	if count(2*glyphAdvance, 3*glyphAdvance) == 0 {
+		t.Errorf("third character not drawn")
+	}
+}
+
+func TestTexture(t *testing.T) {
+	i := newWhiteImage(10, 10)
+
+	tex, err := i.NewTexture(10, 10)
+	if err != nil {
+		t.Fatalf("error creating texture: %v", err)
+	}
+	if err := i.SetTarget(tex); err != nil {
+		t.Fatalf("error setting target: %v", err)
+	}
+	i.SetDrawColor(colors.GetColor("red"))
+	i.Clear()
+
+	if got := i.RGBA().RGBAAt(5, 5); got != white {
+		t.Errorf("drawing on a texture changed the screen: %v", got)
+	}
+
+	if err := i.SetTarget(nil); err != nil {
+		t.Fatalf("error resetting target: %v", err)
+	}
+	if err := i.Copy(tex, nil); err != nil {
+		t.Fatalf("error copying texture: %v", err)
+	}
+	if got := i.RGBA().RGBAAt(5, 5); got != red {
+		t.Errorf("texture not copied to the screen, pixel is %v", got)
+	}
+
+	if _, err := i.NewTexture(0, 10); err == nil {
+		t.Errorf("expected error creating empty texture")
+	}
+}
+
+func TestCopyEx(t *testing.T) {
+	i := newWhiteImage(4, 4)
+
+	// texture with only the top row red
+	tex, _ := i.NewTexture(4, 4)
+	i.SetTarget(tex)
+	i.SetDrawColor(colors.GetColor("white"))
+	i.Clear()
+	i.SetDrawColor(colors.GetColor("red"))
+	i.FillRect(&Rect{X: 0, Y: 0, W: 4, H: 1})
+	i.SetTarget(nil)
+
+	// flipped vertically the red row is at the bottom
+	i.CopyEx(tex, &Rect{X: 0, Y: 0, W: 4, H: 4}, 0, FlipVertical)
+	if got := i.RGBA().RGBAAt(1, 3); got != red {
+		t.Errorf("flipped: pixel (1, 3) is %v, expected %v", got, red)
+	}
+	if got := i.RGBA().RGBAAt(1, 0); got != white {
+		t.Errorf("flipped: pixel (1, 0) is %v, expected %v", got, white)
+	}
+
+	// rotated clockwise by 90 degrees the red row is on the right
+	i.CopyEx(tex, nil, 90, FlipNone)
+	if got := i.RGBA().RGBAAt(3, 1); got != red {
+		t.Errorf("rotated: pixel (3, 1) is %v, expected %v", got, red)
+	}
+	if got := i.RGBA().RGBAAt(0, 1); got != white {
+		t.Errorf("rotated: pixel (0, 1) is %v, expected %v", got, white)
+	}
+}
+
+func TestForeignTexture(t *testing.T) {
+	i := NewImage(10, 10)
+	if err := i.SetTarget(&foreignTexture{}); err == nil {
+		t.Errorf("expected error using a texture from another renderer")
+	}
+}
+
+type foreignTexture struct{}
+
+func (f *foreignTexture) Destroy() error { return nil }
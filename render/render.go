@@ -0,0 +1,68 @@
+// Package render defines the drawing interface the maze draws itself on.
+// The SDL implementation lives in the sdl package. Image (an image.RGBA) and SVG are pure Go implementations that
+// do not need a display.
+package render
+
+import (
+	"github.com/DanTulovsky/mazes/colors"
+)
+
+// Rect is a rectangle with the top left corner at X, Y
+type Rect struct {
+	X, Y, W, H int32
+}
+
+// Flip is the way a texture is flipped when copied with CopyEx
+type Flip int
+
+const (
+	// FlipNone does not flip the texture
+	FlipNone Flip = iota
+	// FlipHorizontal flips the texture left to right
+	FlipHorizontal
+	// FlipVertical flips the texture top to bottom
+	FlipVertical
+)
+
+// Texture is an off-screen surface created by Renderer.NewTexture
+// A texture can only be used with the renderer that created it.
+type Texture interface {
+	Destroy() error
+}
+
+// Renderer draws primitives on the current target, either the screen or a texture
+type Renderer interface {
+	// SetDrawColor sets the color used by Clear, FillRect and FillRects
+	SetDrawColor(c colors.Color) error
+	// Clear fills the whole target with the draw color
+	Clear() error
+	// FillRect fills the rectangle with the draw color
+	FillRect(rect *Rect) error
+	// FillRects fills all the rectangles with the draw color
+	FillRects(rects []Rect) error
+
+	// ThickLine draws a line width pixels wide from x1, y1 to x2, y2
+	ThickLine(x1, y1, x2, y2, width int32, c colors.Color) error
+	// FilledPolygon fills the polygon with vertices at vx, vy
+	FilledPolygon(vx, vy []int16, c colors.Color) error
+	// FilledTrigon fills the triangle with vertices at (x1, y1), (x2, y2) and (x3, y3)
+	FilledTrigon(x1, y1, x2, y2, x3, y3 int32, c colors.Color) error
+	// FilledCircle fills the circle of radius rad centered on x, y
+	FilledCircle(x, y, rad int32, c colors.Color) error
+	// String draws s with its top left corner at x, y
+	String(x, y int32, s string, c colors.Color) error
+
+	// NewTexture returns a new texture w by h pixels
+	NewTexture(w, h int32) (Texture, error)
+	// SetTarget makes all drawing go to t, nil is the screen
+	SetTarget(t Texture) error
+	// Copy copies the texture onto dst in the current target, a nil dst is the whole target
+	Copy(t Texture, dst *Rect) error
+	// CopyEx copies the texture onto dst, rotated clockwise by angle degrees around its center and flipped
+	CopyEx(t Texture, dst *Rect, angle float64, flip Flip) error
+	// Present shows everything drawn on the screen since the last call
+	Present()
+
+	// Do runs f on the thread that is allowed to draw
+	Do(f func())
+}
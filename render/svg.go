@@ -0,0 +1,172 @@
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/DanTulovsky/mazes/colors"
+)
+
+// SVG is a Renderer that records everything drawn as SVG elements
+// Textures are not supported, draw directly on the screen instead.
+type SVG struct {
+	w, h  int
+	color colors.Color
+	body  bytes.Buffer
+}
+
+// NewSVG returns a renderer that draws a w by h SVG image
+func NewSVG(w, h int) *SVG {
+	return &SVG{
+		w:     w,
+		h:     h,
+		color: colors.GetColor("black"),
+	}
+}
+
+// Encode writes the SVG document to w
+func (s *SVG) Encode(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n",
+		s.w, s.h, s.w, s.h); err != nil {
+		return err
+	}
+	if _, err := w.Write(s.body.Bytes()); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "</svg>\n")
+	return err
+}
+
+// fill returns the fill attributes for c
+func fill(c colors.Color) string {
+	a := fmt.Sprintf(`fill="#%02x%02x%02x"`, c.R, c.G, c.B)
+	if c.A != 255 {
+		a += fmt.Sprintf(` fill-opacity="%.3f"`, float64(c.A)/255)
+	}
+	return a
+}
+
+// points returns the points attribute of a polygon
+func points(xs, ys []int32) string {
+	p := make([]string, len(xs))
+	for i := range xs {
+		p[i] = fmt.Sprintf("%d,%d", xs[i], ys[i])
+	}
+	return strings.Join(p, " ")
+}
+
+// SetDrawColor implements Renderer
+func (s *SVG) SetDrawColor(c colors.Color) error {
+	s.color = c
+	return nil
+}
+
+// Clear implements Renderer, everything drawn so far is discarded
+func (s *SVG) Clear() error {
+	s.body.Reset()
+	return s.FillRect(&Rect{W: int32(s.w), H: int32(s.h)})
+}
+
+// FillRect implements Renderer
+func (s *SVG) FillRect(rect *Rect) error {
+	if rect == nil {
+		rect = &Rect{W: int32(s.w), H: int32(s.h)}
+	}
+	if rect.W <= 0 || rect.H <= 0 {
+		return nil
+	}
+	fmt.Fprintf(&s.body, `<rect x="%d" y="%d" width="%d" height="%d" %s/>`+"\n", rect.X, rect.Y, rect.W, rect.H, fill(s.color))
+	return nil
+}
+
+// FillRects implements Renderer
+func (s *SVG) FillRects(rects []Rect) error {
+	for i := range rects {
+		s.FillRect(&rects[i])
+	}
+	return nil
+}
+
+// ThickLine implements Renderer
+func (s *SVG) ThickLine(x1, y1, x2, y2, width int32, c colors.Color) error {
+	a := fmt.Sprintf(`stroke="#%02x%02x%02x"`, c.R, c.G, c.B)
+	if c.A != 255 {
+		a += fmt.Sprintf(` stroke-opacity="%.3f"`, float64(c.A)/255)
+	}
+	fmt.Fprintf(&s.body, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke-width="%d" stroke-linecap="square" %s/>`+"\n",
+		x1, y1, x2, y2, width, a)
+	return nil
+}
+
+// FilledPolygon implements Renderer
+func (s *SVG) FilledPolygon(vx, vy []int16, c colors.Color) error {
+	if len(vx) != len(vy) {
+		return fmt.Errorf("polygon has %v x and %v y coordinates", len(vx), len(vy))
+	}
+	xs, ys := make([]int32, len(vx)), make([]int32, len(vy))
+	for i := range vx {
+		xs[i], ys[i] = int32(vx[i]), int32(vy[i])
+	}
+	fmt.Fprintf(&s.body, `<polygon points="%s" %s/>`+"\n", points(xs, ys), fill(c))
+	return nil
+}
+
+// FilledTrigon implements Renderer
+func (s *SVG) FilledTrigon(x1, y1, x2, y2, x3, y3 int32, c colors.Color) error {
+	fmt.Fprintf(&s.body, `<polygon points="%s" %s/>`+"\n", points([]int32{x1, x2, x3}, []int32{y1, y2, y3}), fill(c))
+	return nil
+}
+
+// FilledCircle implements Renderer
+func (s *SVG) FilledCircle(x, y, rad int32, c colors.Color) error {
+	fmt.Fprintf(&s.body, `<circle cx="%d" cy="%d" r="%d" %s/>`+"\n", x, y, rad, fill(c))
+	return nil
+}
+
+// String implements Renderer
+func (s *SVG) String(x, y int32, str string, c colors.Color) error {
+	var text bytes.Buffer
+	if err := xml.EscapeText(&text, []byte(str)); err != nil {
+		return err
+	}
+	fmt.Fprintf(&s.body, `<text x="%d" y="%d" font-family="monospace" font-size="8" dominant-baseline="hanging" %s>%s</text>`+"\n",
+		x, y, fill(c), text.String())
+	return nil
+}
+
+var errNoTextures = errors.New("textures are not supported by the svg renderer")
+
+// NewTexture implements Renderer, textures are not supported
+func (s *SVG) NewTexture(w, h int32) (Texture, error) {
+	return nil, errNoTextures
+}
+
+// SetTarget implements Renderer, only the screen (nil) is supported
+func (s *SVG) SetTarget(t Texture) error {
+	if t != nil {
+		return errNoTextures
+	}
+	return nil
+}
+
+// Copy implements Renderer, textures are not supported
+func (s *SVG) Copy(t Texture, dst *Rect) error {
+	return errNoTextures
+}
+
+// CopyEx implements Renderer, textures are not supported
+func (s *SVG) CopyEx(t Texture, dst *Rect, angle float64, flip Flip) error {
+	return errNoTextures
+}
+
+// Present implements Renderer
+func (s *SVG) Present() {}
+
+// Do implements Renderer
+func (s *SVG) Do(f func()) {
+	f()
+}
@@ -0,0 +1,44 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/DanTulovsky/mazes/colors"
+)
+
+func TestSVG(t *testing.T) {
+	s := NewSVG(20, 10)
+	s.SetDrawColor(colors.GetColor("white"))
+	s.Clear()
+	s.SetDrawColor(colors.SetOpacity(colors.GetColor("red"), 51))
+	s.FillRect(&Rect{X: 1, Y: 2, W: 3, H: 4})
+	s.FilledTrigon(0, 0, 5, 0, 0, 5, colors.GetColor("blue"))
+	s.String(1, 1, "<3", colors.GetColor("black"))
+
+	var buf bytes.Buffer
+	if err := s.Encode(&buf); err != nil {
+		t.Fatalf("error encoding: %v", err)
+	}
+	svg := buf.String()
+
+	for _, want := range []string{
+		`width="20" height="10"`,
+		`<rect x="0" y="0" width="20" height="10" fill="#ffffff"/>`,
+		`<rect x="1" y="2" width="3" height="4" fill="#ff0000" fill-opacity="0.200"/>`,
+		`<polygon points="0,0 5,0 0,5" fill="#0000ff"/>`,
+		`>&lt;3</text>`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("expected %v in:\n%v", want, svg)
+		}
+	}
+	if !strings.HasSuffix(svg, "</svg>\n") {
+		t.Errorf("svg not closed:\n%v", svg)
+	}
+
+	if _, err := s.NewTexture(10, 10); err == nil {
+		t.Errorf("expected error creating a texture")
+	}
+}
@@ -0,0 +1,156 @@
+package sdl
+
+import (
+	"errors"
+	"log"
+
+	"github.com/DanTulovsky/mazes/colors"
+	"github.com/DanTulovsky/mazes/render"
+
+	"github.com/veandco/go-sdl2/gfx"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Renderer implements render.Renderer on top of an SDL renderer
+type Renderer struct {
+	r *sdl.Renderer
+}
+
+// NewRenderer returns a render.Renderer that draws with r, nil if r is nil (no gui)
+func NewRenderer(r *sdl.Renderer) render.Renderer {
+	if r == nil {
+		return nil
+	}
+	return &Renderer{r: r}
+}
+
+// SetDrawColor Sets the drawing color on the renderer
+func SetDrawColor(c colors.Color, r *sdl.Renderer) *sdl.Renderer {
+	if err := r.SetDrawColor(c.R, c.G, c.B, c.A); err != nil {
+		log.Printf("error setting color [%#v]: %v", c, err)
+	}
+	return r
+}
+
+// gfxError returns the SDL error if a gfx call failed
+func gfxError(ok bool) error {
+	if ok {
+		return nil
+	}
+	if err := sdl.GetError(); err != nil {
+		return err
+	}
+	return errors.New("gfx call failed")
+}
+
+// texture returns the SDL texture behind t
+func texture(t render.Texture) (*sdl.Texture, error) {
+	if t == nil {
+		return nil, nil
+	}
+	st, ok := t.(*sdl.Texture)
+	if !ok {
+		return nil, errors.New("texture was not created by an sdl renderer")
+	}
+	return st, nil
+}
+
+// SetDrawColor implements render.Renderer
+func (r *Renderer) SetDrawColor(c colors.Color) error {
+	return r.r.SetDrawColor(c.R, c.G, c.B, c.A)
+}
+
+// Clear implements render.Renderer
+func (r *Renderer) Clear() error {
+	return r.r.Clear()
+}
+
+// FillRect implements render.Renderer
+func (r *Renderer) FillRect(rect *render.Rect) error {
+	return r.r.FillRect((*sdl.Rect)(rect))
+}
+
+// FillRects implements render.Renderer
+func (r *Renderer) FillRects(rects []render.Rect) error {
+	sr := make([]sdl.Rect, len(rects))
+	for i := range rects {
+		sr[i] = sdl.Rect(rects[i])
+	}
+	return r.r.FillRects(sr)
+}
+
+// ThickLine implements render.Renderer
+func (r *Renderer) ThickLine(x1, y1, x2, y2, width int32, c colors.Color) error {
+	return gfxError(gfx.ThickLineRGBA(r.r, x1, y1, x2, y2, width, c.R, c.G, c.B, c.A))
+}
+
+// FilledPolygon implements render.Renderer
+func (r *Renderer) FilledPolygon(vx, vy []int16, c colors.Color) error {
+	return gfxError(gfx.FilledPolygonRGBA(r.r, vx, vy, c.R, c.G, c.B, c.A))
+}
+
+// FilledTrigon implements render.Renderer
+func (r *Renderer) FilledTrigon(x1, y1, x2, y2, x3, y3 int32, c colors.Color) error {
+	return gfxError(gfx.FilledTrigonRGBA(r.r, x1, y1, x2, y2, x3, y3, c.R, c.G, c.B, c.A))
+}
+
+// FilledCircle implements render.Renderer
+func (r *Renderer) FilledCircle(x, y, rad int32, c colors.Color) error {
+	return gfxError(gfx.FilledCircleRGBA(r.r, x, y, rad, c.R, c.G, c.B, c.A))
+}
+
+// String implements render.Renderer
+func (r *Renderer) String(x, y int32, s string, c colors.Color) error {
+	defer gfx.SetFont(nil, 0, 0)
+	return gfxError(gfx.StringRGBA(r.r, x, y, s, c.R, c.G, c.B, c.A))
+}
+
+// NewTexture implements render.Renderer
+func (r *Renderer) NewTexture(w, h int32) (render.Texture, error) {
+	return r.r.CreateTexture(sdl.PIXELFORMAT_RGB24, sdl.TEXTUREACCESS_TARGET, w, h)
+}
+
+// SetTarget implements render.Renderer
+func (r *Renderer) SetTarget(t render.Texture) error {
+	st, err := texture(t)
+	if err != nil {
+		return err
+	}
+	return r.r.SetRenderTarget(st)
+}
+
+// Copy implements render.Renderer
+func (r *Renderer) Copy(t render.Texture, dst *render.Rect) error {
+	st, err := texture(t)
+	if err != nil {
+		return err
+	}
+	return r.r.Copy(st, nil, (*sdl.Rect)(dst))
+}
+
+// CopyEx implements render.Renderer
+func (r *Renderer) CopyEx(t render.Texture, dst *render.Rect, angle float64, flip render.Flip) error {
+	st, err := texture(t)
+	if err != nil {
+		return err
+	}
+
+	f := sdl.FLIP_NONE
+	switch flip {
+	case render.FlipHorizontal:
+		f = sdl.FLIP_HORIZONTAL
+	case render.FlipVertical:
+		f = sdl.FLIP_VERTICAL
+	}
+	return r.r.CopyEx(st, nil, (*sdl.Rect)(dst), angle, nil, f)
+}
+
+// Present implements render.Renderer
+func (r *Renderer) Present() {
+	r.r.Present()
+}
+
+// Do implements render.Renderer, SDL calls must happen on the main thread
+func (r *Renderer) Do(f func()) {
+	sdl.Do(f)
+}
@@ -40,8 +40,8 @@ func SetupSDL(config *pb.MazeConfig, winTitle string, xOffset, yOffset int32) (*
 	})
 
 	// window
-	winWidth := int32(config.Columns*config.CellWidth + config.WallWidth*2)
-	winHeight := int32(config.Rows*config.CellWidth + config.WallWidth*2)
+	w64, h64 := maze.WindowSize(config)
+	winWidth, winHeight := int32(w64), int32(h64)
 
 	if xOffset != 0 {
 		xOffset = xOffset * winWidth
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	listenAddr = flag.String("listen", ":50051", "serve gRPC on this address")
+	tlsCert    = flag.String("tls_cert", "", "TLS certificate file, with --tls_key gRPC and the JSON API are served over TLS")
+	tlsKey     = flag.String("tls_key", "", "TLS private key file")
+	authTokens = flag.String("auth_tokens", "", "file with the accepted auth tokens, one per line; if set every call needs one, and only the token that created a maze can use it; the JSON API then needs TLS")
+)
+
+// tokens has the owner (see tokenOwner) of every accepted token, nil if auth is off
+var tokens map[string]bool
+
+// ownerKey is the context key of the owner of the token of the call
+type ownerKey struct{}
+
+// tokenOwner returns the owner id of token, clients record it instead of the token itself (e.g. in the store)
+func tokenOwner(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// loadTokens reads the accepted tokens from the file at path, empty lines and lines starting with # are skipped
+func loadTokens(path string) (map[string]bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	owners := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		token := strings.TrimSpace(scanner.Text())
+		if token == "" || strings.HasPrefix(token, "#") {
+			continue
+		}
+		owners[tokenOwner(token)] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(owners) == 0 {
+		return nil, fmt.Errorf("no tokens in %v", path)
+	}
+	return owners, nil
+}
+
+// authenticate returns ctx with the owner of token, or an Unauthenticated error if token is not accepted
+// Everything is allowed when auth is off.
+func authenticate(ctx context.Context, token string) (context.Context, error) {
+	if tokens == nil {
+		return ctx, nil
+	}
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing auth token")
+	}
+	owner := tokenOwner(token)
+	if !tokens[owner] {
+		return nil, status.Error(codes.Unauthenticated, "invalid auth token")
+	}
+	return context.WithValue(ctx, ownerKey{}, owner), nil
+}
+
+// callerOwner returns the owner of the token of the call, empty if auth is off
+func callerOwner(ctx context.Context) string {
+	owner, _ := ctx.Value(ownerKey{}).(string)
+	return owner
+}
+
+// bearerToken returns the token in an "authorization: Bearer <token>" value
+func bearerToken(auth string) string {
+	const prefix = "Bearer "
+	if len(auth) < len(prefix) || !strings.EqualFold(auth[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(auth[len(prefix):])
+}
+
+// metadataToken returns the token sent by a gRPC client, see solvealgos.NewClient
+func metadataToken(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, auth := range md.Get("authorization") {
+		if token := bearerToken(auth); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// unaryAuth is the interceptor authenticating unary calls
+func unaryAuth(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := authenticate(ctx, metadataToken(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authStream is a stream with the owner of the token of the call in its context
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context implements grpc.ServerStream
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
+
+// streamAuth is the interceptor authenticating streaming calls
+func streamAuth(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := authenticate(ss.Context(), metadataToken(ss.Context()))
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+}
+
+// httpAuth authenticates JSON API calls, the token is in the Authorization header, or in the token
+// parameter for WebSockets opened by a browser (which cannot set headers). Other calls cannot use the
+// parameter, URLs end up in logs and browser history.
+func httpAuth(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := bearerToken(r.Header.Get("Authorization"))
+		if token == "" && isWebSocket(r) {
+			token = r.URL.Query().Get("token")
+		}
+		ctx, err := authenticate(r.Context(), token)
+		if err != nil {
+			httpError(w, http.StatusUnauthorized, err)
+			return
+		}
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// isWebSocket returns true if r asks to upgrade the connection to a WebSocket
+func isWebSocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// checkHTTPTLS returns an error if the JSON API would take auth tokens over plain HTTP
+func checkHTTPTLS() error {
+	withTLS, err := useTLS()
+	if err != nil {
+		return err
+	}
+	if *httpAddr != "" && *authTokens != "" && !withTLS {
+		return fmt.Errorf("--auth_tokens needs --tls_cert and --tls_key to serve the JSON API on --http_addr, tokens are not accepted over plain HTTP")
+	}
+	return nil
+}
+
+// allowed returns true if caller may use something owned by owner: with auth on only the same owner can
+// Mazes and clients created while auth was off have no owner, no one can use them once auth is on.
+func allowed(owner, caller string) bool {
+	return tokens == nil || (owner != "" && owner == caller)
+}
+
+// checkMazeOwner returns a PermissionDenied error unless the caller created the maze
+func checkMazeOwner(ctx context.Context, mc *mazeChannels) error {
+	if !allowed(mc.owner, callerOwner(ctx)) {
+		return status.Errorf(codes.PermissionDenied, "maze %v was created with another auth token", mc.watchers.mazeID)
+	}
+	return nil
+}
+
+// checkOwner returns a PermissionDenied error unless the caller created the maze and registered the client
+func checkOwner(ctx context.Context, mc *mazeChannels, clientID string) error {
+	if err := checkMazeOwner(ctx, mc); err != nil {
+		return err
+	}
+	owner, _ := mc.owners.Load(clientID)
+	if s, _ := owner.(string); !allowed(s, callerOwner(ctx)) {
+		return status.Errorf(codes.PermissionDenied, "client %v was not registered with this auth token", clientID)
+	}
+	return nil
+}
+
+// useTLS returns true if the servers should use TLS, an error if only half the key pair is set
+func useTLS() (bool, error) {
+	if (*tlsCert == "") != (*tlsKey == "") {
+		return false, fmt.Errorf("--tls_cert and --tls_key must be set together")
+	}
+	return *tlsCert != "", nil
+}
+
+// serverOptions returns the TLS and auth options of the gRPC server requested on the command line
+func serverOptions() ([]grpc.ServerOption, error) {
+	var opts []grpc.ServerOption
+
+	withTLS, err := useTLS()
+	if err != nil {
+		return nil, err
+	}
+	if withTLS {
+		creds, err := credentials.NewServerTLSFromFile(*tlsCert, *tlsKey)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	if *authTokens != "" {
+		if tokens, err = loadTokens(*authTokens); err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.UnaryInterceptor(unaryAuth), grpc.StreamInterceptor(streamAuth))
+	}
+	return opts, nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// withTokens turns auth on with the accepted tokens for the length of a test
+func withTokens(t *testing.T, accepted ...string) {
+	old := tokens
+	tokens = make(map[string]bool)
+	for _, token := range accepted {
+		tokens[tokenOwner(token)] = true
+	}
+	t.Cleanup(func() { tokens = old })
+}
+
+func TestAuthenticateOff(t *testing.T) {
+	ctx, err := authenticate(context.Background(), "")
+	if err != nil {
+		t.Fatalf("authenticate() with auth off = %v", err)
+	}
+	if owner := callerOwner(ctx); owner != "" {
+		t.Errorf("owner with auth off is %q, want none", owner)
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	withTokens(t, "secret", "other")
+
+	for _, tt := range []struct {
+		token    string
+		wantCode codes.Code
+	}{
+		{token: "secret", wantCode: codes.OK},
+		{token: "other", wantCode: codes.OK},
+		{token: "", wantCode: codes.Unauthenticated},
+		{token: "guess", wantCode: codes.Unauthenticated},
+	} {
+		ctx, err := authenticate(context.Background(), tt.token)
+		if code := status.Code(err); code != tt.wantCode {
+			t.Errorf("authenticate(%q) = %v, want %v", tt.token, code, tt.wantCode)
+			continue
+		}
+		if err == nil && callerOwner(ctx) != tokenOwner(tt.token) {
+			t.Errorf("authenticate(%q): owner is %q, want %q", tt.token, callerOwner(ctx), tokenOwner(tt.token))
+		}
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	for _, tt := range []struct {
+		auth, want string
+	}{
+		{auth: "Bearer secret", want: "secret"},
+		{auth: "bearer  secret ", want: "secret"},
+		{auth: "Basic secret", want: ""},
+		{auth: "Bearer", want: ""},
+		{auth: "", want: ""},
+	} {
+		if got := bearerToken(tt.auth); got != tt.want {
+			t.Errorf("bearerToken(%q) = %q, want %q", tt.auth, got, tt.want)
+		}
+	}
+}
+
+func TestCheckOwner(t *testing.T) {
+	withTokens(t, "secret", "other")
+	owner, _ := authenticate(context.Background(), "secret")
+	other, _ := authenticate(context.Background(), "other")
+
+	mc := newMazeChannels("maze", tokenOwner("secret"), nil, nil)
+	mc.owners.Store("mine", tokenOwner("secret"))
+	mc.owners.Store("unowned", "")
+
+	for _, tt := range []struct {
+		ctx      context.Context
+		clientID string
+		wantCode codes.Code
+	}{
+		{ctx: owner, clientID: "mine", wantCode: codes.OK},
+		{ctx: other, clientID: "mine", wantCode: codes.PermissionDenied},
+		{ctx: owner, clientID: "unowned", wantCode: codes.PermissionDenied},
+		{ctx: owner, clientID: "unknown", wantCode: codes.PermissionDenied},
+	} {
+		if code := status.Code(checkOwner(tt.ctx, mc, tt.clientID)); code != tt.wantCode {
+			t.Errorf("checkOwner(%v by %q) = %v, want %v", tt.clientID, callerOwner(tt.ctx), code, tt.wantCode)
+		}
+	}
+}
+
+func TestCheckMazeOwner(t *testing.T) {
+	ctx := context.Background()
+	if err := checkMazeOwner(ctx, newMazeChannels("maze", "", nil, nil)); err != nil {
+		t.Errorf("checkMazeOwner() with auth off = %v", err)
+	}
+
+	withTokens(t, "secret", "other")
+	owner, _ := authenticate(ctx, "secret")
+	other, _ := authenticate(ctx, "other")
+
+	for _, tt := range []struct {
+		ctx       context.Context
+		mazeOwner string
+		wantCode  codes.Code
+	}{
+		{ctx: owner, mazeOwner: tokenOwner("secret"), wantCode: codes.OK},
+		{ctx: other, mazeOwner: tokenOwner("secret"), wantCode: codes.PermissionDenied},
+		{ctx: owner, mazeOwner: "", wantCode: codes.PermissionDenied},
+	} {
+		mc := newMazeChannels("maze", tt.mazeOwner, nil, nil)
+		if code := status.Code(checkMazeOwner(tt.ctx, mc)); code != tt.wantCode {
+			t.Errorf("checkMazeOwner(maze of %q by %q) = %v, want %v", tt.mazeOwner, callerOwner(tt.ctx), code, tt.wantCode)
+		}
+	}
+}
+
+func TestHTTPAuth(t *testing.T) {
+	withTokens(t, "secret")
+
+	h := httpAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for _, tt := range []struct {
+		name     string
+		url      string
+		header   http.Header
+		wantCode int
+	}{
+		{name: "header", url: "/v1/mazes", header: http.Header{"Authorization": {"Bearer secret"}}, wantCode: http.StatusOK},
+		{name: "no token", url: "/v1/mazes", wantCode: http.StatusUnauthorized},
+		{name: "wrong token", url: "/v1/mazes", header: http.Header{"Authorization": {"Bearer guess"}}, wantCode: http.StatusUnauthorized},
+		// URLs end up in logs, only browsers opening WebSockets may put the token there
+		{name: "parameter", url: "/v1/mazes?token=secret", wantCode: http.StatusUnauthorized},
+		{name: "websocket parameter", url: "/v1/mazes/m/watch?token=secret", header: http.Header{"Upgrade": {"websocket"}}, wantCode: http.StatusOK},
+	} {
+		r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+		for k, v := range tt.header {
+			r.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != tt.wantCode {
+			t.Errorf("%v: status %v, want %v", tt.name, w.Code, tt.wantCode)
+		}
+	}
+}
+
+func TestCheckHTTPTLS(t *testing.T) {
+	defer func(addr, tokens, cert, key string) {
+		*httpAddr, *authTokens, *tlsCert, *tlsKey = addr, tokens, cert, key
+	}(*httpAddr, *authTokens, *tlsCert, *tlsKey)
+
+	for _, tt := range []struct {
+		addr, tokens, cert string
+		wantErr            bool
+	}{
+		{addr: "localhost:8080"},
+		{addr: "localhost:8080", tokens: "tokens.txt", wantErr: true},
+		{addr: "localhost:8080", tokens: "tokens.txt", cert: "cert.pem"},
+		{tokens: "tokens.txt"},
+	} {
+		*httpAddr, *authTokens, *tlsCert, *tlsKey = tt.addr, tt.tokens, tt.cert, tt.cert
+		if err := checkHTTPTLS(); (err != nil) != tt.wantErr {
+			t.Errorf("checkHTTPTLS() with %+v = %v, want error: %v", tt, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/rcrowley/go-metrics"
+	"golang.org/x/net/context"
+
+	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/DanTulovsky/mazes/utils"
+)
+
+var createWorkers = flag.Int("create_workers", runtime.NumCPU(), "number of mazes CreateMazes generates at the same time")
+
+// batchConfigs returns the config of every maze requested by in
+func batchConfigs(in *pb.CreateMazesRequest) ([]*pb.MazeConfig, error) {
+	configs := in.GetConfigs()
+	if len(configs) == 0 {
+		if in.GetConfig() == nil {
+			return nil, fmt.Errorf("either configs or config and count must be set")
+		}
+		if in.GetCount() <= 0 {
+			return nil, fmt.Errorf("count must be positive, got %v", in.GetCount())
+		}
+		configs = make([]*pb.MazeConfig, in.GetCount())
+		for i := range configs {
+			configs[i] = proto.Clone(in.GetConfig()).(*pb.MazeConfig)
+		}
+	}
+
+	// mazes without a seed get one here, one after the other, instead of racing for the clock when generated
+	seedStart := in.GetSeedStart()
+	if seedStart == 0 {
+		seedStart = utils.NewSeed()
+	}
+
+	for i, config := range configs {
+		if config == nil {
+			return nil, fmt.Errorf("maze config %v cannot be nil", i)
+		}
+		config.ReturnMaze = in.GetReturnMazes()
+		if in.GetSeedStart() != 0 || config.GetSeed() == 0 {
+			config.Seed = seedStart + int64(i)
+		}
+	}
+	return configs, nil
+}
+
+// CreateMazes creates many mazes, up to --create_workers at the same time
+func (s *server) CreateMazes(ctx context.Context, in *pb.CreateMazesRequest) (*pb.CreateMazesReply, error) {
+	t := metrics.GetOrRegisterTimer("maze.rpc.create-mazes.latency", nil)
+	defer t.UpdateSince(time.Now())
+
+	configs, err := batchConfigs(in)
+	if err != nil {
+		return &pb.CreateMazesReply{Success: false, Message: err.Error()}, nil
+	}
+	// fail early if they cannot all fit, startMaze enforces the limit for each maze
+	if *maxMazes > 0 && int(atomic.LoadInt64(&mazeCount))+len(configs) > *maxMazes {
+		return &pb.CreateMazesReply{Success: false,
+			Message: fmt.Sprintf("too many mazes (max_mazes=%v), delete some first", *maxMazes)}, nil
+	}
+	log.Printf("creating %v mazes (seed_start=%v)", len(configs), in.GetSeedStart())
+
+	reply := &pb.CreateMazesReply{
+		MazeIds: make([]string, len(configs)),
+		Seeds:   make([]int64, len(configs)),
+	}
+	if in.GetReturnMazes() {
+		reply.EncodedMazes = make([]string, len(configs))
+	}
+
+	errs := make([]error, len(configs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	workers := *createWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				m, err := startMaze(configs[i], "", callerOwner(ctx))
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				reply.MazeIds[i] = m.Config().GetId()
+				reply.Seeds[i] = m.Config().GetSeed()
+				if in.GetReturnMazes() {
+					reply.EncodedMazes[i] = m.EncodedString()
+				}
+			}
+		}()
+	}
+
+	// stop handing out mazes once the caller is gone, the ones started are kept
+	for i := range configs {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	failed := 0
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if failed == 0 {
+			reply.Message = fmt.Sprintf("maze %v: %v", i, err)
+		}
+		failed++
+	}
+	if failed > 0 {
+		reply.Message = fmt.Sprintf("%v of %v mazes failed, first error: %v", failed, len(configs), reply.Message)
+	}
+	reply.Success = failed == 0
+	return reply, nil
+}
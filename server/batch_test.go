@@ -0,0 +1,114 @@
+package main
+
+import (
+	"testing"
+
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+func TestBatchConfigs(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		in        *pb.CreateMazesRequest
+		wantSeeds []int64
+		wantErr   bool
+	}{
+		{
+			name: "configs",
+			in: &pb.CreateMazesRequest{Configs: []*pb.MazeConfig{
+				{Rows: 5, Seed: 3}, {Rows: 6, Seed: 4},
+			}},
+			wantSeeds: []int64{3, 4},
+		}, {
+			name:      "config and count with seeds",
+			in:        &pb.CreateMazesRequest{Config: &pb.MazeConfig{Rows: 5}, Count: 3, SeedStart: 10},
+			wantSeeds: []int64{10, 11, 12},
+		}, {
+			name:      "seed start overrides the seeds of configs",
+			in:        &pb.CreateMazesRequest{Configs: []*pb.MazeConfig{{Seed: 3}, {Seed: 4}}, SeedStart: 7},
+			wantSeeds: []int64{7, 8},
+		}, {
+			name:    "nothing",
+			in:      &pb.CreateMazesRequest{},
+			wantErr: true,
+		}, {
+			name:    "no count",
+			in:      &pb.CreateMazesRequest{Config: &pb.MazeConfig{}},
+			wantErr: true,
+		}, {
+			name:    "negative count",
+			in:      &pb.CreateMazesRequest{Config: &pb.MazeConfig{}, Count: -1},
+			wantErr: true,
+		}, {
+			name:    "nil config",
+			in:      &pb.CreateMazesRequest{Configs: []*pb.MazeConfig{{}, nil}},
+			wantErr: true,
+		},
+	} {
+		configs, err := batchConfigs(tt.in)
+		if err != nil {
+			if !tt.wantErr {
+				t.Errorf("%v: batchConfigs() = %v", tt.name, err)
+			}
+			continue
+		}
+		if tt.wantErr {
+			t.Errorf("%v: batchConfigs() should have failed", tt.name)
+			continue
+		}
+
+		if len(configs) != len(tt.wantSeeds) {
+			t.Errorf("%v: %v configs, want %v", tt.name, len(configs), len(tt.wantSeeds))
+			continue
+		}
+		for i, c := range configs {
+			if c.GetSeed() != tt.wantSeeds[i] {
+				t.Errorf("%v: config %v has seed %v, want %v", tt.name, i, c.GetSeed(), tt.wantSeeds[i])
+			}
+		}
+	}
+}
+
+func TestBatchConfigsUnseeded(t *testing.T) {
+	// generated in parallel, unseeded mazes must not end up with the same seed
+	for _, in := range []*pb.CreateMazesRequest{
+		{Config: &pb.MazeConfig{Rows: 5}, Count: 50},
+		{Configs: []*pb.MazeConfig{{}, {Seed: 7}, {}}},
+	} {
+		configs, err := batchConfigs(in)
+		if err != nil {
+			t.Fatalf("batchConfigs() = %v", err)
+		}
+		seen := make(map[int64]bool)
+		for i, c := range configs {
+			if c.GetSeed() == 0 || seen[c.GetSeed()] {
+				t.Errorf("config %v has seed %v, want a new one", i, c.GetSeed())
+			}
+			seen[c.GetSeed()] = true
+		}
+	}
+
+	// seeds in the configs are kept
+	configs, _ := batchConfigs(&pb.CreateMazesRequest{Configs: []*pb.MazeConfig{{}, {Seed: 7}}})
+	if configs[1].GetSeed() != 7 {
+		t.Errorf("config seed 7 became %v", configs[1].GetSeed())
+	}
+}
+
+func TestBatchConfigsCopies(t *testing.T) {
+	in := &pb.CreateMazesRequest{Config: &pb.MazeConfig{Rows: 5}, Count: 2, ReturnMazes: true}
+	configs, err := batchConfigs(in)
+	if err != nil {
+		t.Fatalf("batchConfigs() = %v", err)
+	}
+
+	// every maze gets its own id and seed, the configs cannot be shared
+	if configs[0] == configs[1] || configs[0] == in.GetConfig() {
+		t.Errorf("configs are shared")
+	}
+	for i, c := range configs {
+		if !c.GetReturnMaze() || c.GetRows() != 5 {
+			t.Errorf("config %v is %v, want the request config with ReturnMaze", i, c)
+		}
+	}
+}
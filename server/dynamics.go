@@ -0,0 +1,83 @@
+package main
+
+import (
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/rcrowley/go-metrics"
+	"github.com/tevino/abool"
+
+	"github.com/DanTulovsky/mazes/dynamics"
+	"github.com/DanTulovsky/mazes/maze"
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+// startDynamics starts changing the passages of m, while it is live, as set by its config (see MazeConfig.Dynamics)
+// The changes run in checkComm, between the commands of the clients.
+func (mc *mazeChannels) startDynamics(m *maze.Maze) error {
+	if m.Config().GetDynamics().GetPolicy() == "" {
+		return nil
+	}
+
+	d, err := dynamics.New(m, m.Config().GetDynamics())
+	if err != nil {
+		return err
+	}
+	log.Printf("changing maze %v every %v (%v)", m.Config().GetId(), d.Interval(), m.Config().GetDynamics().GetPolicy())
+
+	mc.dynamics = d
+	mc.dynamicsTicker = time.NewTicker(d.Interval())
+	return nil
+}
+
+// stopDynamics stops changing the maze
+func (mc *mazeChannels) stopDynamics() {
+	if mc.dynamicsTicker != nil {
+		mc.dynamicsTicker.Stop()
+	}
+}
+
+// dynamicsTicks returns the channel that ticks when it is time for the next change, nil if the maze does not change
+func (mc *mazeChannels) dynamicsTicks() <-chan time.Time {
+	if mc.dynamicsTicker == nil {
+		return nil
+	}
+	return mc.dynamicsTicker.C
+}
+
+// applyDynamics changes the maze once, at time now
+func applyDynamics(m *maze.Maze, channels *mazeChannels, updateBG *abool.AtomicBool, now time.Time) {
+	start := time.Now()
+	t := metrics.GetOrRegisterTimer("maze.dynamics.latency", nil)
+	defer t.UpdateSince(start)
+
+	if cells := channels.dynamics.Step(now); len(cells) > 0 {
+		mazeChanged(channels, updateBG, nil, cells)
+	}
+}
+
+// mazeChanged records that the passages of cells changed, for the next responses to the clients (see
+// SolveMazeResponse.MazeChanged), and tells the watchers; location is the location of the event, if any
+// Only called by the maze, in checkComm.
+func mazeChanged(channels *mazeChannels, updateBG *abool.AtomicBool, location *pb.MazeLocation, cells []*maze.Cell) {
+	channels.version++
+
+	// not a use of the maze, it still expires when no one is solving it (see touch)
+	atomic.StoreInt32(&channels.changed, 1)
+	updateBG.Set()
+
+	var locations []*pb.MazeLocation
+	for _, c := range cells {
+		locations = append(locations, c.Location())
+	}
+	channels.watchers.publish(&pb.MazeEvent{Type: eventMazeChanged, Location: location, Cells: locations})
+}
+
+// clientMazeChanged returns true if the maze changed since clientID was last told, and marks it as told
+// Only called by the maze, in checkComm.
+func (mc *mazeChannels) clientMazeChanged(clientID string) bool {
+	changed := mc.seen[clientID] != mc.version
+	mc.seen[clientID] = mc.version
+	return changed
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/rcrowley/go-metrics"
+	"golang.org/x/net/context"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/DanTulovsky/mazes/maze"
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+var (
+	httpAddr    = flag.String("http_addr", "localhost:8080", "serve the JSON and WebSocket API on this address (e.g. :8080 for all interfaces), empty to disable")
+	httpOrigins = flag.String("http_origins", "", "comma separated origins (e.g. https://example.com) whose web pages may open WebSockets, besides the server's own")
+)
+
+const (
+	// all endpoints are under this path
+	httpAPIPrefix = "/v1/mazes"
+)
+
+var (
+	jsonMarshaler   = &jsonpb.Marshaler{EmitDefaults: true}
+	jsonUnmarshaler = &jsonpb.Unmarshaler{}
+)
+
+// newHTTPHandler returns the JSON API, every endpoint calls the gRPC method of the same name
+// Request bodies are the gRPC request as JSON, ids in the path fill in the request.
+//
+//	GET    /v1/mazes                                  ListMazes
+//	POST   /v1/mazes                                  CreateMaze
+//	GET    /v1/mazes/{maze}                           GetMaze
+//	DELETE /v1/mazes/{maze}                           DeleteMaze
+//	POST   /v1/mazes/{maze}/export                    ExportMaze
+//	POST   /v1/mazes/{maze}/clients                   RegisterClient
+//	POST   /v1/mazes/{maze}/solve                     RunSolver
+//	POST   /v1/mazes/{maze}/clients/{client}/reset    ResetClient
+//	POST   /v1/mazes/{maze}/clients/{client}/move     one SolveMaze request and its response
+//	GET    /v1/mazes/{maze}/clients/{client}/solve    SolveMaze over a WebSocket, one JSON message each way per move
+//	GET    /v1/mazes/{maze}/watch                     WatchMaze over a WebSocket, one JSON message per event
+//
+// Everything else is the web viewer, see web.go.
+func newHTTPHandler(s *server) http.Handler {
+	mux := http.NewServeMux()
+	api := httpAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveAPI(s, w, r)
+	}))
+	mux.Handle("/", webHandler())
+	mux.Handle(httpAPIPrefix, api)
+	mux.Handle(httpAPIPrefix+"/", api)
+	return mux
+}
+
+// serveAPI routes one API request
+func serveAPI(s *server, w http.ResponseWriter, r *http.Request) {
+	t := metrics.GetOrRegisterTimer("maze.http.latency", nil)
+	defer t.UpdateSince(time.Now())
+
+	var path []string
+	if p := strings.Trim(strings.TrimPrefix(r.URL.Path, httpAPIPrefix), "/"); p != "" {
+		path = strings.Split(p, "/")
+	}
+	ctx := r.Context()
+
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		in := &pb.ListMazeRequest{}
+		serveJSON(w, r, in, func() (proto.Message, error) { return s.ListMazes(ctx, in) })
+	case len(path) == 0 && r.Method == http.MethodPost:
+		in := &pb.CreateMazeRequest{}
+		serveJSON(w, r, in, func() (proto.Message, error) { return s.CreateMaze(ctx, in) })
+	case len(path) == 1 && path[0] == "batch" && r.Method == http.MethodPost:
+		in := &pb.CreateMazesRequest{}
+		serveJSON(w, r, in, func() (proto.Message, error) { return s.CreateMazes(ctx, in) })
+	case len(path) == 1 && r.Method == http.MethodGet:
+		in := &pb.GetMazeRequest{}
+		serveJSON(w, r, in, func() (proto.Message, error) {
+			in.MazeId = path[0]
+			return s.GetMaze(ctx, in)
+		})
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		in := &pb.DeleteMazeRequest{}
+		serveJSON(w, r, in, func() (proto.Message, error) {
+			in.MazeId = path[0]
+			return s.DeleteMaze(ctx, in)
+		})
+	case len(path) == 2 && path[1] == "export" && r.Method == http.MethodPost:
+		in := &pb.ExportMazeRequest{}
+		serveJSON(w, r, in, func() (proto.Message, error) {
+			in.MazeId = path[0]
+			return s.ExportMaze(ctx, in)
+		})
+	case len(path) == 2 && path[1] == "clients" && r.Method == http.MethodPost:
+		in := &pb.RegisterClientRequest{}
+		serveJSON(w, r, in, func() (proto.Message, error) {
+			in.MazeId = path[0]
+			return s.RegisterClient(ctx, in)
+		})
+	case len(path) == 2 && path[1] == "solve" && r.Method == http.MethodPost:
+		in := &pb.RunSolverRequest{}
+		serveJSON(w, r, in, func() (proto.Message, error) {
+			in.MazeId = path[0]
+			return s.RunSolver(ctx, in)
+		})
+	case len(path) == 4 && path[1] == "clients" && path[3] == "reset" && r.Method == http.MethodPost:
+		in := &pb.ResetClientRequest{}
+		serveJSON(w, r, in, func() (proto.Message, error) {
+			in.MazeId, in.ClientId = path[0], path[2]
+			return s.ResetClient(ctx, in)
+		})
+	case len(path) == 4 && path[1] == "clients" && path[3] == "move" && r.Method == http.MethodPost:
+		in := &pb.SolveMazeRequest{}
+		serveJSON(w, r, in, func() (proto.Message, error) {
+			in.MazeId, in.ClientId = path[0], path[2]
+			return moveOnce(ctx, in)
+		})
+	case len(path) == 2 && path[1] == "watch" && r.Method == http.MethodGet:
+		websocket.Server{Handshake: checkOrigin, Handler: func(ws *websocket.Conn) {
+			serveWatchMaze(s, ws, path[0])
+		}}.ServeHTTP(w, r)
+	case len(path) == 4 && path[1] == "clients" && path[3] == "solve" && r.Method == http.MethodGet:
+		websocket.Server{Handshake: checkOrigin, Handler: func(ws *websocket.Conn) {
+			serveSolveMaze(s, ws, path[0], path[2])
+		}}.ServeHTTP(w, r)
+	default:
+		httpError(w, http.StatusNotFound, fmt.Errorf("no such endpoint: %v %v", r.Method, r.URL.Path))
+	}
+}
+
+// checkOrigin is the WebSocket handshake, it only accepts web pages served by this server (the web viewer) or
+// by --http_origins; otherwise any page a browser opens could drive the server
+// Programs (not browsers) send no Origin, they are accepted.
+func checkOrigin(config *websocket.Config, r *http.Request) error {
+	origin, err := websocket.Origin(config, r)
+	if err != nil {
+		return err
+	}
+	config.Origin = origin
+	if origin == nil || strings.EqualFold(origin.Host, r.Host) {
+		return nil
+	}
+
+	for _, allowed := range strings.Split(*httpOrigins, ",") {
+		allowed = strings.TrimRight(strings.TrimSpace(allowed), "/")
+		if allowed != "" && strings.EqualFold(allowed, origin.Scheme+"://"+origin.Host) {
+			return nil
+		}
+	}
+	return fmt.Errorf("origin %v is not allowed, see --http_origins", origin)
+}
+
+// serveJSON reads the request body (if any) into in, calls f and writes its reply as JSON
+// Errors returned by f are sent as {"error": "..."} with status 400, or 401/403 for auth errors.
+func serveJSON(w http.ResponseWriter, r *http.Request, in proto.Message, f func() (proto.Message, error)) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err)
+		return
+	}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := jsonUnmarshaler.Unmarshal(bytes.NewReader(body), in); err != nil {
+			httpError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %v", err))
+			return
+		}
+	}
+
+	reply, err := f()
+	if err != nil {
+		code := http.StatusBadRequest
+		switch status.Code(err) {
+		case codes.Unauthenticated:
+			code = http.StatusUnauthorized
+		case codes.PermissionDenied:
+			code = http.StatusForbidden
+		}
+		httpError(w, code, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := jsonMarshaler.Marshal(w, reply); err != nil {
+		log.Printf("error writing reply: %v", err)
+	}
+}
+
+// httpError sends err as {"error": "..."}
+func httpError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	fmt.Fprintf(w, "{\"error\": %q}\n", err.Error())
+}
+
+// moveOnce runs one SolveMaze request: initial puts the client on its from cell, otherwise it moves
+func moveOnce(ctx context.Context, in *pb.SolveMazeRequest) (*pb.SolveMazeResponse, error) {
+	channels, found := mazeMap.Find(in.GetMazeId())
+	if !found {
+		return nil, fmt.Errorf("unable to lookup maze [%v]", in.GetMazeId())
+	}
+	mc := channels.(*mazeChannels)
+	if err := checkOwner(ctx, mc, in.GetClientId()); err != nil {
+		return nil, err
+	}
+
+	if in.GetInitial() {
+		return initialSolveResponse(mc, in)
+	}
+
+	// unlike SolveMaze, nothing makes sure the initial request came first
+	located := mc.send(commandData{Action: maze.CommandLocationInfo, ClientID: in.GetClientId(), Reply: make(chan commandReply)})
+	if located.error != nil {
+		return nil, located.error
+	}
+	return moveResponse(mc, in)
+}
+
+// wsSolveStream is a SolveMaze stream over a WebSocket, messages are SolveMazeRequest and SolveMazeResponse as JSON
+type wsSolveStream struct {
+	grpc.ServerStream // not implemented, SolveMaze only uses Send, Recv and Context
+
+	ws               *websocket.Conn
+	mazeID, clientID string
+	ctx              context.Context
+}
+
+// Send implements pb.Mazer_SolveMazeServer
+func (s *wsSolveStream) Send(r *pb.SolveMazeResponse) error {
+	msg, err := jsonMarshaler.MarshalToString(r)
+	if err != nil {
+		return err
+	}
+	return websocket.Message.Send(s.ws, msg)
+}
+
+// Recv implements pb.Mazer_SolveMazeServer, the maze and client ids come from the path if not set
+func (s *wsSolveStream) Recv() (*pb.SolveMazeRequest, error) {
+	var msg string
+	if err := websocket.Message.Receive(s.ws, &msg); err != nil {
+		return nil, err
+	}
+
+	in := &pb.SolveMazeRequest{}
+	if err := jsonUnmarshaler.Unmarshal(strings.NewReader(msg), in); err != nil {
+		return nil, fmt.Errorf("invalid request: %v", err)
+	}
+	if in.GetMazeId() == "" {
+		in.MazeId = s.mazeID
+	}
+	if in.GetClientId() == "" {
+		in.ClientId = s.clientID
+	}
+	return in, nil
+}
+
+// Context implements grpc.ServerStream
+func (s *wsSolveStream) Context() context.Context {
+	return s.ctx
+}
+
+// serveSolveMaze runs SolveMaze over the WebSocket until either side is done
+func serveSolveMaze(s *server, ws *websocket.Conn, mazeID, clientID string) {
+	defer ws.Close()
+
+	ctx, cancel := context.WithCancel(ws.Request().Context())
+	defer cancel()
+
+	stream := &wsSolveStream{ws: ws, mazeID: mazeID, clientID: clientID, ctx: ctx}
+	if err := s.SolveMaze(stream); err != nil {
+		log.Printf("websocket solve of maze %v: %v", mazeID, err)
+		stream.Send(&pb.SolveMazeResponse{MazeId: mazeID, ClientId: clientID, Error: true, ErrorMessage: err.Error()})
+	}
+}
+
+// wsWatchStream is a WatchMaze stream over a WebSocket, messages are MazeEvent as JSON
+type wsWatchStream struct {
+	grpc.ServerStream // not implemented, WatchMaze only uses Send and Context
+
+	ws  *websocket.Conn
+	ctx context.Context
+}
+
+// Send implements pb.Mazer_WatchMazeServer
+func (s *wsWatchStream) Send(e *pb.MazeEvent) error {
+	msg, err := jsonMarshaler.MarshalToString(e)
+	if err != nil {
+		return err
+	}
+	return websocket.Message.Send(s.ws, msg)
+}
+
+// Context implements grpc.ServerStream
+func (s *wsWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+// serveWatchMaze runs WatchMaze over the WebSocket until the maze is deleted or the watcher goes away
+func serveWatchMaze(s *server, ws *websocket.Conn, mazeID string) {
+	defer ws.Close()
+
+	ctx, cancel := context.WithCancel(ws.Request().Context())
+	defer cancel()
+
+	// the watcher never sends anything, reading notices when it goes away
+	go func() {
+		defer cancel()
+		var msg string
+		for websocket.Message.Receive(ws, &msg) == nil {
+		}
+	}()
+
+	if err := s.WatchMaze(&pb.WatchMazeRequest{MazeId: mazeID}, &wsWatchStream{ws: ws, ctx: ctx}); err != nil && err != context.Canceled {
+		log.Printf("websocket watch of maze %v: %v", mazeID, err)
+	}
+}
+
+// serveHTTP serves the JSON API on addr, over TLS if the gRPC server uses it, it only returns on error
+func serveHTTP(addr string) {
+	log.Printf("JSON API ready on %v%v, web viewer on %v/", addr, httpAPIPrefix, addr)
+
+	var err error
+	if withTLS, _ := useTLS(); withTLS {
+		err = http.ListenAndServeTLS(addr, *tlsCert, *tlsKey, newHTTPHandler(&server{}))
+	} else {
+		err = http.ListenAndServe(addr, newHTTPHandler(&server{}))
+	}
+	if err != nil {
+		log.Fatalf("failed to serve JSON API: %v", err)
+	}
+}
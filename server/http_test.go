@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/websocket"
+)
+
+func TestCheckOrigin(t *testing.T) {
+	old := *httpOrigins
+	*httpOrigins = "https://trainer.example.com/, http://other.example.com:9000"
+	defer func() { *httpOrigins = old }()
+
+	for _, tt := range []struct {
+		host, origin string
+		wantErr      bool
+	}{
+		{host: "localhost:8080", origin: "", wantErr: false}, // not a browser
+		{host: "localhost:8080", origin: "http://localhost:8080", wantErr: false},
+		{host: "mazes:8080", origin: "https://MAZES:8080", wantErr: false},
+		{host: "localhost:8080", origin: "https://trainer.example.com", wantErr: false},
+		{host: "localhost:8080", origin: "http://other.example.com:9000", wantErr: false},
+		{host: "localhost:8080", origin: "http://evil.example.com", wantErr: true},
+		{host: "localhost:8080", origin: "http://localhost:9999", wantErr: true},
+		{host: "localhost:8080", origin: "http://trainer.example.com", wantErr: true},
+		{host: "localhost:8080", origin: "null", wantErr: true},
+	} {
+		r := &http.Request{Host: tt.host, Header: http.Header{}}
+		if tt.origin != "" {
+			r.Header.Set("Origin", tt.origin)
+		}
+		config := &websocket.Config{Version: websocket.ProtocolVersionHybi13}
+
+		if err := checkOrigin(config, r); (err != nil) != tt.wantErr {
+			t.Errorf("checkOrigin(%q on %v) = %v, want error: %v", tt.origin, tt.host, err, tt.wantErr)
+		}
+	}
+}
+
+// postJSON posts body to url and decodes the JSON reply into out, it returns the status code
+func postJSON(t *testing.T, url, body string, out interface{}) int {
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST %v: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		t.Fatalf("POST %v: invalid reply: %v", url, err)
+	}
+	return resp.StatusCode
+}
+
+func TestHTTPMoveBeforeInitial(t *testing.T) {
+	ts := httptest.NewServer(newHTTPHandler(&server{}))
+	defer ts.Close()
+	api := ts.URL + httpAPIPrefix
+
+	var created struct{ MazeId string }
+	if code := postJSON(t, api, `{"config": {"Rows": 4, "Columns": 4, "CreateAlgo": "bintree"}}`, &created); code != http.StatusOK {
+		t.Fatalf("creating a maze: status %v", code)
+	}
+	defer deleteMaze(created.MazeId)
+
+	var registered struct {
+		Success  bool
+		Message  string
+		ClientId string
+	}
+	postJSON(t, api+"/"+created.MazeId+"/clients", `{"client_config": {"SolveAlgo": "manual"}}`, &registered)
+	if !registered.Success {
+		t.Fatalf("registering a client failed: %v", registered.Message)
+	}
+
+	for _, tt := range []struct {
+		name, clientID, body string
+		wantCode             int
+	}{
+		{name: "move before initial", clientID: registered.ClientId, body: `{"direction": "north"}`, wantCode: http.StatusBadRequest},
+		{name: "move back before initial", clientID: registered.ClientId, body: `{"moveBack": true}`, wantCode: http.StatusBadRequest},
+		{name: "unknown client", clientID: "nobody", body: `{"direction": "north"}`, wantCode: http.StatusBadRequest},
+		{name: "initial", clientID: registered.ClientId, body: `{"initial": true}`, wantCode: http.StatusOK},
+		{name: "move after initial", clientID: registered.ClientId, body: `{"direction": "north"}`, wantCode: http.StatusOK},
+	} {
+		var reply map[string]interface{}
+		url := api + "/" + created.MazeId + "/clients/" + tt.clientID + "/move"
+		if code := postJSON(t, url, tt.body, &reply); code != tt.wantCode {
+			t.Errorf("%v: status %v (%v), want %v", tt.name, code, reply, tt.wantCode)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/rcrowley/go-metrics"
+	"golang.org/x/net/context"
+
+	"github.com/DanTulovsky/mazes/genalgos"
+	"github.com/DanTulovsky/mazes/maze"
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+// checkImportedMaze returns an error if encoded is not a valid maze for config
+// The maze is decoded into a throwaway copy, so nothing invalid ever reaches the generator.
+func checkImportedMaze(config *pb.MazeConfig, encoded string, spanningTree bool) error {
+	if strings.TrimSpace(encoded) == "" {
+		return fmt.Errorf("encoded maze cannot be empty")
+	}
+
+	columns, rows, levels := maze.EncodedDimensions(config, encoded)
+	// columns are ignored on polar grids, they follow from the rows
+	if (config.GetColumns() != 0 && config.GetColumns() != columns && config.GetGridType() != maze.GridPolar) ||
+		(config.GetRows() != 0 && config.GetRows() != rows) ||
+		(config.GetLevels() != 0 && config.GetLevels() != levels) {
+		return fmt.Errorf("config is %vx%vx%v (columns x rows x levels), but the encoded maze is %vx%vx%v",
+			config.GetColumns(), config.GetRows(), config.GetLevels(), columns, rows, levels)
+	}
+
+	for l, block := range strings.Split(strings.TrimRight(encoded, "\n"), "\n\n") {
+		lines := strings.Split(block, "\n")
+		if int64(len(lines)) != rows {
+			return fmt.Errorf("level %v has %v rows, level 0 has %v", l, len(lines), rows)
+		}
+		for y, line := range lines {
+			if len(line) != len(lines[0]) {
+				return fmt.Errorf("row %v on level %v is %v characters long, row 0 is %v", y, l, len(line), len(lines[0]))
+			}
+		}
+	}
+
+	c := proto.Clone(config).(*pb.MazeConfig)
+	c.Columns, c.Rows, c.Levels = columns, rows, levels
+	c.Gui = false
+	c.Record = nil
+
+	m, err := maze.NewMaze(c, nil)
+	if err != nil {
+		return err
+	}
+	if err := m.Decode(encoded); err != nil {
+		return err
+	}
+	if err := m.CheckEncoded(encoded); err != nil {
+		return err
+	}
+
+	if spanningTree {
+		var a genalgos.Common
+		if err := a.CheckGrid(m); err != nil {
+			return fmt.Errorf("not a perfect maze: %v", err)
+		}
+	}
+	return nil
+}
+
+// ImportMaze creates and displays a maze sent, encoded, by the client; e.g. one exported from another server
+func (s *server) ImportMaze(ctx context.Context, in *pb.ImportMazeRequest) (*pb.ImportMazeReply, error) {
+	log.Printf("importing maze with config: %#v", in.GetConfig())
+	if in.GetConfig() == nil {
+		return nil, fmt.Errorf("maze config cannot be nil")
+	}
+
+	t := metrics.GetOrRegisterTimer("maze.rpc.import-maze.latency", nil)
+	defer t.UpdateSince(time.Now())
+
+	if err := checkImportedMaze(in.GetConfig(), in.GetEncodedMaze(), in.GetCheckSpanningTree()); err != nil {
+		return &pb.ImportMazeReply{Success: false, Message: fmt.Sprintf("invalid maze: %v", err)}, nil
+	}
+
+	m, err := startMaze(in.GetConfig(), in.GetEncodedMaze(), callerOwner(ctx))
+	if err != nil {
+		return &pb.ImportMazeReply{Success: false, Message: err.Error()}, nil
+	}
+	return &pb.ImportMazeReply{Success: true, MazeId: m.Config().GetId()}, nil
+}
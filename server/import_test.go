@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+// perfectMaze is a 4x3 maze made by the recursive backtracker, encoded
+const perfectMaze = "2354\n61AD\nA339\n"
+
+var importtests = []struct {
+	name         string
+	config       *pb.MazeConfig
+	encoded      string
+	spanningTree bool
+	wantErr      bool
+}{
+	{
+		name:    "dimensions from the maze",
+		config:  &pb.MazeConfig{},
+		encoded: perfectMaze,
+	}, {
+		name:         "matching dimensions, perfect",
+		config:       &pb.MazeConfig{Columns: 4, Rows: 3},
+		encoded:      perfectMaze,
+		spanningTree: true,
+	}, {
+		name:    "wrong rows",
+		config:  &pb.MazeConfig{Columns: 4, Rows: 5},
+		encoded: perfectMaze,
+		wantErr: true,
+	}, {
+		name:    "wrong levels",
+		config:  &pb.MazeConfig{Levels: 2},
+		encoded: perfectMaze,
+		wantErr: true,
+	}, {
+		name:    "empty",
+		config:  &pb.MazeConfig{},
+		encoded: " \n",
+		wantErr: true,
+	}, {
+		name:    "ragged rows",
+		config:  &pb.MazeConfig{},
+		encoded: "2354\n61A\nA339\n",
+		wantErr: true,
+	}, {
+		name:    "not hex",
+		config:  &pb.MazeConfig{},
+		encoded: "2354\n61XD\nA339\n",
+		wantErr: true,
+	}, {
+		name:    "one sided passage",
+		config:  &pb.MazeConfig{},
+		encoded: "3354\n61AD\nA339\n",
+		wantErr: true,
+	}, {
+		name:         "no passages, not perfect",
+		config:       &pb.MazeConfig{},
+		encoded:      "0000\n0000\n0000\n",
+		spanningTree: true,
+		wantErr:      true,
+	}, {
+		name:    "no passages",
+		config:  &pb.MazeConfig{},
+		encoded: "0000\n0000\n0000\n",
+	},
+}
+
+func TestCheckImportedMaze(t *testing.T) {
+	for _, tt := range importtests {
+		err := checkImportedMaze(tt.config, tt.encoded, tt.spanningTree)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%v: checkImportedMaze() = %v, want error: %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCheckImportedMazeKeepsConfig(t *testing.T) {
+	config := &pb.MazeConfig{Gui: true}
+	if err := checkImportedMaze(config, perfectMaze, true); err != nil {
+		t.Fatalf("checkImportedMaze() = %v", err)
+	}
+	if !config.GetGui() || config.GetRows() != 0 {
+		t.Errorf("checkImportedMaze() changed the config, it is now %v", config)
+	}
+}
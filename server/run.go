@@ -2,6 +2,8 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	_ "expvar"
 	"flag"
 	"fmt"
@@ -14,17 +16,20 @@ import (
 	"os"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/DanTulovsky/safemap"
 
 	"github.com/DanTulovsky/mazes/algos"
 	"github.com/DanTulovsky/mazes/colors"
+	"github.com/DanTulovsky/mazes/dynamics"
 	"github.com/DanTulovsky/mazes/maze"
 	pb "github.com/DanTulovsky/mazes/proto"
 	lsdl "github.com/DanTulovsky/mazes/sdl"
 
 	"github.com/DanTulovsky/mazes/genalgos/fromfile"
+	"github.com/DanTulovsky/mazes/genalgos/origin_shift"
 
 	graphite "github.com/cyberdelia/go-metrics-graphite"
 	"github.com/pkg/profile"
@@ -39,10 +44,6 @@ import (
 	"google.golang.org/grpc/reflection"
 )
 
-const (
-	port = ":50051"
-)
-
 // For gui support
 // brew install sdl2{_image,_ttf,_gfx}
 // brew install sdl2_mixer --with-flac --with-fluid-synth --with-libmikmod --with-libmodplug --with-libvorbis --with-smpeg2
@@ -64,11 +65,17 @@ var (
 	maskImage = flag.String("mask_image", "", "file name of mask image")
 
 	// display
-	frameRate = flag.Uint("frame_rate", 120, "frame rate for animation")
+	frameRate  = flag.Uint("frame_rate", 120, "frame rate for animation")
+	termGUI    = flag.Bool("term_gui", false, "show the mazes live in the terminal, works without a display (use --gui=false on the client)")
+	termGUILog = flag.String("term_gui_log", "server.log", "file to write the log to while the terminal viewer is shown")
 
 	// misc
 	bgMusic = flag.String("bg_music", "", "file name of background music to play")
 
+	// limits
+	mazeTTL  = flag.Duration("maze_ttl", 0, "delete mazes not used for this long, 0 keeps them forever")
+	maxMazes = flag.Int("max_mazes", 0, "maximum number of mazes at the same time, 0 for no limit")
+
 	// stats
 	showStats        = flag.Bool("maze_stats", false, "show maze stats")
 	enableMonitoring = flag.Bool("enable_monitoring", false, "enable monitoring")
@@ -102,21 +109,41 @@ func showMazeStats(m *maze.Maze) {
 	log.Printf(">> Dead Ends: %v", len(m.DeadEnds()))
 }
 
-func createMaze(config *pb.MazeConfig) (m *maze.Maze, r *sdl.Renderer, w *sdl.Window, err error) {
+// createMaze creates the maze, encoded is used instead of the generator if not empty (see ImportMaze)
+// The generator steps are published to ws.
+func createMaze(config *pb.MazeConfig, encoded string, ws *watchers) (m *maze.Maze, r *sdl.Renderer, w *sdl.Window, err error) {
+
+	if encoded != "" {
+		config.CreateAlgo = "from-encoded-string"
+		config.Columns, config.Rows, config.Levels = maze.EncodedDimensions(config, encoded)
+	}
 
 	if config.GetCreateAlgo() == "fromfile" {
-		if c, r, err := fromfile.MazeSizeFromFile(config); err == nil {
-			config.Columns, config.Rows = int64(c), int64(r)
+		if c, r, l, err := fromfile.MazeSizeFromFile(config); err == nil {
+			config.Columns, config.Rows, config.Levels = int64(c), int64(r), int64(l)
 		} else {
 			return nil, nil, nil, err
 		}
 	}
 
+	if !algos.CheckCreateAlgo(config.CreateAlgo) {
+		return nil, nil, nil, fmt.Errorf("invalid create algorithm: %v", config.CreateAlgo)
+	}
+	if err := algos.CheckCreateConfig(config); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid config: %v", err)
+	}
+
 	//////////////////////////////////////////////////////////////////////////////////////////////
 	// Setup SDL
 	//////////////////////////////////////////////////////////////////////////////////////////////
 	title := fmt.Sprintf("Server: %v", config.GetTitle())
 	w, r = lsdl.SetupSDL(config, title, 0, 0)
+	defer func(w *sdl.Window, r *sdl.Renderer) {
+		// the error returns do not return the window
+		if err != nil {
+			destroySDL(m, r, w)
+		}
+	}(w, r)
 	//////////////////////////////////////////////////////////////////////////////////////////////
 	// End Setup SDL
 	//////////////////////////////////////////////////////////////////////////////////////////////
@@ -153,29 +180,30 @@ func createMaze(config *pb.MazeConfig) (m *maze.Maze, r *sdl.Renderer, w *sdl.Wi
 
 	// Mask image if provided.
 	// If the mask image is provided, use that as the dimensions of the grid
-	if *maskImage != "" {
+	if *maskImage != "" && encoded == "" {
 		log.Printf("Using %v as grid mask", *maskImage)
-		m, err = maze.NewMazeFromImage(config, *maskImage, r)
+		m, err = maze.NewMazeFromImage(config, *maskImage, lsdl.NewRenderer(r))
 		if err != nil {
-			log.Printf("invalid config: %v", err)
-			os.Exit(1)
+			return nil, nil, nil, fmt.Errorf("invalid config: %v", err)
 		}
 		// Set these for correct window size
 		config.Columns, config.Rows = m.Dimensions()
 	} else {
-		m, err = maze.NewMaze(config, r)
+		m, err = maze.NewMaze(config, lsdl.NewRenderer(r))
 		if err != nil {
-			log.Printf("invalid config: %v", err)
-			os.Exit(1)
+			return nil, nil, nil, fmt.Errorf("invalid config: %v", err)
 		}
 	}
 	//////////////////////////////////////////////////////////////////////////////////////////////
 	// End Configure new grid
 	//////////////////////////////////////////////////////////////////////////////////////////////
-
-	if !algos.CheckCreateAlgo(config.CreateAlgo) {
-		return nil, nil, nil, fmt.Errorf("invalid create algorithm: %v", config.CreateAlgo)
+	if encoded != "" {
+		m.SetEncodedString(encoded)
 	}
+	m.OnGenStep(func(l *pb.MazeLocation) {
+		ws.publish(&pb.MazeEvent{Type: eventGeneratorStep, Location: l})
+	})
+	defer m.OnGenStep(nil)
 
 	//////////////////////////////////////////////////////////////////////////////////////////////
 	// Background Music
@@ -219,9 +247,7 @@ func createMaze(config *pb.MazeConfig) (m *maze.Maze, r *sdl.Renderer, w *sdl.Wi
 	var wd sync.WaitGroup
 
 	wd.Add(1)
-	// TODO(dan): redo error return as a channel to catch problems here
 	generate := func() error {
-		defer wd.Done()
 		log.Printf("running generator %v", config.CreateAlgo)
 
 		if err := algo.Apply(m, delay, generating); err != nil {
@@ -247,14 +273,17 @@ func createMaze(config *pb.MazeConfig) (m *maze.Maze, r *sdl.Renderer, w *sdl.Wi
 			showMazeStats(m)
 		}
 
+		if err := m.SaveGenRecording(); err != nil {
+			log.Printf("error saving generator recording: %v", err)
+		}
+
 		generating.UnSet()
 		return nil
 	}
+	var genErr error // read after wd.Wait()
 	go func() {
-		err := generate()
-		if err != nil {
-			log.Fatalf("error in generate: %v", err)
-		}
+		defer wd.Done()
+		genErr = generate()
 	}()
 
 	if m.Config().GetGui() {
@@ -267,12 +296,12 @@ func createMaze(config *pb.MazeConfig) (m *maze.Maze, r *sdl.Renderer, w *sdl.Wi
 			// Displays the main maze while generating it
 			sdl.Do(func() {
 				// reset the clear color back to white
-				colors.SetDrawColor(colors.GetColor("white"), r)
+				lsdl.SetDrawColor(colors.GetColor("white"), r)
 
 				if err := r.Clear(); err != nil {
 					log.Fatalf("error in clear: %v", err)
 				}
-				m.DrawMazeBackground(r)
+				m.DrawMazeBackground(lsdl.NewRenderer(r))
 				r.Present()
 				sdl.Delay(uint32(1000 / *frameRate))
 				ResetFontCache()
@@ -280,12 +309,15 @@ func createMaze(config *pb.MazeConfig) (m *maze.Maze, r *sdl.Renderer, w *sdl.Wi
 		}
 	}
 	wd.Wait()
+	if genErr != nil {
+		return nil, nil, nil, fmt.Errorf("error in generate: %v", genErr)
+	}
 	///////////////////////////////////////////////////////////////////////////
 	// End Generator
 	///////////////////////////////////////////////////////////////////////////
 	log.Printf("finished creating maze...")
 
-	encoded, err := m.Encode()
+	encoded, err = m.Encode()
 	if err != nil {
 		encoded = err.Error()
 	}
@@ -299,22 +331,9 @@ func createMaze(config *pb.MazeConfig) (m *maze.Maze, r *sdl.Renderer, w *sdl.Wi
 	return m, r, w, nil
 }
 
-// runMaze runs the maze
-func runMaze(m *maze.Maze, r *sdl.Renderer, w *sdl.Window, comm chan commandData, doneCh chan bool) {
-	defer func() {
-		sdl.Do(func() {
-			log.Println("Destroying GUI window...")
-			if err := r.Destroy(); err != nil {
-				log.Printf("error destroying window: %v", err)
-			}
-
-			if err := w.Destroy(); err != nil {
-				log.Printf("error destroying window: %v", err)
-			}
-
-			log.Println("Finished destroying GUI window...")
-		})
-	}()
+// runMaze runs the maze until its window is closed or it is deleted (channels.quit())
+func runMaze(m *maze.Maze, r *sdl.Renderer, w *sdl.Window, channels *mazeChannels) {
+	defer destroySDL(m, r, w)
 	runtime.LockOSThread()
 	var wd sync.WaitGroup
 
@@ -341,9 +360,9 @@ func runMaze(m *maze.Maze, r *sdl.Renderer, w *sdl.Window, comm chan commandData
 	go func() {
 		defer wd.Done()
 		log.Print("starting client comm thread...")
-		for running.IsSet() {
+		for running.IsSet() && !channels.quitting() {
 			// check for client communications, they are serialized for one maze
-			checkComm(m, comm, updateBG)
+			checkComm(m, channels, updateBG)
 		}
 		log.Printf("client comm thread exiting...")
 	}()
@@ -364,6 +383,10 @@ func runMaze(m *maze.Maze, r *sdl.Renderer, w *sdl.Window, comm chan commandData
 			log.Printf("error getting window id: %v", err)
 		}
 		lsdl.CheckQuit(running, id)
+		if channels.quitting() {
+			running.UnSet()
+			break
+		}
 		updateMazeBackground(m, updateBG)
 		displayMaze(m, r)
 
@@ -373,18 +396,50 @@ func runMaze(m *maze.Maze, r *sdl.Renderer, w *sdl.Window, comm chan commandData
 	}
 
 	mazeMap.Delete(m.Config().GetId())
+	releaseMaze()
+	forgetMaze(m.Config().GetId())
 	log.Printf("maze is done, waiting for background threads to exit...")
 
 	// This causes a panic as the sender tries to send on this closed channel
 	// If it's not closed, it causes a race where the sender waits forever
 	// close(comm)
-	close(doneCh)
+	channels.watchers.publish(&pb.MazeEvent{Type: eventMazeDeleted})
+	close(channels.doneCh)
 
 	wd.Wait()
+	channels.stopOriginShift()
+	channels.stopDynamics()
 
 	log.Printf("and all done!")
 }
 
+// destroySDL destroys the window of the maze, and its background; m may be nil if the maze was never created
+func destroySDL(m *maze.Maze, r *sdl.Renderer, w *sdl.Window) {
+	if r == nil {
+		return
+	}
+	sdl.Do(func() {
+		log.Println("Destroying GUI window...")
+		if m != nil {
+			if bg := m.BGTexture(); bg != nil {
+				if err := bg.Destroy(); err != nil {
+					log.Printf("error destroying background: %v", err)
+				}
+			}
+		}
+
+		if err := r.Destroy(); err != nil {
+			log.Printf("error destroying window: %v", err)
+		}
+
+		if err := w.Destroy(); err != nil {
+			log.Printf("error destroying window: %v", err)
+		}
+
+		log.Println("Finished destroying GUI window...")
+	})
+}
+
 func updateMazeBackground(m *maze.Maze, updateBG *abool.AtomicBool) {
 	if updateBG.IsSet() {
 		if m.Config().GetGui() {
@@ -409,7 +464,7 @@ func displayMaze(m *maze.Maze, r *sdl.Renderer) {
 				os.Exit(1)
 			}
 
-			m.DrawMaze(r, m.BGTexture())
+			m.DrawMaze(lsdl.NewRenderer(r), m.BGTexture())
 
 			r.Present()
 			sdl.Delay(uint32(1000 / *frameRate))
@@ -419,9 +474,13 @@ func displayMaze(m *maze.Maze, r *sdl.Renderer) {
 	}
 }
 
-func checkComm(m *maze.Maze, comm commChannel, updateBG *abool.AtomicBool) {
+func checkComm(m *maze.Maze, channels *mazeChannels, updateBG *abool.AtomicBool) {
 	select {
-	case in := <-comm: // type == commandData
+	case in := <-channels.commCh: // type == commandData
+		if !observerCommands[in.Action] {
+			channels.touch()
+		}
+
 		switch in.Action {
 		case maze.CommandListClients:
 			start := time.Now()
@@ -452,6 +511,64 @@ func checkComm(m *maze.Maze, comm commChannel, updateBG *abool.AtomicBool) {
 
 			in.Reply <- commandReply{error: nil}
 
+			t.UpdateSince(start)
+		case maze.CommandRenderMaze:
+			start := time.Now()
+			t := metrics.GetOrRegisterTimer("maze.command.render-maze.latency", nil)
+
+			r := in.Request.request.(*pb.RenderMazeRequest)
+			opts := maze.RenderOptions{
+				ClientID:       r.GetClientId(),
+				DistanceColors: r.GetShowDistanceColors(),
+			}
+
+			var buf bytes.Buffer
+			if err := m.Render(&buf, r.GetFormat(), opts); err != nil {
+				in.Reply <- commandReply{error: fmt.Errorf("failed to render maze: %v", err)}
+				return
+			}
+
+			in.Reply <- commandReply{answer: buf.Bytes()}
+
+			t.UpdateSince(start)
+		case maze.CommandGetMaze:
+			start := time.Now()
+			t := metrics.GetOrRegisterTimer("maze.command.get-maze.latency", nil)
+
+			in.Reply <- commandReply{answer: m.Proto()}
+
+			t.UpdateSince(start)
+		case maze.CommandSaveMaze:
+			start := time.Now()
+			t := metrics.GetOrRegisterTimer("maze.command.save-maze.latency", nil)
+
+			in.Reply <- commandReply{answer: m.Proto()}
+
+			t.UpdateSince(start)
+		case maze.CommandTextMaze:
+			start := time.Now()
+			t := metrics.GetOrRegisterTimer("maze.command.text-maze.latency", nil)
+
+			r := in.Request.request.(*textRequest)
+			opts := maze.TextOptions{Clients: true, CellWidth: r.cellWidth}
+
+			var clients []string
+			for _, c := range m.ClientsSorted() {
+				clients = append(clients, c.ID())
+			}
+			if len(clients) > 0 {
+				opts.ClientID = clients[r.client%len(clients)]
+				opts.Path = true
+			}
+
+			text, err := m.Text(opts)
+			if err != nil {
+				in.Reply <- commandReply{error: fmt.Errorf("failed to draw maze as text: %v", err)}
+				return
+			}
+
+			in.Reply <- commandReply{answer: &textReply{text: text, clients: clients}}
+
 			t.UpdateSince(start)
 		case maze.CommandAddClient:
 			start := time.Now()
@@ -474,6 +591,7 @@ func checkComm(m *maze.Maze, comm commChannel, updateBG *abool.AtomicBool) {
 
 			// send reply via the reply channel
 			in.Reply <- commandReply{answer: l}
+			channels.watchers.publish(&pb.MazeEvent{Type: eventClientRegistered, ClientId: in.ClientID, Location: l.From, ToCell: l.To})
 			t.UpdateSince(start)
 		case maze.CommandResetClient:
 
@@ -500,6 +618,7 @@ func checkComm(m *maze.Maze, comm commChannel, updateBG *abool.AtomicBool) {
 
 			// send reply via the reply channel
 			in.Reply <- commandReply{answer: l}
+			channels.watchers.publish(&pb.MazeEvent{Type: eventClientReset, ClientId: in.ClientID, Location: l.From})
 		case maze.CommandGetDirections:
 			start := time.Now()
 			t := metrics.GetOrRegisterTimer("maze.command.get-direction.latency", nil)
@@ -510,6 +629,10 @@ func checkComm(m *maze.Maze, comm commChannel, updateBG *abool.AtomicBool) {
 				return
 
 			}
+			if client.CurrentLocation() == nil {
+				in.Reply <- commandReply{error: errNotPlaced(in.ClientID)}
+				return
+			}
 			in.Reply <- commandReply{answer: client.CurrentLocation().DirectionLinks(in.ClientID)}
 			t.UpdateSince(start)
 		case maze.CommandSetInitialClientLocation:
@@ -528,6 +651,7 @@ func checkComm(m *maze.Maze, comm commChannel, updateBG *abool.AtomicBool) {
 			s := maze.NewSegment(cell, "north", true)
 			cell.SetVisited(in.ClientID)
 			client.TravelPath.AddSegement(s)
+			channels.seen[in.ClientID] = channels.version
 			in.Reply <- commandReply{error: nil}
 			t.UpdateSince(start)
 		case maze.CommandCurrentLocation:
@@ -552,10 +676,15 @@ func checkComm(m *maze.Maze, comm commChannel, updateBG *abool.AtomicBool) {
 				in.Reply <- commandReply{error: fmt.Errorf("failed to get client location: %v", err)}
 				return
 			}
+			if client.CurrentLocation() == nil {
+				in.Reply <- commandReply{error: errNotPlaced(in.ClientID)}
+				return
+			}
 			info := &locationInfo{
 				current: client.CurrentLocation().Location(),
 				From:    m.FromCell(client).Location(),
 				To:      m.ToCell(client).Location(),
+				version: channels.version,
 			}
 			in.Reply <- commandReply{answer: info}
 			t.UpdateSince(start)
@@ -568,6 +697,11 @@ func checkComm(m *maze.Maze, comm commChannel, updateBG *abool.AtomicBool) {
 				in.Reply <- commandReply{error: fmt.Errorf("failed to find client: %v", err)}
 				return
 			}
+			if client.CurrentLocation() == nil {
+				in.Reply <- commandReply{error: errNotPlaced(in.ClientID),
+					answer: &moveReply{reward: -100, version: channels.version}}
+				return
+			}
 
 			// remove from solution if we are backtracking
 			client.TravelPath.LastSegment().RemoveFromSolution()
@@ -584,31 +718,22 @@ func checkComm(m *maze.Maze, comm commChannel, updateBG *abool.AtomicBool) {
 			last.SetVisited(in.ClientID)
 			client.SetCurrentLocation(last)
 
-			var facing string
-
-			switch {
-			case currentCell.North() == last:
-				facing = "north"
-			case currentCell.South() == last:
-				facing = "south"
-			case currentCell.West() == last:
-				facing = "west"
-			case currentCell.East() == last:
-				facing = "east"
-			}
+			facing := currentCell.GetFacingDirection(last)
 
 			// moving back, don't set this cell where we've been as solution
 			s := maze.NewSegment(client.CurrentLocation(), facing, false)
 			client.TravelPath.AddSegement(s)
 			m.SetClientPath(client)
 
-			in.Reply <- commandReply{
-				answer: &moveReply{
-					current:             client.CurrentLocation().Location(),
-					availableDirections: client.CurrentLocation().DirectionLinks(in.ClientID),
-					solved:              client.CurrentLocation().Location().String() == m.ToCell(client).Location().String(),
-				},
+			reply := &moveReply{
+				current:             client.CurrentLocation().Location(),
+				availableDirections: client.CurrentLocation().DirectionLinks(in.ClientID),
+				solved:              client.CurrentLocation().Location().String() == m.ToCell(client).Location().String(),
+				version:             channels.version,
+				mazeChanged:         channels.clientMazeChanged(in.ClientID),
 			}
+			in.Reply <- commandReply{answer: reply}
+			channels.watchers.publishMove(in.ClientID, reply, facing, true)
 			t.UpdateSince(start)
 
 		case maze.CommandMove:
@@ -619,6 +744,12 @@ func checkComm(m *maze.Maze, comm commChannel, updateBG *abool.AtomicBool) {
 			direction := r.request.(string)
 
 			client, err := m.MoveClient(in.ClientID, direction)
+			if client == nil {
+				// unknown client, or not placed yet: there is no location to report
+				in.Reply <- commandReply{error: fmt.Errorf("error moving: %v", err),
+					answer: &moveReply{reward: -100, version: channels.version}}
+				return
+			}
 			solved := client.CurrentLocation().Location().String() == m.ToCell(client).Location().String()
 
 			weight := float64(client.CurrentLocation().Weight())
@@ -630,6 +761,7 @@ func checkComm(m *maze.Maze, comm commChannel, updateBG *abool.AtomicBool) {
 			if solved {
 				reward = 0.0
 			}
+			mazeChanged := channels.clientMazeChanged(in.ClientID)
 
 			if err != nil {
 				in.Reply <- commandReply{
@@ -639,19 +771,23 @@ func checkComm(m *maze.Maze, comm commChannel, updateBG *abool.AtomicBool) {
 						availableDirections: client.CurrentLocation().DirectionLinks(in.ClientID),
 						solved:              solved,
 						reward:              -100, // invalid move, should be larger than any weight
+						version:             channels.version,
+						mazeChanged:         mazeChanged,
 					},
 				}
 				return
 			}
 
-			in.Reply <- commandReply{
-				answer: &moveReply{
-					current:             client.CurrentLocation().Location(),
-					availableDirections: client.CurrentLocation().DirectionLinks(in.ClientID),
-					solved:              solved,
-					reward:              reward,
-				},
+			reply := &moveReply{
+				current:             client.CurrentLocation().Location(),
+				availableDirections: client.CurrentLocation().DirectionLinks(in.ClientID),
+				solved:              solved,
+				reward:              reward,
+				version:             channels.version,
+				mazeChanged:         mazeChanged,
 			}
+			in.Reply <- commandReply{answer: reply}
+			channels.watchers.publishMove(in.ClientID, reply, direction, false)
 
 			t.UpdateSince(start)
 		default:
@@ -661,22 +797,56 @@ func checkComm(m *maze.Maze, comm commChannel, updateBG *abool.AtomicBool) {
 
 		// when the client disconnects, this will block until the timer fires
 		// if this is just a 'default' fall through, much cpu is used as multiple mazes are run
+	case <-channels.shifts():
+		shiftOrigin(m, channels, updateBG)
+	case now := <-channels.dynamicsTicks():
+		applyDynamics(m, channels, updateBG, now)
 	case <-time.After(5 * time.Second):
+	case <-channels.quitCh:
 
 	}
 }
 
 func runServer() {
-	lis, err := net.Listen("tcp", port)
+	opts, err := serverOptions()
+	if err != nil {
+		log.Fatalf("invalid server options: %v", err)
+	}
+	if err := checkHTTPTLS(); err != nil {
+		log.Fatalf("invalid server options: %v", err)
+	}
+	lis, err := net.Listen("tcp", *listenAddr)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
+	s := grpc.NewServer(opts...)
 	pb.RegisterMazerServer(s, &server{})
 	// Register reflection service on gRPC server.
 	reflection.Register(s)
 
-	log.Printf("server ready on port %v", port)
+	log.Printf("server ready on %v", *listenAddr)
+	if tokens != nil {
+		log.Printf("accepting %v auth tokens from %v", len(tokens), *authTokens)
+	}
+
+	if err := openStore(); err != nil {
+		log.Fatalf("failed to open store: %v", err)
+	}
+	if mazeStore != nil {
+		if err := restoreMazes(); err != nil {
+			log.Fatalf("failed to restore mazes: %v", err)
+		}
+		go saveMazes(*storeInterval)
+	}
+
+	if *httpAddr != "" {
+		go serveHTTP(*httpAddr)
+	}
+
+	if *mazeTTL > 0 {
+		log.Printf("deleting mazes unused for %v", *mazeTTL)
+		go reapMazes(*mazeTTL)
+	}
 
 	if *enableMonitoring {
 		log.Printf("starting metrics...")
@@ -721,6 +891,16 @@ func main() {
 		}
 	}()
 
+	if *termGUI {
+		f, err := os.OpenFile(*termGUILog, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			log.Fatalf("failed to open log file: %v", err)
+		}
+		log.Printf("terminal viewer enabled, logging to %v", *termGUILog)
+		log.SetOutput(f)
+		go runTermViewer()
+	}
+
 	// must be like this to keep drawing functions in main thread
 	runtime.LockOSThread()
 	sdl.Main(runServer)
@@ -753,6 +933,7 @@ type locationInfo struct {
 	current *pb.MazeLocation
 	From    *pb.MazeLocation
 	To      *pb.MazeLocation
+	version int64 // see mazeChannels.version
 }
 
 type moveReply struct {
@@ -760,13 +941,15 @@ type moveReply struct {
 	availableDirections []*pb.Direction
 	solved              bool
 	reward              float64
+	version             int64 // see mazeChannels.version
+	mazeChanged         bool  // the maze changed since the client was last told
 }
 
 // server is used to implement MazerServer.
 type server struct{}
 
 // ExportMaze exports the given maze to disk, only the structure is preserved
-func (s *server) ExportMaze(_ context.Context, in *pb.ExportMazeRequest) (*pb.ExportMazeReply, error) {
+func (s *server) ExportMaze(ctx context.Context, in *pb.ExportMazeRequest) (*pb.ExportMazeReply, error) {
 	log.Printf("exporting maze with id: %v", in.GetMazeId())
 	if in.GetMazeId() == "" {
 		return nil, fmt.Errorf("maze id cannot be empty")
@@ -779,16 +962,16 @@ func (s *server) ExportMaze(_ context.Context, in *pb.ExportMazeRequest) (*pb.Ex
 	if !found {
 		return &pb.ExportMazeReply{Success: false, Message: fmt.Sprintf("unable to lookup maze [%v]", in.GetMazeId())}, nil
 	}
-
-	comm := channels.(*mazeChannels).commCh
+	if err := checkMazeOwner(ctx, channels.(*mazeChannels)); err != nil {
+		return nil, err
+	}
 
 	data := commandData{
 		Action: maze.CommandExportMaze,
 		Reply:  make(chan commandReply),
 	}
-	comm <- data
 	// get response from maze
-	reply := <-data.Reply
+	reply := channels.(*mazeChannels).send(data)
 	if reply.error != nil {
 		return &pb.ExportMazeReply{Success: false, Message: reply.error.(error).Error()}, nil
 	}
@@ -796,6 +979,90 @@ func (s *server) ExportMaze(_ context.Context, in *pb.ExportMazeRequest) (*pb.Ex
 	return &pb.ExportMazeReply{Success: true}, nil
 }
 
+// RenderMaze returns a snapshot of the maze as a PNG or SVG image
+func (s *server) RenderMaze(ctx context.Context, in *pb.RenderMazeRequest) (*pb.RenderMazeReply, error) {
+	log.Printf("rendering maze with id: %v", in.GetMazeId())
+	if in.GetMazeId() == "" {
+		return nil, fmt.Errorf("maze id cannot be empty")
+	}
+
+	t := metrics.GetOrRegisterTimer("maze.rpc.render-maze.latency", nil)
+	defer t.UpdateSince(time.Now())
+
+	channels, found := mazeMap.Find(in.GetMazeId())
+	if !found {
+		return &pb.RenderMazeReply{Success: false, Message: fmt.Sprintf("unable to lookup maze [%v]", in.GetMazeId())}, nil
+	}
+	if err := checkMazeOwner(ctx, channels.(*mazeChannels)); err != nil {
+		return nil, err
+	}
+
+	data := commandData{
+		Action:  maze.CommandRenderMaze,
+		Request: commandRequest{request: in},
+		Reply:   make(chan commandReply),
+	}
+	// get response from maze
+	reply := channels.(*mazeChannels).send(data)
+	if reply.error != nil {
+		return &pb.RenderMazeReply{Success: false, Message: reply.error.(error).Error()}, nil
+	}
+
+	return &pb.RenderMazeReply{Success: true, Image: reply.answer.([]byte)}, nil
+}
+
+// GetMaze returns the full structure of an existing maze, so clients can rebuild it locally
+func (s *server) GetMaze(ctx context.Context, in *pb.GetMazeRequest) (*pb.GetMazeReply, error) {
+	log.Printf("getting maze with id: %v", in.GetMazeId())
+	if in.GetMazeId() == "" {
+		return nil, fmt.Errorf("maze id cannot be empty")
+	}
+
+	t := metrics.GetOrRegisterTimer("maze.rpc.get-maze.latency", nil)
+	defer t.UpdateSince(time.Now())
+
+	channels, found := mazeMap.Find(in.GetMazeId())
+	if !found {
+		return &pb.GetMazeReply{Success: false, Message: fmt.Sprintf("unable to lookup maze [%v]", in.GetMazeId())}, nil
+	}
+	if err := checkMazeOwner(ctx, channels.(*mazeChannels)); err != nil {
+		return nil, err
+	}
+
+	data := commandData{
+		Action: maze.CommandGetMaze,
+		Reply:  make(chan commandReply),
+	}
+	// get response from maze
+	reply := channels.(*mazeChannels).send(data)
+	if reply.error != nil {
+		return &pb.GetMazeReply{Success: false, Message: reply.error.(error).Error()}, nil
+	}
+
+	return &pb.GetMazeReply{Success: true, Maze: reply.answer.(*pb.Maze)}, nil
+}
+
+// DeleteMaze deletes a maze, its clients' SolveMaze streams end with an error
+func (s *server) DeleteMaze(ctx context.Context, in *pb.DeleteMazeRequest) (*pb.DeleteMazeReply, error) {
+	log.Printf("deleting maze with id: %v", in.GetMazeId())
+	if in.GetMazeId() == "" {
+		return nil, fmt.Errorf("maze id cannot be empty")
+	}
+
+	t := metrics.GetOrRegisterTimer("maze.rpc.delete-maze.latency", nil)
+	defer t.UpdateSince(time.Now())
+
+	if channels, found := mazeMap.Find(in.GetMazeId()); found {
+		if err := checkMazeOwner(ctx, channels.(*mazeChannels)); err != nil {
+			return nil, err
+		}
+	}
+	if err := deleteMaze(in.GetMazeId()); err != nil {
+		return &pb.DeleteMazeReply{Success: false, Message: err.Error()}, nil
+	}
+	return &pb.DeleteMazeReply{Success: true}, nil
+}
+
 // mazeChannels holds the comm channels
 type mazeChannels struct {
 	// commCh is used to send data commands to the maze
@@ -803,17 +1070,172 @@ type mazeChannels struct {
 
 	// doneChe is used to signal, from the maze, that it is exiting
 	doneCh chan bool
+
+	// quitCh is closed to tell the maze to exit, see quit()
+	quitCh   chan bool
+	quitOnce sync.Once
+
+	lastUsed int64 // unix nanoseconds of the last command that used the maze, atomic
+	streams  int64 // number of SolveMaze streams in progress, atomic
+	changed  int32 // 1 if the maze may have changed since it was last saved (see store), atomic
+
+	solverRuns int64 // number of RunSolver calls, each run seeds its random numbers with the maze seed plus this, atomic
+
+	// originShift changes the maze at every tick of shiftTicker, both nil if the maze does not change (see startOriginShift)
+	originShift *origin_shift.Tree
+	shiftTicker *time.Ticker
+
+	// dynamics changes the maze at every tick of dynamicsTicker, both nil if the maze does not change (see startDynamics)
+	dynamics       *dynamics.Dynamics
+	dynamicsTicker *time.Ticker
+
+	// version goes up every time the maze changes, seen is the version each client was last told about,
+	// client id -> version (see mazeChanged); only used by the maze, in checkComm
+	version int64
+	seen    map[string]int64
+
+	// watchers get the events of the maze, see WatchMaze
+	watchers *watchers
+
+	// owner is the owner of the auth token that created the maze, owners the one that registered each client,
+	// client id -> owner (see auth.go)
+	owner  string
+	owners sync.Map
+}
+
+func newMazeChannels(mazeID, owner string, comm chan commandData, done chan bool) *mazeChannels {
+	mc := &mazeChannels{
+		commCh:   comm,
+		doneCh:   done,
+		quitCh:   make(chan bool),
+		owner:    owner,
+		watchers: newWatchers(mazeID, owner),
+		seen:     make(map[string]int64),
+	}
+	mc.touch()
+	return mc
+}
+
+// send sends data to the maze and returns its reply, the reply has an error if the maze exited
+func (mc *mazeChannels) send(data commandData) commandReply {
+	select {
+	case <-mc.doneCh:
+		return commandReply{error: fmt.Errorf("maze exited")}
+	case mc.commCh <- data:
+	}
+	return <-data.Reply
+}
+
+// quit tells the maze to exit, it is safe to call more than once
+func (mc *mazeChannels) quit() {
+	mc.quitOnce.Do(func() {
+		close(mc.quitCh)
+	})
+}
+
+// quitting returns true once quit() is called
+func (mc *mazeChannels) quitting() bool {
+	select {
+	case <-mc.quitCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// touch marks the maze as used now, and as changed
+func (mc *mazeChannels) touch() {
+	atomic.StoreInt64(&mc.lastUsed, time.Now().UnixNano())
+	atomic.StoreInt32(&mc.changed, 1)
+}
+
+// idle returns how long the maze has not been used for, mazes being solved are never idle
+func (mc *mazeChannels) idle() time.Duration {
+	if atomic.LoadInt64(&mc.streams) > 0 {
+		return 0
+	}
+	return time.Since(time.Unix(0, atomic.LoadInt64(&mc.lastUsed)))
+}
+
+// observerCommands only look at the maze, they do not count as using it (see maze_ttl)
+var observerCommands = map[commandAction]bool{
+	maze.CommandListClients: true,
+	maze.CommandTextMaze:    true,
+	maze.CommandSaveMaze:    true,
+}
+
+// mazeCount is the number of mazes running or being started, atomic (see reserveMaze)
+var mazeCount int64
+
+// reserveMaze counts a new maze, or returns an error if there are --max_mazes already
+// Every maze that is reserved calls releaseMaze once it is gone, or failed to start.
+func reserveMaze() error {
+	for {
+		n := atomic.LoadInt64(&mazeCount)
+		if *maxMazes > 0 && n >= int64(*maxMazes) {
+			return fmt.Errorf("too many mazes (max_mazes=%v), delete some first", *maxMazes)
+		}
+		if atomic.CompareAndSwapInt64(&mazeCount, n, n+1) {
+			return nil
+		}
+	}
+}
+
+// releaseMaze stops counting a maze, see reserveMaze
+func releaseMaze() {
+	atomic.AddInt64(&mazeCount, -1)
+}
+
+// abortMaze removes a maze that failed to start, like runMaze does when a maze exits
+// Callers waiting on it (send, deleteMaze, watchers) see it exit.
+func abortMaze(id string, channels *mazeChannels) {
+	mazeMap.Delete(id)
+	releaseMaze()
+	channels.quit()
+	channels.stopOriginShift()
+	channels.stopDynamics()
+	channels.watchers.publish(&pb.MazeEvent{Type: eventMazeDeleted})
+	close(channels.doneCh)
 }
 
-func newMazeChannels(comm chan commandData, done chan bool) *mazeChannels {
-	return &mazeChannels{
-		commCh: comm,
-		doneCh: done,
+// deleteMaze tells the maze with id to exit and waits until it is gone
+func deleteMaze(id string) error {
+	channels, found := mazeMap.Find(id)
+	if !found {
+		return fmt.Errorf("unable to lookup maze [%v]", id)
+	}
+	mc := channels.(*mazeChannels)
+
+	mc.quit()
+	<-mc.doneCh
+	return nil
+}
+
+// reapMazes deletes mazes that have not been used for longer than ttl, it never returns
+func reapMazes(ttl time.Duration) {
+	interval := ttl / 2
+	if interval > time.Minute {
+		interval = time.Minute
+	}
+
+	for range time.Tick(interval) {
+		for _, id := range mazeMap.Keys() {
+			channels, found := mazeMap.Find(id)
+			if !found {
+				continue
+			}
+			if idle := channels.(*mazeChannels).idle(); idle > ttl {
+				log.Printf("deleting maze %v, unused for %v", id, idle)
+				if err := deleteMaze(id); err != nil {
+					log.Printf("error deleting maze: %v", err)
+				}
+			}
+		}
 	}
 }
 
 // CreateMaze creates and displays the maze specified by the config
-func (s *server) CreateMaze(_ context.Context, in *pb.CreateMazeRequest) (*pb.CreateMazeReply, error) {
+func (s *server) CreateMaze(ctx context.Context, in *pb.CreateMazeRequest) (*pb.CreateMazeReply, error) {
 	log.Printf("creating maze with config: %#v", in.Config)
 	if in.Config == nil {
 		return nil, fmt.Errorf("maze config cannot be nil")
@@ -822,29 +1244,59 @@ func (s *server) CreateMaze(_ context.Context, in *pb.CreateMazeRequest) (*pb.Cr
 	t := metrics.GetOrRegisterTimer("maze.rpc.create-maze.latency", nil)
 	defer t.UpdateSince(time.Now())
 
+	m, err := startMaze(in.GetConfig(), "", callerOwner(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Config.ReturnMaze {
+		log.Printf("client requested maze in the response!")
+	}
+	return &pb.CreateMazeReply{MazeId: m.Config().GetId(), EncodedMaze: m.EncodedString()}, nil
+}
+
+// startMaze creates a new maze (see createMaze) for owner, registers it under a new id and starts running it
+// The maze is registered while it is generated, so it can be watched; if it fails, it is removed again.
+func startMaze(config *pb.MazeConfig, encoded, owner string) (*maze.Maze, error) {
+	if err := reserveMaze(); err != nil {
+		return nil, err
+	}
+
 	var mazeID string
 	mazeIDraw := uuid.NewV4()
 	mazeID = mazeIDraw.String()
-	in.GetConfig().Id = mazeID
+	config.Id = mazeID
 
-	commCh := make(chan commandData)
-	doneCh := make(chan bool)
-	mazeMap.Insert(mazeID, newMazeChannels(commCh, doneCh))
+	channels := newMazeChannels(mazeID, owner, make(chan commandData), make(chan bool))
+	mazeMap.Insert(mazeID, channels)
 
-	m, r, w, err := createMaze(in.Config)
+	m, r, w, err := createMaze(config, encoded, channels.watchers)
 	if err != nil {
+		abortMaze(mazeID, channels)
 		return nil, err
 	}
-	go runMaze(m, r, w, commCh, doneCh)
+	if err := channels.startOriginShift(m); err != nil {
+		destroySDL(m, r, w)
+		abortMaze(mazeID, channels)
+		return nil, fmt.Errorf("cannot shift the origin of the maze: %v", err)
+	}
+	if err := channels.startDynamics(m); err != nil {
+		destroySDL(m, r, w)
+		abortMaze(mazeID, channels)
+		return nil, fmt.Errorf("cannot change the maze: %v", err)
+	}
+	go runMaze(m, r, w, channels)
 
-	if in.Config.ReturnMaze {
-		log.Printf("client requested maze in the response!")
+	if mazeStore != nil {
+		if err := saveMaze(mazeID); err != nil {
+			log.Printf("error saving maze %v: %v", mazeID, err)
+		}
 	}
-	return &pb.CreateMazeReply{MazeId: mazeID, EncodedMaze: m.EncodedString()}, nil
+	return m, nil
 }
 
 // RegisterClient registers a new client with an existing maze
-func (s *server) RegisterClient(_ context.Context, in *pb.RegisterClientRequest) (*pb.RegisterClientReply, error) {
+func (s *server) RegisterClient(ctx context.Context, in *pb.RegisterClientRequest) (*pb.RegisterClientReply, error) {
 	log.Printf("associating new client with maze: %#v", in.GetMazeId())
 	t := metrics.GetOrRegisterTimer("maze.rpc.register-client.latency", nil)
 	defer t.UpdateSince(time.Now())
@@ -856,8 +1308,9 @@ func (s *server) RegisterClient(_ context.Context, in *pb.RegisterClientRequest)
 	if !found {
 		return &pb.RegisterClientReply{Success: false, Message: fmt.Sprintf("unable to lookup maze [%v]", in.GetMazeId())}, nil
 	}
-
-	comm := channels.(*mazeChannels).commCh
+	if err := checkMazeOwner(ctx, channels.(*mazeChannels)); err != nil {
+		return nil, err
+	}
 
 	data := commandData{
 		Action:       maze.CommandAddClient,
@@ -865,13 +1318,14 @@ func (s *server) RegisterClient(_ context.Context, in *pb.RegisterClientRequest)
 		ClientConfig: in.GetClientConfig(),
 		Reply:        make(chan commandReply),
 	}
-	comm <- data
 	// get response from maze
-	reply := <-data.Reply
+	reply := channels.(*mazeChannels).send(data)
 	if reply.error != nil {
 		return &pb.RegisterClientReply{Success: false, Message: reply.error.(error).Error()}, nil
 	}
 
+	channels.(*mazeChannels).owners.Store(clientID, callerOwner(ctx))
+
 	locationInfo := reply.answer.(*locationInfo)
 	return &pb.RegisterClientReply{Success: true, ClientId: clientID,
 		FromCell: locationInfo.From, ToCell: locationInfo.To}, nil
@@ -879,7 +1333,7 @@ func (s *server) RegisterClient(_ context.Context, in *pb.RegisterClientRequest)
 }
 
 // ResetClient resets an existing client in an existing maze
-func (s *server) ResetClient(_ context.Context, in *pb.ResetClientRequest) (*pb.ResetClientReply, error) {
+func (s *server) ResetClient(ctx context.Context, in *pb.ResetClientRequest) (*pb.ResetClientReply, error) {
 	// log.Printf("resetting client [%v] in maze [%#v]", in.GetClientId(), in.GetMazeId())
 
 	clientID := in.GetClientId()
@@ -890,17 +1344,17 @@ func (s *server) ResetClient(_ context.Context, in *pb.ResetClientRequest) (*pb.
 			Success: false,
 			Message: fmt.Sprintf("unable to lookup maze [%v]", in.GetMazeId())}, nil
 	}
-
-	comm := channels.(*mazeChannels).commCh
+	if err := checkOwner(ctx, channels.(*mazeChannels), clientID); err != nil {
+		return nil, err
+	}
 
 	data := commandData{
 		Action:   maze.CommandResetClient,
 		ClientID: clientID,
 		Reply:    make(chan commandReply),
 	}
-	comm <- data
 	// get response from maze
-	reply := <-data.Reply
+	reply := channels.(*mazeChannels).send(data)
 	if reply.error != nil {
 		return &pb.ResetClientReply{Success: false, Message: reply.error.(error).Error()}, nil
 	}
@@ -912,8 +1366,8 @@ func (s *server) ResetClient(_ context.Context, in *pb.ResetClientRequest) (*pb.
 
 }
 
-// ListMazes lists all the mazes
-func (s *server) ListMazes(_ context.Context, _ *pb.ListMazeRequest) (*pb.ListMazeReply, error) {
+// ListMazes lists all the mazes the caller can use
+func (s *server) ListMazes(ctx context.Context, _ *pb.ListMazeRequest) (*pb.ListMazeReply, error) {
 	t := metrics.GetOrRegisterTimer("maze.rpc.list-mazes.latency", nil)
 	defer t.UpdateSince(time.Now())
 
@@ -923,22 +1377,22 @@ func (s *server) ListMazes(_ context.Context, _ *pb.ListMazeRequest) (*pb.ListMa
 
 		channels, found := mazeMap.Find(k)
 		if !found {
-			// should never happen
-			return nil, fmt.Errorf("failed to find maze with id %v", k)
+			// deleted since listing the keys
+			continue
+		}
+		if !allowed(channels.(*mazeChannels).owner, callerOwner(ctx)) {
+			continue
 		}
 
-		// comm is the channel to talk to the maze
-		comm := channels.(*mazeChannels).commCh
-		replyChannel := make(chan commandReply)
 		data := commandData{
 			Action: maze.CommandListClients,
-			Reply:  replyChannel,
+			Reply:  make(chan commandReply),
+		}
+		// send request to maze, receive reply from maze, blocking
+		mazeReply := channels.(*mazeChannels).send(data)
+		if mazeReply.error != nil {
+			continue
 		}
-		// send request to maze
-		comm <- data
-
-		// receive reply from maze, blocking
-		mazeReply := <-replyChannel
 		// maze reply, in this case a []string, client IDs
 		m.ClientIds = mazeReply.answer.([]string)
 
@@ -970,13 +1424,117 @@ func (s *server) SolveMaze(stream pb.Mazer_SolveMazeServer) error {
 		return fmt.Errorf("unable to lookup maze [%v]: %v", in.GetMazeId(), err)
 	}
 
-	// commCh is the communication channel with this maze
-	commCh := channels.(*mazeChannels).commCh
-	// doneCh is closed when the maze goroutine exits
-	doneCh := channels.(*mazeChannels).doneCh
+	mc := channels.(*mazeChannels)
+	atomic.AddInt64(&mc.streams, 1)
+	defer atomic.AddInt64(&mc.streams, -1)
 
 	// check that client is valid
+	if err := checkOwner(stream.Context(), mc, in.GetClientId()); err != nil {
+		return err
+	}
 
+	reply, err := initialSolveResponse(mc, in)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(reply); err != nil {
+		return err
+	}
+
+	trpc := metrics.GetOrRegisterTimer("maze.rpc.solve-maze-loop.latency", nil)
+
+	// receive in the background, so the stream ends as soon as the maze exits, even while waiting for the client
+	requests := make(chan *pb.SolveMazeRequest)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			in, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case requests <- in:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	var solveErr error
+	// this is the main loop as the client tries to solve the maze
+SOLVE:
+	for {
+		var in *pb.SolveMazeRequest
+		select {
+		case <-mc.doneCh:
+			solveErr = fmt.Errorf("maze exited during solve")
+			break SOLVE
+		case err := <-recvErr:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case in = <-requests:
+		}
+		start := time.Now()
+
+		if in.Initial {
+			// client is mis-behaving!
+			r := &pb.SolveMazeResponse{
+				Error:        true,
+				ErrorMessage: fmt.Sprintf("received initial on subsequent request"),
+			}
+			if err := stream.Send(r); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := checkOwner(stream.Context(), mc, in.GetClientId()); err != nil {
+			r := &pb.SolveMazeResponse{
+				Error:        true,
+				ErrorMessage: err.Error(),
+			}
+			if err := stream.Send(r); err != nil {
+				return err
+			}
+			continue
+		}
+
+		r, err := moveResponse(mc, in)
+		if err == errMazeExited {
+			solveErr = fmt.Errorf("maze exited during solve")
+			break SOLVE
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(r); err != nil {
+			return err
+		}
+
+		trpc.UpdateSince(start)
+	}
+
+	if solveErr != nil {
+		log.Printf("solve for loop error: %v", solveErr)
+	}
+
+	log.Println("SolveMaze exited...")
+	return solveErr
+}
+
+// errNotPlaced is the error of commands for a client that has no location yet, see initialSolveResponse
+func errNotPlaced(clientID string) error {
+	return fmt.Errorf("client %v is not in the maze yet, send the initial request first", clientID)
+}
+
+// errMazeExited is returned by moveResponse when the maze exits before it answers
+var errMazeExited = errors.New("maze exited")
+
+// initialSolveResponse puts the client on its from cell and returns where it is and where it can go from there
+func initialSolveResponse(mc *mazeChannels, in *pb.SolveMazeRequest) (*pb.SolveMazeResponse, error) {
 	// set client initial location
 	data := commandData{
 		Action:   maze.CommandSetInitialClientLocation,
@@ -985,15 +1543,9 @@ func (s *server) SolveMaze(stream pb.Mazer_SolveMazeServer) error {
 		Request:  commandRequest{},
 	}
 
-	select {
-	case <-doneCh:
-		return fmt.Errorf("maze exited")
-	case commCh <- data:
-	}
-
-	initialLocationReply := <-data.Reply
+	initialLocationReply := mc.send(data)
 	if initialLocationReply.error != nil {
-		return initialLocationReply.error.(error)
+		return nil, initialLocationReply.error.(error)
 	}
 
 	// send request into commChannel for available directions, include client id
@@ -1002,15 +1554,10 @@ func (s *server) SolveMaze(stream pb.Mazer_SolveMazeServer) error {
 		ClientID: in.GetClientId(),
 		Reply:    make(chan commandReply),
 	}
-	select {
-	case <-doneCh:
-		return fmt.Errorf("maze exited")
-	case commCh <- data:
-	}
 	// get response from maze
-	dirReply := <-data.Reply
+	dirReply := mc.send(data)
 	if dirReply.error != nil {
-		return dirReply.error.(error)
+		return nil, dirReply.error.(error)
 	}
 
 	// send request into commChannel for current location
@@ -1019,20 +1566,15 @@ func (s *server) SolveMaze(stream pb.Mazer_SolveMazeServer) error {
 		ClientID: in.GetClientId(),
 		Reply:    make(chan commandReply),
 	}
-	select {
-	case <-doneCh:
-		return fmt.Errorf("maze exited")
-	case commCh <- data:
-	}
 	// get current location from maze
-	locationInfoReply := <-data.Reply
+	locationInfoReply := mc.send(data)
 	if locationInfoReply.error != nil {
-		return locationInfoReply.error.(error)
+		return nil, locationInfoReply.error.(error)
 	}
 	locationInfo := locationInfoReply.answer.(*locationInfo)
 
 	// return available directions and current location
-	reply := &pb.SolveMazeResponse{
+	return &pb.SolveMazeResponse{
 		Initial:             true,
 		MazeId:              in.GetMazeId(),
 		ClientId:            in.ClientId,
@@ -1040,110 +1582,65 @@ func (s *server) SolveMaze(stream pb.Mazer_SolveMazeServer) error {
 		CurrentLocation:     locationInfo.current,
 		FromCell:            locationInfo.From,
 		ToCell:              locationInfo.To,
-	}
-	if err := stream.Send(reply); err != nil {
-		return err
-	}
-
-	trpc := metrics.GetOrRegisterTimer("maze.rpc.solve-maze-loop.latency", nil)
-
-	var solveErr error
-	// this is the main loop as the client tries to solve the maze
-SOLVE:
-	for {
-		start := time.Now()
-
-		in, err := stream.Recv()
-		if err == io.EOF {
-			return nil
-		}
-		if err != nil {
-			return err
-		}
-
-		if in.Initial {
-			// client is mis-behaving!
-			r := &pb.SolveMazeResponse{
-				Error:        true,
-				ErrorMessage: fmt.Sprintf("received initial on subsequent request"),
-			}
-			if err := stream.Send(r); err != nil {
-				return err
-			}
-			continue
-		}
-
-		var action commandAction
-		if in.GetMoveBack() {
-			action = maze.CommandMoveBack
-		} else {
-			action = maze.CommandMove
-		}
-
-		commStart := time.Now()
-		tcomm := metrics.GetOrRegisterTimer("maze.rpc.solve-maze-loop-comm.latency", nil)
-		data = commandData{
-			Action:   action,
-			ClientID: in.ClientId,
-			Request: commandRequest{
-				request: in.GetDirection(), // which way to move
-			},
-			Reply: make(chan commandReply),
-		}
+		MazeVersion:         locationInfo.version,
+	}, nil
+}
 
-		// There is a race here with the maze goroutine closing that is now fixed
-		// This thread is the producer, so it should be responsible for closing the channel
-		select {
-		case <-doneCh:
-			solveErr = fmt.Errorf("maze exited during solve")
-			break SOLVE
-		case commCh <- data:
-		}
+// moveResponse moves the client (or back) and returns the result, an invalid move is an error in the response
+// The returned error is errMazeExited if the maze exited.
+func moveResponse(mc *mazeChannels, in *pb.SolveMazeRequest) (*pb.SolveMazeResponse, error) {
+	var action commandAction
+	if in.GetMoveBack() {
+		action = maze.CommandMoveBack
+	} else {
+		action = maze.CommandMove
+	}
 
-		// get response from maze
-		mazeReply := <-data.Reply
-		if mazeReply.answer == nil {
-			return fmt.Errorf("moveReply was nil")
-		}
-		moveReply := mazeReply.answer.(*moveReply)
+	commStart := time.Now()
+	tcomm := metrics.GetOrRegisterTimer("maze.rpc.solve-maze-loop-comm.latency", nil)
+	data := commandData{
+		Action:   action,
+		ClientID: in.ClientId,
+		Request: commandRequest{
+			request: in.GetDirection(), // which way to move
+		},
+		Reply: make(chan commandReply),
+	}
 
-		if err := mazeReply.error; err != nil {
-			r := &pb.SolveMazeResponse{
-				Error:               true,
-				ErrorMessage:        err.(error).Error(),
-				MazeId:              in.GetMazeId(),
-				ClientId:            in.ClientId,
-				CurrentLocation:     moveReply.current,
-				AvailableDirections: moveReply.availableDirections,
-				Solved:              moveReply.solved,
-				Reward:              moveReply.reward,
-			}
-			if err := stream.Send(r); err != nil {
-				return err
-			}
-			continue
+	// get response from maze, the reply has only an error if the maze exited
+	mazeReply := mc.send(data)
+	if mazeReply.answer == nil {
+		if mazeReply.error != nil {
+			return nil, errMazeExited
 		}
-		tcomm.UpdateSince(commStart)
+		return nil, fmt.Errorf("moveReply was nil")
+	}
+	moveReply := mazeReply.answer.(*moveReply)
 
-		r := &pb.SolveMazeResponse{
+	if err := mazeReply.error; err != nil {
+		return &pb.SolveMazeResponse{
+			Error:               true,
+			ErrorMessage:        err.(error).Error(),
 			MazeId:              in.GetMazeId(),
 			ClientId:            in.ClientId,
 			CurrentLocation:     moveReply.current,
 			AvailableDirections: moveReply.availableDirections,
 			Solved:              moveReply.solved,
 			Reward:              moveReply.reward,
-		}
-		if err := stream.Send(r); err != nil {
-			return err
-		}
-
-		trpc.UpdateSince(start)
-	}
-
-	if solveErr != nil {
-		log.Printf("solve for loop error: %v", solveErr)
+			MazeVersion:         moveReply.version,
+			MazeChanged:         moveReply.mazeChanged,
+		}, nil
 	}
+	tcomm.UpdateSince(commStart)
 
-	log.Println("SolveMaze exited...")
-	return solveErr
+	return &pb.SolveMazeResponse{
+		MazeId:              in.GetMazeId(),
+		ClientId:            in.ClientId,
+		CurrentLocation:     moveReply.current,
+		AvailableDirections: moveReply.availableDirections,
+		Solved:              moveReply.solved,
+		Reward:              moveReply.reward,
+		MazeVersion:         moveReply.version,
+		MazeChanged:         moveReply.mazeChanged,
+	}, nil
 }
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+// withMaxMazes sets --max_mazes for the length of a test
+func withMaxMazes(t *testing.T, n int) {
+	old := *maxMazes
+	*maxMazes = n
+	t.Cleanup(func() { *maxMazes = old })
+}
+
+func TestStartMazeFailures(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		config *pb.MazeConfig
+	}{
+		{
+			name:   "invalid create algorithm",
+			config: &pb.MazeConfig{Rows: 5, Columns: 5, CreateAlgo: "nope"},
+		}, {
+			name:   "square only generator on a hex grid",
+			config: &pb.MazeConfig{Rows: 5, Columns: 5, CreateAlgo: "bintree", GridType: "hex"},
+		}, {
+			name:   "invalid grid",
+			config: &pb.MazeConfig{Rows: 5, Columns: 5, CreateAlgo: "prim", GridType: "round"},
+		}, {
+			name:   "origin shift on a maze that is not perfect",
+			config: &pb.MazeConfig{Rows: 5, Columns: 5, CreateAlgo: "full", OriginShiftRate: 1},
+		},
+	} {
+		before := len(mazeMap.Keys())
+		if _, err := startMaze(tt.config, "", ""); err == nil {
+			t.Errorf("%v: startMaze() should have failed", tt.name)
+			continue
+		}
+		if n := len(mazeMap.Keys()); n != before {
+			t.Errorf("%v: %v mazes after the failure, want %v", tt.name, n, before)
+		}
+		if _, found := mazeMap.Find(tt.config.GetId()); found {
+			t.Errorf("%v: failed maze %v is still registered", tt.name, tt.config.GetId())
+		}
+	}
+
+	// the failures do not use up --max_mazes
+	withMaxMazes(t, 1)
+	if err := reserveMaze(); err != nil {
+		t.Errorf("failed mazes were counted: %v", err)
+	} else {
+		releaseMaze()
+	}
+}
+
+func TestAbortMaze(t *testing.T) {
+	channels := newMazeChannels("aborted", "", make(chan commandData), make(chan bool))
+	mazeMap.Insert("aborted", channels)
+	if err := reserveMaze(); err != nil {
+		t.Fatalf("reserveMaze() = %v", err)
+	}
+	w := channels.watchers.add("")
+
+	// a command sent while the maze is being created must not wait forever
+	replies := make(chan commandReply)
+	go func() {
+		replies <- channels.send(commandData{Reply: make(chan commandReply)})
+	}()
+
+	abortMaze("aborted", channels)
+
+	select {
+	case reply := <-replies:
+		if reply.error == nil {
+			t.Errorf("send() to an aborted maze should fail")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("send() to an aborted maze is stuck")
+	}
+
+	if _, found := mazeMap.Find("aborted"); found {
+		t.Errorf("aborted maze is still registered")
+	}
+	if err := deleteMaze("aborted"); err == nil {
+		t.Errorf("deleteMaze() of an aborted maze should fail")
+	}
+	if e := <-w.events; e.GetType() != eventMazeDeleted {
+		t.Errorf("watcher got %v, want %v", e.GetType(), eventMazeDeleted)
+	}
+}
+
+func TestReserveMaze(t *testing.T) {
+	withMaxMazes(t, 10)
+
+	var reserved int64
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if reserveMaze() == nil {
+				lock.Lock()
+				reserved++
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if reserved != 10 {
+		t.Errorf("%v mazes reserved at the same time, want --max_mazes=10", reserved)
+	}
+	for i := int64(0); i < reserved; i++ {
+		releaseMaze()
+	}
+	if err := reserveMaze(); err != nil {
+		t.Errorf("reserveMaze() after releasing all = %v", err)
+	}
+	releaseMaze()
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/rcrowley/go-metrics"
+	"github.com/tevino/abool"
+
+	"github.com/DanTulovsky/mazes/genalgos/origin_shift"
+	"github.com/DanTulovsky/mazes/maze"
+)
+
+// startOriginShift starts changing m, while it is live, at the rate set by its config (see MazeConfig.OriginShiftRate)
+// The steps run in checkComm, between the commands of the clients.
+func (mc *mazeChannels) startOriginShift(m *maze.Maze) error {
+	rate := m.Config().GetOriginShiftRate()
+	if rate <= 0 {
+		return nil
+	}
+
+	tree, err := origin_shift.NewTree(m)
+	if err != nil {
+		return err
+	}
+
+	interval := time.Duration(float64(time.Second) / rate)
+	if interval < time.Millisecond {
+		interval = time.Millisecond
+	}
+	log.Printf("shifting the origin of maze %v every %v", m.Config().GetId(), interval)
+
+	mc.originShift = tree
+	mc.shiftTicker = time.NewTicker(interval)
+	return nil
+}
+
+// stopOriginShift stops changing the maze
+func (mc *mazeChannels) stopOriginShift() {
+	if mc.shiftTicker != nil {
+		mc.shiftTicker.Stop()
+	}
+}
+
+// shifts returns the channel that ticks when it is time for the next origin shift, nil if the maze does not change
+func (mc *mazeChannels) shifts() <-chan time.Time {
+	if mc.shiftTicker == nil {
+		return nil
+	}
+	return mc.shiftTicker.C
+}
+
+// shiftOrigin moves the origin of m one step and tells the watchers which cells changed
+func shiftOrigin(m *maze.Maze, channels *mazeChannels, updateBG *abool.AtomicBool) {
+	start := time.Now()
+	t := metrics.GetOrRegisterTimer("maze.origin-shift.latency", nil)
+	defer t.UpdateSince(start)
+
+	before := channels.originShift.Root()
+	root, unlinked := channels.originShift.Shift()
+	if unlinked == nil {
+		return // the passage moved back the way it came, nothing changed
+	}
+
+	mazeChanged(channels, updateBG, root.Location(), []*maze.Cell{before, root, unlinked})
+}
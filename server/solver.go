@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/rcrowley/go-metrics"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/DanTulovsky/mazes/algos"
+	"github.com/DanTulovsky/mazes/maze"
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+// clientOnlySolveAlgos cannot run in the server: manual needs a human at a terminal, follow-policy the client's policy
+var clientOnlySolveAlgos = map[string]bool{
+	"manual":        true,
+	"follow-policy": true,
+}
+
+// localSolveStream is a SolveMaze stream to a maze in this server, for solvers run by RunSolver
+// Every request is answered right away, the same way SolveMaze answers it. The stream fails once ctx is done,
+// so the solver stops when the RunSolver caller goes away.
+type localSolveStream struct {
+	grpc.ClientStream // not implemented, solvers only use Send and Recv
+
+	ctx   context.Context
+	mc    *mazeChannels
+	reply *pb.SolveMazeResponse // answer to the last request, until received
+	err   error
+
+	steps  int64
+	solved bool
+}
+
+// Send implements pb.Mazer_SolveMazeClient
+func (s *localSolveStream) Send(in *pb.SolveMazeRequest) error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.err = s.ctx.Err(); s.err != nil {
+		return s.err
+	}
+
+	if in.GetInitial() {
+		s.reply, s.err = initialSolveResponse(s.mc, in)
+		return s.err
+	}
+
+	s.reply, s.err = moveResponse(s.mc, in)
+	if s.err != nil {
+		return s.err
+	}
+	if !s.reply.GetError() {
+		s.steps++
+		s.solved = s.reply.GetSolved()
+	}
+	return nil
+}
+
+// Recv implements pb.Mazer_SolveMazeClient
+func (s *localSolveStream) Recv() (*pb.SolveMazeResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.reply == nil {
+		return nil, io.EOF
+	}
+	reply := s.reply
+	s.reply = nil
+	return reply, nil
+}
+
+// localMaze returns a copy of the maze, with the client, for the solver to look at (e.g. dijkstra plans on it)
+// Random solvers draw from the random numbers of the copy, seeded with the seed of the maze plus run.
+func localMaze(pm *pb.Maze, clientID string, config *pb.ClientConfig, from, to *pb.MazeLocation, run int64) (*maze.Maze, error) {
+	pm = proto.Clone(pm).(*pb.Maze)
+	pm.GetConfig().Gui = false
+	pm.GetConfig().Seed += run
+
+	m, err := maze.NewMazeFromProto(pm, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	config = proto.Clone(config).(*pb.ClientConfig)
+	config.Record = nil
+	config.FromCell = fmt.Sprintf("%d,%d,%d", from.GetX(), from.GetY(), from.GetZ())
+	config.ToCell = fmt.Sprintf("%d,%d,%d", to.GetX(), to.GetY(), to.GetZ())
+	if _, _, err := m.AddClient(clientID, config); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RunSolver registers a new client and runs its solver against the maze in the server
+func (s *server) RunSolver(ctx context.Context, in *pb.RunSolverRequest) (*pb.RunSolverReply, error) {
+	t := metrics.GetOrRegisterTimer("maze.rpc.run-solver.latency", nil)
+	defer t.UpdateSince(time.Now())
+
+	algo := in.GetClientConfig().GetSolveAlgo()
+	if _, ok := algos.SolveAlgorithms[algo]; !ok || clientOnlySolveAlgos[algo] {
+		return &pb.RunSolverReply{Success: false, Message: fmt.Sprintf("solve algorithm cannot run in the server: %q", algo)}, nil
+	}
+
+	var delay time.Duration
+	if in.GetDrawDelay() != "" {
+		var err error
+		if delay, err = time.ParseDuration(in.GetDrawDelay()); err != nil {
+			return &pb.RunSolverReply{Success: false, Message: fmt.Sprintf("invalid draw delay: %v", err)}, nil
+		}
+	}
+
+	channels, found := mazeMap.Find(in.GetMazeId())
+	if !found {
+		return &pb.RunSolverReply{Success: false, Message: fmt.Sprintf("unable to lookup maze [%v]", in.GetMazeId())}, nil
+	}
+	mc := channels.(*mazeChannels)
+	if err := checkMazeOwner(ctx, mc); err != nil {
+		return nil, err
+	}
+	atomic.AddInt64(&mc.streams, 1)
+	defer atomic.AddInt64(&mc.streams, -1)
+
+	registered, err := s.RegisterClient(ctx, &pb.RegisterClientRequest{MazeId: in.GetMazeId(), ClientConfig: in.GetClientConfig()})
+	if err != nil {
+		return nil, err
+	}
+	if !registered.GetSuccess() {
+		return &pb.RunSolverReply{Success: false, Message: registered.GetMessage()}, nil
+	}
+	clientID := registered.GetClientId()
+	log.Printf("running solver %v in maze %v (client=%v)", algo, in.GetMazeId(), clientID)
+
+	fail := func(err error) (*pb.RunSolverReply, error) {
+		return &pb.RunSolverReply{Success: false, Message: err.Error(), ClientId: clientID}, nil
+	}
+
+	before, err := s.GetMaze(ctx, &pb.GetMazeRequest{MazeId: in.GetMazeId()})
+	if err != nil {
+		return fail(err)
+	}
+	if !before.GetSuccess() {
+		return fail(fmt.Errorf("%v", before.GetMessage()))
+	}
+	m, err := localMaze(before.GetMaze(), clientID, in.GetClientConfig(), registered.GetFromCell(), registered.GetToCell(),
+		atomic.AddInt64(&mc.solverRuns, 1))
+	if err != nil {
+		return fail(err)
+	}
+
+	stream := &localSolveStream{ctx: ctx, mc: mc}
+	if err := stream.Send(&pb.SolveMazeRequest{Initial: true, MazeId: in.GetMazeId(), ClientId: clientID}); err != nil {
+		return fail(err)
+	}
+	initial, err := stream.Recv()
+	if err != nil {
+		return fail(err)
+	}
+
+	solver := algos.NewSolver(algo, stream)
+	solver.SetResetter(func(mazeID, clientID string) (*pb.ResetClientReply, error) {
+		return s.ResetClient(ctx, &pb.ResetClientRequest{MazeId: mazeID, ClientId: clientID})
+	})
+
+	start := time.Now()
+	solveErr := solver.Solve(in.GetMazeId(), clientID, initial.GetFromCell(), initial.GetToCell(), delay,
+		initial.GetAvailableDirections(), m)
+	solveTime := time.Since(start)
+
+	reply := &pb.RunSolverReply{
+		Success:   solveErr == nil,
+		ClientId:  clientID,
+		Solved:    stream.solved,
+		Steps:     stream.steps,
+		SolveTime: solveTime.Nanoseconds(),
+		FromCell:  initial.GetFromCell(),
+		ToCell:    initial.GetToCell(),
+	}
+	if solveErr != nil {
+		reply.Message = fmt.Sprintf("error running solver: %v", solveErr)
+	}
+
+	// the paths as the maze has them, moves back are not in the solution
+	after, err := s.GetMaze(ctx, &pb.GetMazeRequest{MazeId: in.GetMazeId()})
+	if err == nil && after.GetSuccess() {
+		for _, c := range after.GetMaze().GetClients() {
+			if c.GetClientId() != clientID {
+				continue
+			}
+			reply.TravelPath = c.GetTravelPath()
+			for _, segment := range c.GetTravelPath() {
+				if segment.GetSolution() {
+					reply.SolvePath = append(reply.SolvePath, segment)
+				}
+			}
+		}
+	}
+	return reply, nil
+}
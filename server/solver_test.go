@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+// runSolver runs algo once on a new maze made from seed, and returns its reply
+func runSolver(t *testing.T, algo string, seed int64) *pb.RunSolverReply {
+	m, err := startMaze(&pb.MazeConfig{Rows: 8, Columns: 8, CreateAlgo: "prim", Seed: seed}, "", "")
+	if err != nil {
+		t.Fatalf("startMaze() = %v", err)
+	}
+	defer deleteMaze(m.Config().GetId())
+
+	reply, err := (&server{}).RunSolver(context.Background(), &pb.RunSolverRequest{
+		MazeId:       m.Config().GetId(),
+		ClientConfig: &pb.ClientConfig{SolveAlgo: algo},
+	})
+	if err != nil {
+		t.Fatalf("%v: RunSolver() = %v", algo, err)
+	}
+	if !reply.GetSuccess() || !reply.GetSolved() {
+		t.Fatalf("%v: RunSolver() did not solve the maze: %v", algo, reply.GetMessage())
+	}
+	return reply
+}
+
+func TestRunSolverSeed(t *testing.T) {
+	for _, algo := range []string{"random", "random-unvisited"} {
+		one, two := runSolver(t, algo, 7), runSolver(t, algo, 7)
+
+		// the first run in mazes from the same seed walks the same way
+		if one.GetSteps() != two.GetSteps() || len(one.GetTravelPath()) != len(two.GetTravelPath()) {
+			t.Errorf("%v: runs from seed 7 took %v and %v steps", algo, one.GetSteps(), two.GetSteps())
+			continue
+		}
+		for i := range one.GetTravelPath() {
+			if !proto.Equal(one.GetTravelPath()[i], two.GetTravelPath()[i]) {
+				t.Errorf("%v: runs from seed 7 split at move %v: %v and %v", algo, i, one.GetTravelPath()[i], two.GetTravelPath()[i])
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/rcrowley/go-metrics"
+
+	"github.com/DanTulovsky/mazes/maze"
+	pb "github.com/DanTulovsky/mazes/proto"
+	lsdl "github.com/DanTulovsky/mazes/sdl"
+	"github.com/DanTulovsky/mazes/store"
+)
+
+var (
+	storeType     = flag.String("store", "", "persist mazes across restarts: file (one file per maze) or kv (one database file); empty keeps them in memory only")
+	storePath     = flag.String("store_path", "mazes.store", "directory (--store=file) or database file (--store=kv) the mazes are saved in")
+	storeInterval = flag.Duration("store_interval", 10*time.Second, "how often changed mazes are saved")
+)
+
+const (
+	// how long to wait for a maze to answer, mazes being generated do not answer until they are done
+	storeSaveTimeout = time.Second
+)
+
+var (
+	// mazeStore is nil unless --store is set
+	mazeStore store.Store
+	// storeLock makes sure a maze that is deleted is not saved again right after
+	storeLock sync.Mutex
+)
+
+// openStore opens the store requested on the command line, if any
+func openStore() error {
+	if *storeType == "" {
+		return nil
+	}
+	s, err := store.New(*storeType, *storePath)
+	if err != nil {
+		return err
+	}
+	log.Printf("saving mazes to %v (%v) every %v", *storePath, *storeType, *storeInterval)
+	mazeStore = s
+	return nil
+}
+
+// saveMaze saves the maze with id, if it has changed since the last save
+func saveMaze(id string) error {
+	channels, found := mazeMap.Find(id)
+	if !found {
+		return nil
+	}
+	mc := channels.(*mazeChannels)
+	if !atomic.CompareAndSwapInt32(&mc.changed, 1, 0) {
+		return nil
+	}
+
+	start := time.Now()
+	t := metrics.GetOrRegisterTimer("maze.store.save.latency", nil)
+	defer t.UpdateSince(start)
+
+	data := commandData{
+		Action: maze.CommandSaveMaze,
+		Reply:  make(chan commandReply),
+	}
+	select {
+	case mc.commCh <- data:
+	case <-mc.doneCh:
+		return nil
+	case <-time.After(storeSaveTimeout):
+		// busy, try again next time
+		atomic.StoreInt32(&mc.changed, 1)
+		return nil
+	}
+	reply := <-data.Reply
+	if reply.error != nil {
+		atomic.StoreInt32(&mc.changed, 1)
+		return reply.error
+	}
+
+	storeLock.Lock()
+	defer storeLock.Unlock()
+	if _, found := mazeMap.Find(id); !found {
+		// deleted while it was being saved
+		return nil
+	}
+	pm := reply.answer.(*pb.Maze)
+	pm.Owner = mc.owner
+	for _, c := range pm.GetClients() {
+		if owner, ok := mc.owners.Load(c.GetClientId()); ok {
+			c.Owner = owner.(string)
+		}
+	}
+	if err := mazeStore.Put(pm); err != nil {
+		atomic.StoreInt32(&mc.changed, 1)
+		return err
+	}
+	return nil
+}
+
+// forgetMaze removes a deleted maze from the store
+func forgetMaze(id string) {
+	if mazeStore == nil {
+		return
+	}
+	storeLock.Lock()
+	defer storeLock.Unlock()
+
+	if err := mazeStore.Delete(id); err != nil {
+		log.Printf("error removing maze %v from store: %v", id, err)
+	}
+}
+
+// saveMazes saves all changed mazes every interval, it never returns
+func saveMazes(interval time.Duration) {
+	for range time.Tick(interval) {
+		for _, id := range mazeMap.Keys() {
+			if err := saveMaze(id); err != nil {
+				log.Printf("error saving maze %v: %v", id, err)
+			}
+		}
+	}
+}
+
+// restoreMaze recreates a saved maze, with its clients, and starts running it
+func restoreMaze(pm *pb.Maze) (err error) {
+	config := pm.GetConfig()
+	if config == nil {
+		return fmt.Errorf("maze has no config")
+	}
+
+	if err := reserveMaze(); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			releaseMaze()
+		}
+	}()
+
+	// restore a throwaway copy first, without a window, so a maze that cannot be restored never opens one
+	check := proto.Clone(pm).(*pb.Maze)
+	check.Config.Gui = false
+	cm, err := maze.NewMazeFromProto(check, nil)
+	if err != nil {
+		return err
+	}
+	if err := cm.RestoreClients(check); err != nil {
+		return err
+	}
+
+	title := fmt.Sprintf("Server: %v", config.GetTitle())
+	w, r := lsdl.SetupSDL(config, title, 0, 0)
+	defer func() {
+		if err != nil {
+			destroySDL(nil, r, w)
+		}
+	}()
+
+	m, err := maze.NewMazeFromProto(pm, lsdl.NewRenderer(r))
+	if err != nil {
+		return err
+	}
+	if err := m.RestoreClients(pm); err != nil {
+		return err
+	}
+
+	channels := newMazeChannels(m.Config().GetId(), pm.GetOwner(), make(chan commandData), make(chan bool))
+	for _, c := range pm.GetClients() {
+		channels.owners.Store(c.GetClientId(), c.GetOwner())
+	}
+	if err := channels.startOriginShift(m); err != nil {
+		return fmt.Errorf("cannot shift the origin of the maze: %v", err)
+	}
+	if err := channels.startDynamics(m); err != nil {
+		channels.stopOriginShift()
+		return fmt.Errorf("cannot change the maze: %v", err)
+	}
+	mazeMap.Insert(m.Config().GetId(), channels)
+	go runMaze(m, r, w, channels)
+	return nil
+}
+
+// restoreMazes recreates all mazes in the store, mazes that fail are logged and left in the store
+func restoreMazes() error {
+	mazes, err := mazeStore.All()
+	if err != nil {
+		return err
+	}
+
+	for _, pm := range mazes {
+		if err := restoreMaze(pm); err != nil {
+			log.Printf("unable to restore maze %v: %v", pm.GetMazeId(), err)
+			continue
+		}
+		log.Printf("restored maze %v with %v clients", pm.GetMazeId(), len(pm.GetClients()))
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+func TestRestoreMazeInvalid(t *testing.T) {
+	withMaxMazes(t, 1)
+
+	for _, tt := range []struct {
+		name string
+		pm   *pb.Maze
+	}{
+		{
+			name: "no config",
+			pm:   &pb.Maze{MazeId: "bad"},
+		}, {
+			name: "invalid grid",
+			pm:   &pb.Maze{MazeId: "bad", Config: &pb.MazeConfig{Id: "bad", Rows: 3, Columns: 3, GridType: "round"}},
+		}, {
+			name: "passage off the grid",
+			pm: &pb.Maze{MazeId: "bad", Config: &pb.MazeConfig{Id: "bad", Rows: 3, Columns: 3}, Cells: []*pb.Cell{
+				{Location: &pb.MazeLocation{X: 0, Y: 0, Z: 0}, Links: []string{"north"}},
+			}},
+		}, {
+			name: "client outside the maze",
+			pm: &pb.Maze{MazeId: "bad", Config: &pb.MazeConfig{Id: "bad", Rows: 3, Columns: 3}, Clients: []*pb.MazeClient{
+				{ClientId: "c", FromCell: &pb.MazeLocation{X: 7, Y: 0, Z: 0}, ToCell: &pb.MazeLocation{X: 1, Y: 1, Z: 0}},
+			}},
+		},
+	} {
+		if err := restoreMaze(tt.pm); err == nil {
+			t.Errorf("%v: restoreMaze() should have failed", tt.name)
+		}
+		if _, found := mazeMap.Find("bad"); found {
+			t.Fatalf("%v: invalid maze was registered", tt.name)
+		}
+	}
+
+	// the failures do not use up --max_mazes
+	if err := reserveMaze(); err != nil {
+		t.Errorf("failed restores were counted: %v", err)
+	} else {
+		releaseMaze()
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	termbox "github.com/nsf/termbox-go"
+
+	"github.com/DanTulovsky/mazes/maze"
+)
+
+// cell widths, in characters, of the zoom levels of the terminal viewer
+var termZoomLevels = []int{1, 3, 5, 7}
+
+const (
+	// the terminal is not redrawn more often than this, regardless of frame_rate
+	termMaxFrameRate = 30
+	// how long to wait for a maze to answer, mazes being generated do not answer until they are done
+	termTextTimeout = 100 * time.Millisecond
+)
+
+// textRequest asks a maze to draw itself as text
+type textRequest struct {
+	client    int // index of the client whose path is drawn, in ClientsSorted order
+	cellWidth int
+}
+
+// textReply is the maze drawn as text, with the clients in it
+type textReply struct {
+	text    string
+	clients []string
+}
+
+// termViewer shows the mazes on the server, one at a time, in the terminal
+type termViewer struct {
+	mazeID string // maze currently shown
+	client int    // index of the client whose path is shown
+	zoom   int    // index into termZoomLevels
+	x, y   int    // scroll offset, in characters
+}
+
+func newTermViewer() *termViewer {
+	return &termViewer{zoom: 1}
+}
+
+// mazeIDs returns the ids of all mazes, sorted so switching between them is stable
+func mazeIDs() []string {
+	ids := mazeMap.Keys()
+	sort.Strings(ids)
+	return ids
+}
+
+// mazeText asks maze id, through its comm channel, to draw itself as text
+func mazeText(id string, in *textRequest) (*textReply, error) {
+	channels, found := mazeMap.Find(id)
+	if !found {
+		return nil, fmt.Errorf("unable to lookup maze [%v]", id)
+	}
+
+	comm := channels.(*mazeChannels).commCh
+
+	data := commandData{
+		Action:  maze.CommandTextMaze,
+		Request: commandRequest{request: in},
+		Reply:   make(chan commandReply),
+	}
+	select {
+	case comm <- data:
+	case <-time.After(termTextTimeout):
+		return nil, fmt.Errorf("maze is busy (generating?)")
+	}
+	// get response from maze
+	reply := <-data.Reply
+	if reply.error != nil {
+		return nil, reply.error
+	}
+	return reply.answer.(*textReply), nil
+}
+
+// switchMaze shows the maze offset places away from the current one
+func (v *termViewer) switchMaze(offset int) {
+	ids := mazeIDs()
+	if len(ids) == 0 {
+		return
+	}
+
+	i := sort.SearchStrings(ids, v.mazeID)
+	if i == len(ids) || ids[i] != v.mazeID {
+		// the current maze is gone, start from where it used to be
+		offset = 0
+	}
+	v.mazeID = ids[((i+offset)%len(ids)+len(ids))%len(ids)]
+	v.client, v.x, v.y = 0, 0, 0
+}
+
+// handleKey updates the view, returns false if the viewer should quit
+func (v *termViewer) handleKey(ev termbox.Event) bool {
+	_, height := termbox.Size()
+	page := height / 2
+
+	switch {
+	case ev.Key == termbox.KeyEsc || ev.Key == termbox.KeyCtrlC || ev.Ch == 'q':
+		return false
+	case ev.Key == termbox.KeyArrowUp:
+		v.y--
+	case ev.Key == termbox.KeyArrowDown:
+		v.y++
+	case ev.Key == termbox.KeyArrowLeft:
+		v.x -= 2
+	case ev.Key == termbox.KeyArrowRight:
+		v.x += 2
+	case ev.Key == termbox.KeyPgup:
+		v.y -= page
+	case ev.Key == termbox.KeyPgdn:
+		v.y += page
+	case ev.Key == termbox.KeyHome:
+		v.x, v.y = 0, 0
+	case ev.Ch == '+' || ev.Ch == '=':
+		if v.zoom < len(termZoomLevels)-1 {
+			v.zoom++
+		}
+	case ev.Ch == '-':
+		if v.zoom > 0 {
+			v.zoom--
+		}
+	case ev.Key == termbox.KeyTab || ev.Ch == 'n':
+		v.switchMaze(1)
+	case ev.Ch == 'p':
+		v.switchMaze(-1)
+	case ev.Ch == 'c':
+		v.client++
+	}
+	return true
+}
+
+// draw shows the current maze, clipped to the terminal and scrolled to the current offset
+func (v *termViewer) draw() {
+	termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+	defer termbox.Flush()
+
+	if _, found := mazeMap.Find(v.mazeID); !found {
+		v.switchMaze(0)
+	}
+	ids := mazeIDs()
+
+	status := "no mazes"
+	var lines []string
+	if len(ids) > 0 {
+		status = fmt.Sprintf("maze %v/%v [%v]", sort.SearchStrings(ids, v.mazeID)+1, len(ids), v.mazeID)
+
+		reply, err := mazeText(v.mazeID, &textRequest{client: v.client, cellWidth: termZoomLevels[v.zoom]})
+		if err != nil {
+			status = fmt.Sprintf("%v: %v", status, err)
+		} else {
+			if len(reply.clients) > 0 {
+				v.client %= len(reply.clients)
+				status = fmt.Sprintf("%v client %v/%v [%v]", status, v.client+1, len(reply.clients), reply.clients[v.client])
+			}
+			lines = strings.Split(strings.TrimRight(reply.text, "\n"), "\n")
+		}
+	}
+
+	width, height := termbox.Size()
+
+	// keep the maze on the screen
+	longest := 0
+	for _, line := range lines {
+		if l := len([]rune(line)); l > longest {
+			longest = l
+		}
+	}
+	v.x = clamp(v.x, 0, longest-width)
+	v.y = clamp(v.y, 0, len(lines)-(height-2))
+
+	termPrint(0, 0, status, termbox.ColorDefault|termbox.AttrBold)
+	for y := 0; y < height-2 && v.y+y < len(lines); y++ {
+		line := []rune(lines[v.y+y])
+		for x := 0; x < width && v.x+x < len(line); x++ {
+			fg := termbox.ColorDefault
+			if line[v.x+x] == '@' {
+				fg = termbox.ColorRed | termbox.AttrBold
+			}
+			termbox.SetCell(x, y+1, line[v.x+x], fg, termbox.ColorDefault)
+		}
+	}
+	termPrint(0, height-1, "arrows/pgup/pgdn: scroll  +/-: zoom  n/p: maze  c: client  q: quit", termbox.ColorDefault)
+}
+
+// termPrint writes s on line y, starting at x
+func termPrint(x, y int, s string, fg termbox.Attribute) {
+	for i, ch := range []rune(s) {
+		termbox.SetCell(x+i, y, ch, fg, termbox.ColorDefault)
+	}
+}
+
+// clamp returns v limited to [min, max], min wins if max < min
+func clamp(v, min, max int) int {
+	if v > max {
+		v = max
+	}
+	if v < min {
+		v = min
+	}
+	return v
+}
+
+// runTermViewer shows the mazes live in the terminal until the user quits, the server keeps running
+func runTermViewer() {
+	if err := termbox.Init(); err != nil {
+		log.Fatalf("failed to initialize terminal: %v", err)
+	}
+
+	// events is closed once the poller is interrupted, when the user quits
+	events := make(chan termbox.Event)
+	go func() {
+		defer close(events)
+		for {
+			ev := termbox.PollEvent()
+			if ev.Type == termbox.EventInterrupt {
+				return
+			}
+			events <- ev
+		}
+	}()
+
+	rate := *frameRate
+	if rate == 0 || rate > termMaxFrameRate {
+		rate = termMaxFrameRate
+	}
+	ticker := time.NewTicker(time.Second / time.Duration(rate))
+	defer ticker.Stop()
+
+	v := newTermViewer()
+	for {
+		v.draw()
+
+		select {
+		case ev := <-events:
+			if ev.Type == termbox.EventKey && !v.handleKey(ev) {
+				// stop the poller, taking the events it still sends, before giving the terminal back
+				go termbox.Interrupt()
+				for range events {
+				}
+				termbox.Close()
+				log.Print("terminal viewer closed, the server keeps running")
+				return
+			}
+		case <-ticker.C:
+		}
+	}
+}
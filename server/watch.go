@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/rcrowley/go-metrics"
+
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+// maze event types, see pb.MazeEvent
+const (
+	eventGeneratorStep    = "generator-step"
+	eventClientRegistered = "client-registered"
+	eventClientReset      = "client-reset"
+	eventClientMoved      = "client-moved"
+	eventClientSolved     = "client-solved"
+	eventMazeChanged      = "maze-changed"
+	eventMazeDeleted      = "maze-deleted"
+)
+
+const (
+	// events buffered per watcher, once full new events are dropped until the watcher catches up
+	watchBufferSize = 1024
+)
+
+// watcher is one WatchMaze stream
+type watcher struct {
+	owner   string // owner of the auth token of the stream, see auth.go
+	events  chan *pb.MazeEvent
+	dropped int64 // events dropped since the last one sent, atomic
+}
+
+// watchers are the watchers of one maze
+// publish never blocks, so watchers cannot slow down the maze or its solvers.
+type watchers struct {
+	mazeID string
+	owner  string // owner of the maze, only watchers with the same owner get its events
+	list   map[*watcher]bool
+	sync.RWMutex
+}
+
+func newWatchers(mazeID, owner string) *watchers {
+	return &watchers{mazeID: mazeID, owner: owner, list: make(map[*watcher]bool)}
+}
+
+// allWatchers get the events of all the mazes they can use, including the ones created after they start watching
+var allWatchers = newWatchers("", "")
+
+// add returns a new watcher for owner that receives all events published from now on
+func (ws *watchers) add(owner string) *watcher {
+	ws.Lock()
+	defer ws.Unlock()
+
+	w := &watcher{owner: owner, events: make(chan *pb.MazeEvent, watchBufferSize)}
+	ws.list[w] = true
+	return w
+}
+
+// remove stops sending events to w
+func (ws *watchers) remove(w *watcher) {
+	ws.Lock()
+	defer ws.Unlock()
+	delete(ws.list, w)
+}
+
+// publish sends e, an event of this maze, to its watchers and to allWatchers
+func (ws *watchers) publish(e *pb.MazeEvent) {
+	e.MazeId = ws.mazeID
+	e.Time = time.Now().UnixNano()
+
+	ws.deliver(e, ws.owner)
+	allWatchers.deliver(e, ws.owner)
+}
+
+// deliver sends e, an event of a maze of owner, to the watchers allowed to see it, watchers that are behind miss it
+func (ws *watchers) deliver(e *pb.MazeEvent, owner string) {
+	ws.RLock()
+	defer ws.RUnlock()
+
+	for w := range ws.list {
+		if !allowed(owner, w.owner) {
+			continue
+		}
+		select {
+		case w.events <- e:
+		default:
+			atomic.AddInt64(&w.dropped, 1)
+			metrics.GetOrRegisterCounter("maze.watch.dropped", nil).Inc(1)
+		}
+	}
+}
+
+// publishMove publishes the moves of client, and the solve if it is solved
+func (ws *watchers) publishMove(clientID string, reply *moveReply, direction string, back bool) {
+	ws.publish(&pb.MazeEvent{
+		Type:      eventClientMoved,
+		ClientId:  clientID,
+		Location:  reply.current,
+		Direction: direction,
+		MoveBack:  back,
+		Reward:    reply.reward,
+	})
+	if reply.solved {
+		ws.publish(&pb.MazeEvent{Type: eventClientSolved, ClientId: clientID, Location: reply.current})
+	}
+}
+
+// send sends e to the stream, with the number of events dropped before it
+func (w *watcher) send(stream pb.Mazer_WatchMazeServer, e *pb.MazeEvent) error {
+	// e is shared by all watchers
+	if dropped := atomic.SwapInt64(&w.dropped, 0); dropped > 0 {
+		e = proto.Clone(e).(*pb.MazeEvent)
+		e.Dropped = dropped
+	}
+	return stream.Send(e)
+}
+
+// WatchMaze streams the events of a maze until it is deleted or the watcher goes away
+// Without a maze id, the events of all the mazes of the caller are streamed until the watcher goes away.
+func (s *server) WatchMaze(in *pb.WatchMazeRequest, stream pb.Mazer_WatchMazeServer) error {
+	log.Printf("watching maze with id: %v", in.GetMazeId())
+	if in.GetMazeId() == "" {
+		return watchAll(stream)
+	}
+
+	channels, found := mazeMap.Find(in.GetMazeId())
+	if !found {
+		return fmt.Errorf("unable to lookup maze [%v]", in.GetMazeId())
+	}
+	mc := channels.(*mazeChannels)
+	if err := checkMazeOwner(stream.Context(), mc); err != nil {
+		return err
+	}
+
+	w := mc.watchers.add(callerOwner(stream.Context()))
+	defer mc.watchers.remove(w)
+
+	for {
+		select {
+		case e := <-w.events:
+			if err := w.send(stream, e); err != nil {
+				return err
+			}
+		case <-mc.doneCh:
+			// the maze is gone, send what is left, which ends with the deletion
+			for {
+				select {
+				case e := <-w.events:
+					if err := w.send(stream, e); err != nil {
+						return err
+					}
+				default:
+					return nil
+				}
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// watchAll streams the events of all the mazes of the caller until the watcher goes away
+func watchAll(stream pb.Mazer_WatchMazeServer) error {
+	w := allWatchers.add(callerOwner(stream.Context()))
+	defer allWatchers.remove(w)
+
+	for {
+		select {
+		case e := <-w.events:
+			if err := w.send(stream, e); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"testing"
+
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+// fakeWatchStream records the events sent to a watcher
+type fakeWatchStream struct {
+	pb.Mazer_WatchMazeServer
+	sent []*pb.MazeEvent
+}
+
+func (s *fakeWatchStream) Send(e *pb.MazeEvent) error {
+	s.sent = append(s.sent, e)
+	return nil
+}
+
+func TestWatchersFanOut(t *testing.T) {
+	ws := newWatchers("maze", "")
+	one, two := ws.add(""), ws.add("")
+	all := allWatchers.add("")
+	defer allWatchers.remove(all)
+
+	ws.publish(&pb.MazeEvent{Type: eventClientMoved, ClientId: "c"})
+
+	for name, w := range map[string]*watcher{"one": one, "two": two, "all": all} {
+		select {
+		case e := <-w.events:
+			if e.GetMazeId() != "maze" || e.GetType() != eventClientMoved || e.GetTime() == 0 {
+				t.Errorf("watcher %v got %v", name, e)
+			}
+		default:
+			t.Errorf("watcher %v got nothing", name)
+		}
+	}
+
+	// removed watchers get nothing more
+	ws.remove(two)
+	ws.publish(&pb.MazeEvent{Type: eventMazeDeleted})
+	if len(one.events) != 1 || len(two.events) != 0 {
+		t.Errorf("after removing a watcher: %v and %v events, want 1 and 0", len(one.events), len(two.events))
+	}
+}
+
+func TestWatchersDrop(t *testing.T) {
+	ws := newWatchers("maze", "")
+	slow := ws.add("")
+
+	// the maze never waits for a watcher, the events past its buffer are dropped
+	extra := 5
+	for i := 0; i < watchBufferSize+extra; i++ {
+		ws.publish(&pb.MazeEvent{Type: eventClientMoved})
+	}
+	if len(slow.events) != watchBufferSize {
+		t.Fatalf("watcher has %v events, want %v", len(slow.events), watchBufferSize)
+	}
+
+	stream := &fakeWatchStream{}
+	first := <-slow.events
+	for i := 0; i < 2; i++ {
+		if err := slow.send(stream, <-slow.events); err != nil {
+			t.Fatalf("send failed: %v", err)
+		}
+	}
+	if got := stream.sent[0].GetDropped(); got != int64(extra) {
+		t.Errorf("first event sent says %v dropped, want %v", got, extra)
+	}
+	if got := stream.sent[1].GetDropped(); got != 0 {
+		t.Errorf("second event sent says %v dropped, want 0", got)
+	}
+	if first.GetDropped() != 0 {
+		t.Errorf("the shared event was changed")
+	}
+}
+
+func TestWatchersOwner(t *testing.T) {
+	withTokens(t, "secret", "other")
+
+	ws := newWatchers("maze", tokenOwner("secret"))
+	all := allWatchers.add(tokenOwner("secret"))
+	defer allWatchers.remove(all)
+	other := allWatchers.add(tokenOwner("other"))
+	defer allWatchers.remove(other)
+
+	// only the watchers of the owner of the maze get its events
+	ws.publish(&pb.MazeEvent{Type: eventClientMoved})
+	if len(all.events) != 1 || len(other.events) != 0 {
+		t.Errorf("watchers of the owner and another token got %v and %v events, want 1 and 0", len(all.events), len(other.events))
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"log"
+	"net/http"
+)
+
+// web is the web viewer: it draws the mazes on a canvas from the JSON API, follows them live over the watch
+// WebSocket and lets a human play a maze over the solve WebSocket, like the manual solver.
+//
+//go:embed web
+var web embed.FS
+
+// webHandler serves the web viewer
+func webHandler() http.Handler {
+	root, err := fs.Sub(web, "web")
+	if err != nil {
+		log.Fatalf("web viewer is missing: %v", err)
+	}
+	return http.FileServer(http.FS(root))
+}
@@ -0,0 +1,600 @@
+// Web viewer for the maze server.
+// Mazes come from the JSON API (/v1/mazes), live changes from the watch WebSocket and playing uses the
+// solve WebSocket, one move per key press, like the manual solver in the terminal.
+'use strict';
+
+const api = '/v1/mazes';
+
+// auth token for servers started with --auth_tokens, from the page url: /#token=...
+// The fragment is never sent to the server, so the token does not end up in its logs.
+const token = new URLSearchParams(location.hash.slice(1)).get('token');
+
+// moves, as sent to the server, for each key; the same keys as the manual solver
+const keyDirections = {
+  ArrowUp: 'north',
+  ArrowDown: 'south',
+  ArrowLeft: 'west',
+  ArrowRight: 'east',
+  PageUp: 'up',
+  PageDown: 'down',
+};
+
+const offsets = {
+  north: [0, -1, 0],
+  south: [0, 1, 0],
+  east: [1, 0, 0],
+  west: [-1, 0, 0],
+  up: [0, 0, 1],
+  down: [0, 0, -1],
+};
+
+const opposite = {north: 'south', south: 'north', east: 'west', west: 'east', up: 'down', down: 'up'};
+
+// used for clients without a PathColor, in order of registration
+const pathColors = ['red', 'blue', 'green', 'orange', 'purple', 'brown', 'magenta', 'teal'];
+
+const levelGap = 2; // cells between levels, drawn side by side
+const maxCellSize = 32;
+const minCellSize = 4;
+
+const el = (id) => document.getElementById(id);
+const canvas = el('maze');
+const ctx = canvas.getContext('2d');
+
+let maze = null; // the maze being shown, see loadMaze
+let watch = null; // WebSocket with the events of the maze being shown
+let play = null; // WebSocket of the client being played, if any
+
+function status(msg, error) {
+  el('status').textContent = msg;
+  el('status').className = error ? 'error' : '';
+}
+
+async function request(method, path, body) {
+  const headers = token ? {Authorization: 'Bearer ' + token} : {};
+  const r = await fetch(api + path, {method: method, headers: headers, body: body && JSON.stringify(body)});
+  const reply = await r.json();
+  if (!r.ok) {
+    throw new Error(reply.error);
+  }
+  if (reply.success === false) {
+    throw new Error(reply.message);
+  }
+  return reply;
+}
+
+function socket(path) {
+  const scheme = location.protocol === 'https:' ? 'wss:' : 'ws:';
+  const query = token ? '?token=' + encodeURIComponent(token) : '';
+  return new WebSocket(scheme + '//' + location.host + api + path + query);
+}
+
+// loc returns the {x, y, z, under} of a MazeLocation, int64 values are strings in JSON
+function loc(l, under) {
+  return {x: Number(l.X), y: Number(l.Y), z: Number(l.Z), under: !!under};
+}
+
+function key(l) {
+  return l.x + ',' + l.y + ',' + l.z + (l.under ? ',under' : '');
+}
+
+function sameLocation(a, b) {
+  return a && b && a.x === b.x && a.y === b.y && a.z === b.z;
+}
+
+// loadMaze fetches the maze and indexes its cells and clients
+async function loadMaze(id) {
+  const reply = await request('GET', '/' + id);
+  const pm = reply.maze;
+  const config = pm.config;
+
+  const m = {
+    id: id,
+    config: config,
+    rows: Number(config.Rows),
+    columns: Number(config.Columns),
+    levels: Math.max(1, Number(config.Levels)),
+    cells: new Map(), // key -> {l, links, weight, orphan}
+    clients: new Map(), // client id -> see addClient
+  };
+
+  for (const pc of pm.cells) {
+    const l = loc(pc.location);
+    m.cells.set(key(l), {l: l, links: pc.links, weight: Number(pc.weight), orphan: pc.orphan});
+    if (pc.under) {
+      const u = loc(pc.location, true);
+      m.cells.set(key(u), {l: u, links: pc.under.links, weight: Number(pc.under.weight), orphan: false});
+    }
+  }
+
+  for (const mc of pm.clients) {
+    const c = addClient(m, mc.clientId, mc.config, mc.fromCell && loc(mc.fromCell), mc.toCell && loc(mc.toCell));
+    c.path = mc.travelPath.map((s) => loc(s.location, s.under));
+    if (mc.currentLocation) {
+      c.current = loc(mc.currentLocation, mc.currentLocationUnder);
+    }
+    c.solved = sameLocation(c.current, c.to);
+  }
+  return m;
+}
+
+function addClient(m, id, config, from, to) {
+  const c = {
+    id: id,
+    config: config || {},
+    from: from,
+    to: to,
+    current: from,
+    path: [],
+    solved: false,
+    color: (config && config.PathColor) || pathColors[m.clients.size % pathColors.length],
+  };
+  m.clients.set(id, c);
+  return c;
+}
+
+// neighbor returns the location reached by moving from l in direction d
+// The cell at the far end of a weave tunnel is the one with a passage back, on top or in the tunnel under it.
+function neighbor(m, l, d) {
+  const o = offsets[d];
+  if (!o) {
+    return null;
+  }
+  let x = l.x + o[0];
+  let y = l.y + o[1];
+  const z = l.z + o[2];
+
+  const wrap = m.config.Wrap;
+  if (wrap === 'horizontal' || wrap === 'both') {
+    x = (x + m.columns) % m.columns;
+  }
+  if (wrap === 'vertical' || wrap === 'both') {
+    y = (y + m.rows) % m.rows;
+  }
+
+  const top = {x: x, y: y, z: z, under: false};
+  const under = {x: x, y: y, z: z, under: true};
+  const topCell = m.cells.get(key(top));
+  const underCell = m.cells.get(key(under));
+  if (underCell && !(topCell && topCell.links.includes(opposite[d])) && underCell.links.includes(opposite[d])) {
+    return under;
+  }
+  return topCell ? top : null;
+}
+
+// distances returns the distance of every cell from l, and the longest one; moving into a cell costs its weight, as on the server
+function distances(m, from) {
+  const dist = new Map([[key(from), 0]]);
+  const heap = [[0, from]];
+  let longest = 0;
+
+  while (heap.length) {
+    const [d, l] = heapPop(heap);
+    if (d > dist.get(key(l))) {
+      continue;
+    }
+    longest = Math.max(longest, d);
+
+    for (const dir of m.cells.get(key(l)).links) {
+      const n = neighbor(m, l, dir);
+      if (!n) {
+        continue;
+      }
+      const nd = d + m.cells.get(key(n)).weight;
+      const old = dist.get(key(n));
+      if (old === undefined || nd < old) {
+        dist.set(key(n), nd);
+        heapPush(heap, [nd, n]);
+      }
+    }
+  }
+  return {dist: dist, longest: longest};
+}
+
+function heapPush(h, item) {
+  h.push(item);
+  for (let i = h.length - 1; i > 0;) {
+    const p = (i - 1) >> 1;
+    if (h[p][0] <= h[i][0]) {
+      break;
+    }
+    [h[p], h[i]] = [h[i], h[p]];
+    i = p;
+  }
+}
+
+function heapPop(h) {
+  const top = h[0];
+  const last = h.pop();
+  if (h.length) {
+    h[0] = last;
+    for (let i = 0; ;) {
+      let min = i;
+      for (const c of [2 * i + 1, 2 * i + 2]) {
+        if (c < h.length && h[c][0] < h[min][0]) {
+          min = c;
+        }
+      }
+      if (min === i) {
+        break;
+      }
+      [h[min], h[i]] = [h[i], h[min]];
+      i = min;
+    }
+  }
+  return top;
+}
+
+// cellSize returns the size of a cell so that all levels fit in the window
+function cellSize(m) {
+  const width = m.levels * m.columns + (m.levels - 1) * levelGap;
+  const fit = Math.floor(Math.min((window.innerWidth - 40) / width, (window.innerHeight - 100) / m.rows));
+  return Math.max(minCellSize, Math.min(maxCellSize, fit));
+}
+
+function draw() {
+  ctx.clearRect(0, 0, canvas.width, canvas.height);
+  const m = maze;
+  if (!m) {
+    canvas.width = canvas.height = 0;
+    return;
+  }
+  if ((m.config.GridType || 'square') !== 'square') {
+    canvas.width = canvas.height = 0;
+    status('only square grids can be drawn, this maze is ' + m.config.GridType, true);
+    return;
+  }
+
+  const size = cellSize(m);
+  canvas.width = (m.levels * m.columns + (m.levels - 1) * levelGap) * size + 2;
+  canvas.height = m.rows * size + 2;
+
+  // top left corner of the cell at l
+  const origin = (l) => [1 + (l.z * (m.columns + levelGap) + l.x) * size, 1 + l.y * size];
+  const center = (l) => {
+    const [x, y] = origin(l);
+    return [x + size / 2, y + size / 2];
+  };
+
+  const client = m.clients.get(el('clients').value);
+
+  // background: distance colors from the selected client's start, or from the first cell
+  if (el('distances').checked) {
+    const from = (client && client.from) || {x: 0, y: 0, z: 0, under: false};
+    if (m.cells.has(key(from))) {
+      const {dist, longest} = distances(m, from);
+      for (const cell of m.cells.values()) {
+        const d = dist.get(key(cell.l));
+        if (cell.l.under || d === undefined) {
+          continue;
+        }
+        const [x, y] = origin(cell.l);
+        ctx.globalAlpha = (27 + (longest ? 228 * d / longest : 0)) / 255;
+        ctx.fillStyle = '#2a9d8f';
+        ctx.fillStyle = m.config.BgColor && m.config.BgColor !== 'white' ? m.config.BgColor : ctx.fillStyle;
+        ctx.fillRect(x, y, size, size);
+      }
+      ctx.globalAlpha = 1;
+    }
+  }
+
+  // walls, orphans and the ways between levels
+  ctx.strokeStyle = m.config.WallColor || 'black';
+  ctx.lineWidth = 1;
+  for (const cell of m.cells.values()) {
+    const [x, y] = origin(cell.l);
+    if (cell.orphan) {
+      ctx.fillStyle = '#999';
+      ctx.fillRect(x, y, size, size);
+      continue;
+    }
+    const has = (d) => cell.links.includes(d);
+    if (cell.l.under) {
+      // the tunnel shows as the ends of its walls on either side of the cell on top
+      const q = size / 4;
+      ctx.beginPath();
+      if (has('east') || has('west')) {
+        for (const [sx, ex] of [[x, x + q], [x + size - q, x + size]]) {
+          ctx.moveTo(sx, y + q);
+          ctx.lineTo(ex, y + q);
+          ctx.moveTo(sx, y + size - q);
+          ctx.lineTo(ex, y + size - q);
+        }
+      } else {
+        for (const [sy, ey] of [[y, y + q], [y + size - q, y + size]]) {
+          ctx.moveTo(x + q, sy);
+          ctx.lineTo(x + q, ey);
+          ctx.moveTo(x + size - q, sy);
+          ctx.lineTo(x + size - q, ey);
+        }
+      }
+      ctx.stroke();
+      continue;
+    }
+
+    ctx.beginPath();
+    if (!has('north')) {
+      ctx.moveTo(x, y);
+      ctx.lineTo(x + size, y);
+    }
+    if (!has('south')) {
+      ctx.moveTo(x, y + size);
+      ctx.lineTo(x + size, y + size);
+    }
+    if (!has('west')) {
+      ctx.moveTo(x, y);
+      ctx.lineTo(x, y + size);
+    }
+    if (!has('east')) {
+      ctx.moveTo(x + size, y);
+      ctx.lineTo(x + size, y + size);
+    }
+    ctx.stroke();
+
+    // stairs: a triangle pointing up or down
+    ctx.fillStyle = ctx.strokeStyle;
+    const t = size / 6;
+    for (const [d, sign] of [['up', -1], ['down', 1]]) {
+      if (has(d)) {
+        const cx = x + size / 2 + (d === 'up' ? -t : t);
+        const cy = y + size / 2;
+        ctx.beginPath();
+        ctx.moveTo(cx - t, cy - sign * t / 2);
+        ctx.lineTo(cx + t, cy - sign * t / 2);
+        ctx.lineTo(cx, cy + sign * t);
+        ctx.fill();
+      }
+    }
+  }
+
+  // clients: from and to cells, path and current location; the selected client is drawn last, on top
+  const clients = [...m.clients.values()].filter((c) => c !== client);
+  if (client) {
+    clients.push(client);
+  }
+  for (const c of clients) {
+    ctx.fillStyle = c.color;
+    for (const l of [c.from, c.to]) {
+      if (l) {
+        const [x, y] = origin(l);
+        ctx.globalAlpha = 0.3;
+        ctx.fillRect(x, y, size, size);
+      }
+    }
+    ctx.globalAlpha = 1;
+
+    if (el('paths').checked && c.path.length > 1) {
+      ctx.strokeStyle = c.color;
+      ctx.lineWidth = Math.max(1, size / 8);
+      ctx.setLineDash([]);
+      ctx.beginPath();
+      let prev = null;
+      for (const l of c.path) {
+        const [x, y] = center(l);
+        // a jump (wrap around, another level) is not a line
+        const adjacent = prev && prev.z === l.z && Math.abs(prev.x - l.x) + Math.abs(prev.y - l.y) === 1;
+        if (adjacent) {
+          ctx.lineTo(x, y);
+        } else {
+          ctx.moveTo(x, y);
+        }
+        prev = l;
+      }
+      ctx.stroke();
+    }
+
+    if (c.current) {
+      const [x, y] = center(c.current);
+      ctx.fillStyle = c.config.CurrentLocationColor || c.color;
+      ctx.beginPath();
+      ctx.arc(x, y, Math.max(2, size / 3), 0, 2 * Math.PI);
+      ctx.fill();
+      if (c.current.under) {
+        ctx.strokeStyle = 'white';
+        ctx.lineWidth = 1;
+        ctx.stroke();
+      }
+    }
+  }
+}
+
+// showClients fills in the client list, keeping the selected one if it is still there
+function showClients() {
+  const select = el('clients');
+  const selected = select.value;
+  select.innerHTML = '';
+  select.add(new Option('(none)', ''));
+  if (maze) {
+    for (const c of maze.clients.values()) {
+      select.add(new Option(c.id + (c.solved ? ' (solved)' : ''), c.id));
+    }
+  }
+  select.value = maze && maze.clients.has(selected) ? selected : '';
+}
+
+async function listMazes() {
+  const reply = await request('GET', '');
+  const select = el('mazes');
+  const selected = select.value;
+  select.innerHTML = '';
+  select.add(new Option('(none)', ''));
+  for (const m of reply.mazes) {
+    select.add(new Option(m.mazeId, m.mazeId));
+  }
+  select.value = reply.mazes.some((m) => m.mazeId === selected) ? selected : '';
+}
+
+// showMaze loads the maze with id and follows it
+async function showMaze(id) {
+  stopPlaying();
+  if (watch) {
+    watch.onclose = null;
+    watch.close();
+    watch = null;
+  }
+  maze = null;
+  if (id) {
+    maze = await loadMaze(id);
+    watch = socket('/' + id + '/watch');
+    watch.onmessage = (e) => onEvent(JSON.parse(e.data));
+    watch.onclose = () => status('lost the connection to maze ' + id, true);
+    status(maze.rows + 'x' + maze.columns + (maze.levels > 1 ? 'x' + maze.levels : '') +
+        ' maze, created with ' + maze.config.CreateAlgo);
+  } else {
+    status('');
+  }
+  showClients();
+  draw();
+}
+
+// reloadMaze loads the maze being shown again, at most one load at a time: events while loading are
+// covered by one more load after it
+let reloading = null;
+function reloadMaze() {
+  if (reloading) {
+    reloading.again = true;
+    return;
+  }
+  const id = maze.id;
+  reloading = {again: false};
+  loadMaze(id).then((m) => {
+    if (maze && maze.id === id) {
+      maze = m;
+      showClients();
+      draw();
+    }
+  }).catch((err) => status(err.message, true)).finally(() => {
+    const again = reloading.again;
+    reloading = null;
+    if (again && maze && maze.id === id) {
+      reloadMaze();
+    }
+  });
+}
+
+// onEvent applies a MazeEvent of the maze being shown
+function onEvent(e) {
+  if (!maze || e.mazeId !== maze.id) {
+    return;
+  }
+  const c = maze.clients.get(e.clientId);
+
+  switch (e.type) {
+    case 'client-registered':
+    case 'maze-changed':
+      // the event does not have the client's config or from cell (or all the passages), load it all again
+      reloadMaze();
+      return;
+    case 'client-reset':
+      if (c) {
+        c.current = c.from;
+        c.path = [];
+        c.solved = false;
+      }
+      break;
+    case 'client-moved':
+      if (c) {
+        c.current = loc(e.location);
+        // the location of the event is never the tunnel, only the top cell is known
+        if (e.moveBack) {
+          c.path.pop();
+        } else {
+          if (!c.path.length && c.from) {
+            c.path.push(c.from);
+          }
+          c.path.push(c.current);
+        }
+      }
+      break;
+    case 'client-solved':
+      if (c) {
+        c.solved = true;
+        showClients();
+      }
+      break;
+    case 'maze-deleted':
+      watch.onclose = null;
+      status('maze ' + maze.id + ' was deleted', true);
+      maze = null;
+      stopPlaying();
+      listMazes();
+      showClients();
+      break;
+  }
+  draw();
+}
+
+// startPlaying registers a new client and solves the maze with the keyboard
+async function startPlaying() {
+  if (!maze) {
+    status('pick a maze to play', true);
+    return;
+  }
+  stopPlaying();
+
+  const reply = await request('POST', '/' + maze.id + '/clients', {
+    client_config: {FromCell: 'min', ToCell: 'max', SolveAlgo: 'manual'},
+  });
+  const id = reply.clientId;
+  if (!maze.clients.has(id)) {
+    addClient(maze, id, {}, loc(reply.fromCell), loc(reply.toCell));
+  }
+  showClients();
+  el('clients').value = id;
+  draw();
+
+  play = socket('/' + maze.id + '/clients/' + id + '/solve');
+  play.onopen = () => play.send(JSON.stringify({initial: true}));
+  play.onmessage = (e) => {
+    const r = JSON.parse(e.data);
+    if (r.error) {
+      status(r.errorMessage, true);
+      return;
+    }
+    if (r.solved) {
+      status('solved!');
+      stopPlaying();
+      return;
+    }
+    status('playing ' + id + ', can go ' + r.availableDirections.map((d) => d.name).join(', '));
+  };
+  play.onclose = () => {
+    play = null;
+  };
+}
+
+function stopPlaying() {
+  if (play) {
+    play.onclose = null;
+    play.close();
+    play = null;
+  }
+}
+
+document.addEventListener('keydown', (e) => {
+  const direction = keyDirections[e.key];
+  if (!play || !direction || play.readyState !== WebSocket.OPEN) {
+    return;
+  }
+  e.preventDefault();
+  play.send(JSON.stringify({direction: direction}));
+});
+
+const fail = (err) => status(err.message, true);
+
+el('mazes').onchange = (e) => showMaze(e.target.value).catch(fail);
+el('refresh').onclick = () => listMazes().catch(fail);
+el('clients').onchange = draw;
+el('distances').onchange = draw;
+el('paths').onchange = draw;
+el('play').onclick = () => startPlaying().catch(fail);
+window.onresize = draw;
+
+listMazes().then(() => {
+  // a maze can be picked in the url: /#maze-id
+  const id = location.hash.slice(1);
+  if (id) {
+    el('mazes').value = id;
+    return showMaze(id);
+  }
+}).catch(fail);
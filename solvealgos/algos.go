@@ -2,6 +2,7 @@
 package solvealgos
 
 import (
+	"crypto/tls"
 	"errors"
 	"flag"
 	"fmt"
@@ -9,10 +10,12 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/DanTulovsky/mazes/maze"
 	"github.com/DanTulovsky/mazes/ml"
 	pb "github.com/DanTulovsky/mazes/proto"
+	"github.com/DanTulovsky/mazes/utils"
 
 	"context"
 
@@ -22,10 +25,12 @@ import (
 var (
 	// stats
 	showStats = flag.Bool("stats", false, "show maze stats")
-)
 
-const (
-	ADDRESS = "localhost:50051"
+	// server connection
+	serverAddress = flag.String("server_address", "localhost:50051", "address of the maze server")
+	useTLS        = flag.Bool("tls", false, "connect to the server with TLS, verified with the system certificates")
+	tlsCA         = flag.String("tls_ca", "", "connect to the server with TLS, verified with the CA certificate in this file")
+	authToken     = flag.String("auth_token", "", "token sent with every call, for servers started with --auth_tokens")
 )
 
 type Algorithmer interface {
@@ -44,11 +49,15 @@ type Algorithmer interface {
 		directions []*pb.Direction, m *maze.Maze) error
 	Stream() pb.Mazer_SolveMazeClient
 	SetStream(pb.Mazer_SolveMazeClient)
+	SetResetter(ResetFunc)
 	ShowStats()
 	TravelPath() *maze.Path // all the cells traveled
 	CellForLocation(m *maze.Maze, l *pb.MazeLocation) (*maze.Cell, error)
 }
 
+// ResetFunc resets a client to its from cell, see Common.ResetClient
+type ResetFunc func(mazeID, clientID string) (*pb.ResetClientReply, error)
+
 type Common struct {
 	solvePath  *maze.Path    // path of the final solution
 	solveSteps int           // how many cell visits it took (including duplicates)
@@ -56,6 +65,7 @@ type Common struct {
 	stream     pb.Mazer_SolveMazeClient
 	travelPath *maze.Path // all the cells visited in order
 	policy     *ml.Policy
+	resetter   ResetFunc // resets clients without a connection to the server, nil to use one
 }
 
 func (a *Common) CellForLocation(m *maze.Maze, l *pb.MazeLocation) (*maze.Cell, error) {
@@ -66,6 +76,15 @@ func (a *Common) CellForLocation(m *maze.Maze, l *pb.MazeLocation) (*maze.Cell,
 	return cell, nil
 }
 
+// Rand returns the random numbers for random solvers: those of m, the local copy of the maze, so runs on the same
+// copy (e.g. see the server's RunSolver) are the same; a new source if there is no copy
+func Rand(m *maze.Maze) *utils.Rand {
+	if m == nil {
+		return utils.NewRand(time.Now().UnixNano())
+	}
+	return m.Rand()
+}
+
 // Solve should write the path of the solution to the grid
 func (a *Common) Solve(mazeID, clientID string, fromCell, toCell *pb.MazeLocation, delay time.Duration,
 	directions []*pb.Direction, m *maze.Maze) error {
@@ -135,10 +154,53 @@ func (a *Common) SetStream(s pb.Mazer_SolveMazeClient) {
 	a.stream = s
 }
 
+// tokenCredentials sends the auth token with every call
+type tokenCredentials string
+
+// GetRequestMetadata implements credentials.PerRPCCredentials
+func (t tokenCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials, tokens are never sent without TLS
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// dialOptions returns the TLS and auth options requested on the command line
+func dialOptions() ([]grpc.DialOption, error) {
+	var opts []grpc.DialOption
+
+	switch {
+	case *tlsCA != "":
+		creds, err := credentials.NewClientTLSFromFile(*tlsCA, "")
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	case *useTLS:
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+	default:
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	if *authToken != "" {
+		if *tlsCA == "" && !*useTLS {
+			return nil, fmt.Errorf("--auth_token needs --tls or --tls_ca, tokens are not sent without TLS")
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCredentials(*authToken)))
+	}
+	return opts, nil
+}
+
 // NewClient creates a server connection and returns a new SoleMazeClient
 func NewClient() (*grpc.ClientConn, pb.MazerClient) {
+	opts, err := dialOptions()
+	if err != nil {
+		log.Fatalf("invalid connection options: %v", err)
+	}
 	// Set up a connection to the server.
-	conn, err := grpc.Dial(ADDRESS, grpc.WithInsecure())
+	conn, err := grpc.Dial(*serverAddress, opts...)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
@@ -146,7 +208,16 @@ func NewClient() (*grpc.ClientConn, pb.MazerClient) {
 	return conn, pb.NewMazerClient(conn)
 }
 
+// SetResetter sets how ResetClient resets clients, e.g. when the solver runs in the server
+func (a *Common) SetResetter(f ResetFunc) {
+	a.resetter = f
+}
+
+// ResetClient resets the client to its from cell
 func (a *Common) ResetClient(mazeID, clientID string) (*pb.ResetClientReply, error) {
+	if a.resetter != nil {
+		return a.resetter(mazeID, clientID)
+	}
 
 	conn, c := NewClient()
 	defer conn.Close()
@@ -161,6 +232,10 @@ func (a *Common) ResetClient(mazeID, clientID string) (*pb.ResetClientReply, err
 	return r, err
 }
 
+// ErrMazeChanged is returned by Move, with the reply, if the move failed because the maze changed since the last
+// reply (see SolveMazeResponse.MazeChanged); the reply has where the client is and where it can go now
+var ErrMazeChanged = errors.New("maze changed")
+
 // Move sends a move request to the server and returns the reply
 func (a *Common) Move(mazeID, clientID, d string) (*pb.SolveMazeResponse, error) {
 	t := metrics.GetOrRegisterTimer("solver.step.latency", nil)
@@ -186,6 +261,9 @@ func (a *Common) Move(mazeID, clientID, d string) (*pb.SolveMazeResponse, error)
 
 	if reply.GetError() {
 		// log.Printf(">>>> %v", reply.GetErrorMessage())
+		if reply.GetMazeChanged() {
+			return reply, ErrMazeChanged
+		}
 		return reply, fmt.Errorf("%v", reply.GetErrorMessage())
 	}
 
@@ -22,6 +22,8 @@ func getNextCell(key termbox.Key) string {
 		termbox.KeyArrowDown:  "south",
 		termbox.KeyArrowLeft:  "west",
 		termbox.KeyArrowRight: "east",
+		termbox.KeyPgup:       "up",
+		termbox.KeyPgdn:       "down",
 	}
 
 	return dirMap[key]
@@ -39,7 +39,7 @@ func (a *MLFollowPolicy) Solve(mazeID, clientID string, fromCell, toCell *pb.Maz
 		time.Sleep(delay)
 
 		loc := &pb.MazeLocation{X: currentCell.GetX(), Y: currentCell.GetY(), Z: currentCell.GetZ()}
-		state, err := utils.StateFromLocation(m.Config().Rows, m.Config().Columns, loc)
+		state, err := utils.StateFromWrappedLocation(m.Config().Rows, m.Config().Columns, m.Config().GetWrap(), loc)
 		if err != nil {
 			return fmt.Errorf("error converting [%v] to location: %v", state, err)
 		}
@@ -19,14 +19,15 @@ type Random struct {
 }
 
 // randomDirection returns a random direction from the list of available ones
-func randomDirection(d []*pb.Direction) string {
-	return d[utils.Random(0, len(d))].GetName()
+func randomDirection(r *utils.Rand, d []*pb.Direction) string {
+	return d[r.Random(0, len(d))].GetName()
 }
 
 func (a *Random) Solve(mazeID, clientID string, fromCell, toCell *pb.MazeLocation, delay time.Duration,
 	directions []*pb.Direction, m *maze.Maze) error {
 	defer solvealgos.TimeTrack(a, time.Now())
 
+	r := solvealgos.Rand(m)
 	currentCell := fromCell
 	solved := false
 	steps := 0
@@ -35,8 +36,13 @@ func (a *Random) Solve(mazeID, clientID string, fromCell, toCell *pb.MazeLocatio
 		// animation delay
 		time.Sleep(delay)
 
-		if nextCell := randomDirection(directions); nextCell != "" {
+		if nextCell := randomDirection(r, directions); nextCell != "" {
 			reply, err := a.Move(mazeID, clientID, nextCell)
+			if err == solvealgos.ErrMazeChanged {
+				// the passage closed after the directions were sent, pick again from here
+				directions = reply.GetAvailableDirections()
+				continue
+			}
 			if err != nil {
 				return err
 			}
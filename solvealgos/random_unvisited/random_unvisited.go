@@ -19,7 +19,7 @@ type RandomUnvisited struct {
 }
 
 // randomDirection returns a random direction from the list of available ones
-func randomUnvisitedDirection(directions []*pb.Direction) string {
+func randomUnvisitedDirection(r *utils.Rand, directions []*pb.Direction) string {
 	available := []*pb.Direction{}
 	for _, dir := range directions {
 		if !dir.GetVisited() {
@@ -28,16 +28,17 @@ func randomUnvisitedDirection(directions []*pb.Direction) string {
 	}
 
 	if len(available) > 0 {
-		return available[utils.Random(0, len(available))].GetName()
+		return available[r.Random(0, len(available))].GetName()
 	}
 
-	return directions[utils.Random(0, len(directions))].GetName()
+	return directions[r.Random(0, len(directions))].GetName()
 }
 
 func (a *RandomUnvisited) Solve(mazeID, clientID string, fromCell, toCell *pb.MazeLocation,
 	delay time.Duration, directions []*pb.Direction, m *maze.Maze) error {
 	defer solvealgos.TimeTrack(a, time.Now())
 
+	r := solvealgos.Rand(m)
 	currentCell := fromCell
 	solved := false
 	steps := 0
@@ -46,8 +47,13 @@ func (a *RandomUnvisited) Solve(mazeID, clientID string, fromCell, toCell *pb.Ma
 		// animation delay
 		time.Sleep(delay)
 
-		if moveDir := randomUnvisitedDirection(directions); moveDir != "" {
+		if moveDir := randomUnvisitedDirection(r, directions); moveDir != "" {
 			reply, err := a.Move(mazeID, clientID, moveDir)
+			if err == solvealgos.ErrMazeChanged {
+				// the passage closed after the directions were sent, pick again from here
+				directions = reply.GetAvailableDirections()
+				continue
+			}
 			if err != nil {
 				return err
 			}
@@ -19,20 +19,40 @@ type WallFollower struct {
 	solvealgos.Common
 }
 
+// directionOrder is the order in which a walker turns, going around with its hand on the wall
+// up and down only exist on mazes with more than one level, they are tried between the planar directions
+var directionOrder = []string{"east", "north", "up", "west", "south", "down"}
+
+var opposite = map[string]string{
+	"north": "south",
+	"south": "north",
+	"east":  "west",
+	"west":  "east",
+	"up":    "down",
+	"down":  "up",
+}
+
 // getDirections returns the possible directions to move in the proper order based on which way you are "facing"
+// This is "right", "forward", "left", "back" on a single level. The order starts right after the direction
+// the walker came from and goes around directionOrder.
 func getDirections(facing string) []string {
+	back, ok := opposite[facing]
+	if !ok {
+		return []string{}
+	}
+
+	var start int
+	for i, d := range directionOrder {
+		if d == back {
+			start = i + 1
+		}
+	}
 
-	switch facing {
-	case "north":
-		return []string{"east", "north", "west", "south"}
-	case "east":
-		return []string{"south", "east", "north", "west"}
-	case "south":
-		return []string{"west", "south", "east", "north"}
-	case "west":
-		return []string{"north", "west", "south", "east"}
+	var dirs []string
+	for i := 0; i < len(directionOrder); i++ {
+		dirs = append(dirs, directionOrder[(start+i)%len(directionOrder)])
 	}
-	return []string{}
+	return dirs
 }
 
 func pickNextDir(directions []*pb.Direction, facing string) string {
@@ -78,7 +98,7 @@ func (a *WallFollower) Solve(mazeID, clientID string, fromCell, toCell *pb.MazeL
 		}
 		visited[currentCell.String()]++
 
-		if visited[currentCell.String()] > 4 {
+		if visited[currentCell.String()] > len(directionOrder) {
 			// we are stuck in a loop, fail
 			return fmt.Errorf("cell %v visited %v times, stuck in a loop", currentCell.String(), visited[currentCell.String()])
 		}
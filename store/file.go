@@ -0,0 +1,102 @@
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+const fileExt = ".maze"
+
+// File keeps every maze in its own file, named after the maze id, in a directory
+type File struct {
+	dir string
+}
+
+// NewFile returns a store in dir, which is created if needed
+func NewFile(dir string) (*File, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &File{dir: dir}, nil
+}
+
+// path returns the file the maze with id is kept in
+func (s *File) path(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return "", fmt.Errorf("invalid maze id: %q", id)
+	}
+	return filepath.Join(s.dir, id+fileExt), nil
+}
+
+// Put implements Store, the file is replaced atomically so a crash never leaves half a maze
+func (s *File) Put(m *pb.Maze) error {
+	p, err := s.path(m.GetMazeId())
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(s.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), p)
+}
+
+// Delete implements Store
+func (s *File) Delete(id string) error {
+	p, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// All implements Store
+func (s *File) All() ([]*pb.Maze, error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*"+fileExt))
+	if err != nil {
+		return nil, err
+	}
+
+	var mazes []*pb.Maze
+	for _, f := range files {
+		data, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		m := &pb.Maze{}
+		if err := proto.Unmarshal(data, m); err != nil {
+			return nil, fmt.Errorf("invalid maze in %v: %v", f, err)
+		}
+		mazes = append(mazes, m)
+	}
+	return mazes, nil
+}
+
+// Close implements Store
+func (s *File) Close() error {
+	return nil
+}
@@ -0,0 +1,382 @@
+package store
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+const (
+	opPut byte = iota
+	opDelete
+)
+
+const (
+	// crc (4), op (1), key length (4), value length (4)
+	recordHeaderSize = 13
+	// the log is only compacted once it is at least this big, and mostly old values
+	minCompactSize = 1 << 20
+	// the longest keys and values, records claiming to be longer are corrupt
+	maxKeySize   = 1 << 10
+	maxValueSize = 64 << 20
+)
+
+var errCorrupt = errors.New("corrupt record")
+
+// DB is a small embedded key-value database: an append-only log of puts and deletes in one file, indexed in memory
+// A record that was not completely written (crash) is dropped when the log is opened. The log is compacted
+// whenever it holds more old values than current ones, when it is opened and as it is written.
+type DB struct {
+	path   string
+	f      *os.File
+	values map[string][]byte
+	size   int64 // bytes in the log
+	live   int64 // bytes in the log of the records holding the current values
+
+	sync.Mutex
+}
+
+// OpenDB opens, or creates, the database in the file at path
+func OpenDB(path string) (*DB, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	db := &DB{path: path, f: f, values: make(map[string][]byte)}
+	if err := db.load(); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if db.needsCompact() {
+		if err := db.compact(); err != nil {
+			db.f.Close()
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+// needsCompact returns true if the log is big, and mostly old values; db must be locked
+func (db *DB) needsCompact() bool {
+	return db.size >= minCompactSize && db.size > 2*db.live
+}
+
+// maybeCompact compacts the log if it needs it; db must be locked
+// The record just written is already safe in the log, so a failed compaction is only logged.
+func (db *DB) maybeCompact() {
+	if !db.needsCompact() {
+		return
+	}
+	if err := db.compact(); err != nil {
+		log.Printf("error compacting %v, will try again: %v", db.path, err)
+	}
+}
+
+// recordSize returns the size in the log of the record for key and value
+func recordSize(key string, value []byte) int64 {
+	return int64(recordHeaderSize + len(key) + len(value))
+}
+
+// load reads the log into memory, the log is truncated after the last good record
+func (db *DB) load() error {
+	r := bufio.NewReader(db.f)
+	for {
+		op, key, value, err := readRecord(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Printf("dropping the end of %v after %v bytes: %v", db.path, db.size, err)
+			if err := db.f.Truncate(db.size); err != nil {
+				return err
+			}
+			break
+		}
+
+		if old, ok := db.values[key]; ok {
+			db.live -= recordSize(key, old)
+		}
+		switch op {
+		case opPut:
+			db.values[key] = value
+			db.live += recordSize(key, value)
+		case opDelete:
+			delete(db.values, key)
+		}
+		db.size += recordSize(key, value)
+	}
+
+	_, err := db.f.Seek(db.size, io.SeekStart)
+	return err
+}
+
+// readRecord reads one record, returns io.EOF if there are no more
+func readRecord(r io.Reader) (op byte, key string, value []byte, err error) {
+	header := make([]byte, recordHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = errCorrupt
+		}
+		return 0, "", nil, err
+	}
+
+	op = header[4]
+	keyLen, valueLen := binary.BigEndian.Uint32(header[5:]), binary.BigEndian.Uint32(header[9:])
+	if op != opPut && op != opDelete {
+		return 0, "", nil, errCorrupt
+	}
+	// the lengths are not checked by the crc yet, do not trust them with a huge allocation
+	if keyLen > maxKeySize || valueLen > maxValueSize {
+		return 0, "", nil, errCorrupt
+	}
+
+	data := make([]byte, int(keyLen)+int(valueLen))
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, "", nil, errCorrupt
+	}
+
+	crc := crc32.NewIEEE()
+	crc.Write(header[4:])
+	crc.Write(data)
+	if crc.Sum32() != binary.BigEndian.Uint32(header) {
+		return 0, "", nil, errCorrupt
+	}
+	return op, string(data[:keyLen]), data[keyLen:], nil
+}
+
+// writeRecord writes one record to w
+func writeRecord(w io.Writer, op byte, key string, value []byte) error {
+	record := make([]byte, recordSize(key, value))
+	record[4] = op
+	binary.BigEndian.PutUint32(record[5:], uint32(len(key)))
+	binary.BigEndian.PutUint32(record[9:], uint32(len(value)))
+	copy(record[recordHeaderSize:], key)
+	copy(record[recordHeaderSize+len(key):], value)
+	binary.BigEndian.PutUint32(record, crc32.ChecksumIEEE(record[4:]))
+
+	_, err := w.Write(record)
+	return err
+}
+
+// append writes one record to the end of the log and syncs it to disk
+func (db *DB) append(op byte, key string, value []byte) error {
+	if db.f == nil {
+		return errors.New("database is closed")
+	}
+	if len(key) > maxKeySize || len(value) > maxValueSize {
+		return fmt.Errorf("key (%v bytes) or value (%v bytes) too long, the limits are %v and %v", len(key), len(value), maxKeySize, maxValueSize)
+	}
+	if err := writeRecord(db.f, op, key, value); err != nil {
+		return db.rollback(err)
+	}
+	if err := db.f.Sync(); err != nil {
+		return db.rollback(err)
+	}
+	db.size += recordSize(key, value)
+	return nil
+}
+
+// rollback drops whatever a failed append left past db.size, so the next record does not follow a partial one
+// Returns err, the reason for the rollback.
+func (db *DB) rollback(err error) error {
+	if terr := db.f.Truncate(db.size); terr != nil {
+		return fmt.Errorf("%v, and cannot drop the partial record: %v", err, terr)
+	}
+	if _, serr := db.f.Seek(db.size, io.SeekStart); serr != nil {
+		return fmt.Errorf("%v, and cannot seek back to the end of the log: %v", err, serr)
+	}
+	return err
+}
+
+// Get returns the value of key
+func (db *DB) Get(key string) ([]byte, bool) {
+	db.Lock()
+	defer db.Unlock()
+
+	value, ok := db.values[key]
+	return value, ok
+}
+
+// Put sets key to value
+func (db *DB) Put(key string, value []byte) error {
+	db.Lock()
+	defer db.Unlock()
+
+	if err := db.append(opPut, key, value); err != nil {
+		return err
+	}
+	if old, ok := db.values[key]; ok {
+		db.live -= recordSize(key, old)
+	}
+	db.values[key] = append([]byte(nil), value...)
+	db.live += recordSize(key, value)
+
+	db.maybeCompact()
+	return nil
+}
+
+// Delete removes key, it is not an error if there is no such key
+func (db *DB) Delete(key string) error {
+	db.Lock()
+	defer db.Unlock()
+
+	old, ok := db.values[key]
+	if !ok {
+		return nil
+	}
+	if err := db.append(opDelete, key, nil); err != nil {
+		return err
+	}
+	delete(db.values, key)
+	db.live -= recordSize(key, old)
+
+	db.maybeCompact()
+	return nil
+}
+
+// Keys returns all keys, sorted
+func (db *DB) Keys() []string {
+	db.Lock()
+	defer db.Unlock()
+
+	var keys []string
+	for k := range db.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// compact rewrites the log with only the current values; db must be locked
+func (db *DB) compact() error {
+	tmp := db.path + ".compact"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	// the log is left as it is if anything goes wrong
+	abort := func(err error) error {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	var size int64
+	for key, value := range db.values {
+		if err := writeRecord(w, opPut, key, value); err != nil {
+			return abort(err)
+		}
+		size += recordSize(key, value)
+	}
+	if err := w.Flush(); err != nil {
+		return abort(err)
+	}
+	if err := f.Sync(); err != nil {
+		return abort(err)
+	}
+	if err := os.Rename(tmp, db.path); err != nil {
+		return abort(err)
+	}
+
+	db.f.Close()
+	db.f = f
+	db.size, db.live = size, size
+
+	// the rename is only durable once the directory entry is on disk
+	return syncDir(filepath.Dir(db.path))
+}
+
+// syncDir flushes the entries of directory dir to disk
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := d.Sync(); err != nil {
+		d.Close()
+		return err
+	}
+	return d.Close()
+}
+
+// Close closes the database file
+func (db *DB) Close() error {
+	db.Lock()
+	defer db.Unlock()
+
+	if db.f == nil {
+		return nil
+	}
+	err := db.f.Close()
+	db.f = nil
+	return err
+}
+
+// KV keeps all mazes in one embedded key-value database (DB), by maze id
+type KV struct {
+	db *DB
+}
+
+// NewKV returns a store in the database file at path
+func NewKV(path string) (*KV, error) {
+	db, err := OpenDB(path)
+	if err != nil {
+		return nil, err
+	}
+	return &KV{db: db}, nil
+}
+
+// Put implements Store
+func (s *KV) Put(m *pb.Maze) error {
+	if m.GetMazeId() == "" {
+		return errors.New("maze id cannot be empty")
+	}
+	data, err := proto.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(m.GetMazeId(), data)
+}
+
+// Delete implements Store
+func (s *KV) Delete(id string) error {
+	return s.db.Delete(id)
+}
+
+// All implements Store
+func (s *KV) All() ([]*pb.Maze, error) {
+	var mazes []*pb.Maze
+	for _, id := range s.db.Keys() {
+		data, ok := s.db.Get(id)
+		if !ok {
+			continue
+		}
+		m := &pb.Maze{}
+		if err := proto.Unmarshal(data, m); err != nil {
+			return nil, fmt.Errorf("invalid maze %v: %v", id, err)
+		}
+		mazes = append(mazes, m)
+	}
+	return mazes, nil
+}
+
+// Close implements Store
+func (s *KV) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,38 @@
+// Package store persists mazes, with their clients, so they survive a server restart
+package store
+
+import (
+	"fmt"
+
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+const (
+	// TypeFile keeps each maze in its own file in a directory
+	TypeFile = "file"
+	// TypeKV keeps all mazes in one embedded key-value store file
+	TypeKV = "kv"
+)
+
+// Store saves mazes, as returned by maze.Proto(), by maze id
+type Store interface {
+	// Put saves m, replacing the earlier version of the same maze
+	Put(m *pb.Maze) error
+	// Delete removes the maze with id, it is not an error if there is none
+	Delete(id string) error
+	// All returns all saved mazes
+	All() ([]*pb.Maze, error)
+	// Close releases the store, it cannot be used after
+	Close() error
+}
+
+// New returns a store of type t (TypeFile or TypeKV) that keeps its data at path
+func New(t, path string) (Store, error) {
+	switch t {
+	case TypeFile:
+		return NewFile(path)
+	case TypeKV:
+		return NewKV(path)
+	}
+	return nil, fmt.Errorf("invalid store type: %v", t)
+}
@@ -0,0 +1,313 @@
+package store
+
+import (
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	pb "github.com/DanTulovsky/mazes/proto"
+)
+
+var storetests = []struct {
+	storeType string
+	path      string // relative to a temporary directory
+}{
+	{
+		storeType: TypeFile,
+		path:      "mazes",
+	}, {
+		storeType: TypeKV,
+		path:      "mazes.db",
+	},
+}
+
+func testMaze(id string, rows int64) *pb.Maze {
+	return &pb.Maze{
+		MazeId: id,
+		Config: &pb.MazeConfig{Id: id, Rows: rows, Columns: 3},
+		Cells: []*pb.Cell{
+			{Location: &pb.MazeLocation{X: 0, Y: 0, Z: 0}, Links: []string{"east"}, Weight: 2},
+			{Location: &pb.MazeLocation{X: 1, Y: 0, Z: 0}, Links: []string{"west"}, Visited: map[string]int64{"client-1": 3}},
+		},
+		Clients: []*pb.MazeClient{
+			{ClientId: "client-1", CurrentLocation: &pb.MazeLocation{X: 1, Y: 0, Z: 0}},
+		},
+	}
+}
+
+// checkAll verifies that s has exactly the mazes in want
+func checkAll(t *testing.T, s Store, want ...*pb.Maze) {
+	t.Helper()
+
+	mazes, err := s.All()
+	if err != nil {
+		t.Fatalf("All() failed: %v", err)
+	}
+	if len(mazes) != len(want) {
+		t.Fatalf("expected %v mazes, have %v", len(want), len(mazes))
+	}
+	found := make(map[string]*pb.Maze)
+	for _, m := range mazes {
+		found[m.GetMazeId()] = m
+	}
+	for _, w := range want {
+		if !proto.Equal(found[w.GetMazeId()], w) {
+			t.Errorf("expected maze %v, have %v", w, found[w.GetMazeId()])
+		}
+	}
+}
+
+func TestStore(t *testing.T) {
+	for _, tt := range storetests {
+		dir, err := ioutil.TempDir("", "store")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, tt.path)
+
+		s, err := New(tt.storeType, path)
+		if err != nil {
+			t.Fatalf("%v: New() failed: %v", tt.storeType, err)
+		}
+		checkAll(t, s)
+
+		m1, m2 := testMaze("maze-1", 2), testMaze("maze-2", 4)
+		for _, m := range []*pb.Maze{m1, m2, testMaze("maze-3", 5)} {
+			if err := s.Put(m); err != nil {
+				t.Fatalf("%v: Put() failed: %v", tt.storeType, err)
+			}
+		}
+		if err := s.Delete("maze-3"); err != nil {
+			t.Errorf("%v: Delete() failed: %v", tt.storeType, err)
+		}
+		if err := s.Delete("maze-3"); err != nil {
+			t.Errorf("%v: Delete() of a missing maze failed: %v", tt.storeType, err)
+		}
+		// replace
+		m1 = testMaze("maze-1", 6)
+		if err := s.Put(m1); err != nil {
+			t.Fatalf("%v: Put() failed: %v", tt.storeType, err)
+		}
+		checkAll(t, s, m1, m2)
+
+		if err := s.Put(&pb.Maze{}); err == nil {
+			t.Errorf("%v: Put() of a maze without an id should fail", tt.storeType)
+		}
+
+		// everything is still there after reopening
+		if err := s.Close(); err != nil {
+			t.Errorf("%v: Close() failed: %v", tt.storeType, err)
+		}
+		if s, err = New(tt.storeType, path); err != nil {
+			t.Fatalf("%v: reopening failed: %v", tt.storeType, err)
+		}
+		checkAll(t, s, m1, m2)
+		s.Close()
+	}
+}
+
+func TestKVCorruptTail(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mazes.db")
+
+	s, err := NewKV(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m1, m2 := testMaze("maze-1", 2), testMaze("maze-2", 4)
+	for _, m := range []*pb.Maze{m1, m2} {
+		if err := s.Put(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.Close()
+
+	// cut the last record in half, as if the server crashed while writing it
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(path, info.Size()-5); err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err = NewKV(path); err != nil {
+		t.Fatalf("reopening failed: %v", err)
+	}
+	checkAll(t, s, m1)
+
+	// new records go after the last good one
+	if err := s.Put(m2); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	if s, err = NewKV(path); err != nil {
+		t.Fatalf("reopening failed: %v", err)
+	}
+	checkAll(t, s, m1, m2)
+	s.Close()
+}
+
+func TestKVCorruptLengths(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mazes.db")
+
+	s, err := NewKV(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m1 := testMaze("maze-1", 2)
+	if err := s.Put(m1); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	// a header claiming a value of 4GB, it must be dropped before anything is allocated for it
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := make([]byte, recordHeaderSize)
+	header[4] = opPut
+	binary.BigEndian.PutUint32(header[5:], 6)
+	binary.BigEndian.PutUint32(header[9:], 0xffffffff)
+	if _, err := f.Write(append(header, "maze-2"...)); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	if s, err = NewKV(path); err != nil {
+		t.Fatalf("reopening failed: %v", err)
+	}
+	checkAll(t, s, m1)
+	s.Close()
+}
+
+func TestKVCompact(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mazes.db")
+
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// saving the same maze over and over (see --store_interval) must not grow the log without bound
+	value := make([]byte, minCompactSize/8)
+	for i := 0; i < 100; i++ {
+		value[0] = byte(i)
+		if err := db.Put("maze", value); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Put("other", value[:10]); err != nil {
+			t.Fatal(err)
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() > 2*minCompactSize {
+			t.Fatalf("log is %v bytes after %v puts, it is not compacted", info.Size(), 2*(i+1))
+		}
+	}
+
+	if got, _ := db.Get("maze"); got[0] != 99 || len(got) != len(value) {
+		t.Errorf("maze has the wrong value after compacting")
+	}
+	if err := db.Delete("other"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the compacted log is what is read back
+	db.Close()
+	if db, err = OpenDB(path); err != nil {
+		t.Fatalf("reopening failed: %v", err)
+	}
+	if keys := db.Keys(); len(keys) != 1 || keys[0] != "maze" {
+		t.Errorf("keys after reopening are %v, want [maze]", keys)
+	}
+	if got, _ := db.Get("maze"); got[0] != 99 {
+		t.Errorf("maze has the wrong value after reopening")
+	}
+}
+
+func TestKVTooLong(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := OpenDB(filepath.Join(dir, "mazes.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// it could never be read back
+	if err := db.Put("maze", make([]byte, maxValueSize+1)); err == nil {
+		t.Errorf("value over %v bytes should be rejected", maxValueSize)
+	}
+	if _, ok := db.Get("maze"); ok {
+		t.Errorf("rejected value was stored")
+	}
+}
+
+func TestKVRollback(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mazes.db")
+
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := db.Put("first", []byte("one")); err != nil {
+		t.Fatal(err)
+	}
+
+	// what a write that failed halfway leaves behind
+	if _, err := db.f.Write([]byte{1, 2, 3, 4, 5}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.rollback(errors.New("disk full")); err == nil || err.Error() != "disk full" {
+		t.Fatalf("rollback returned %v, want the original error", err)
+	}
+
+	// the next record follows the last good one, not the partial one
+	if err := db.Put("second", []byte("two")); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+	if db, err = OpenDB(path); err != nil {
+		t.Fatalf("reopening failed: %v", err)
+	}
+	if keys := db.Keys(); len(keys) != 2 {
+		t.Errorf("keys after reopening are %v, want [first second]", keys)
+	}
+}
@@ -3,6 +3,8 @@ package utils
 import (
 	"log"
 	"math/rand"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"fmt"
@@ -13,21 +15,64 @@ import (
 	"github.com/gonum/matrix/mat64"
 )
 
-func init() {
-	rand.Seed(time.Now().Unix())
+// Rand is a source of random numbers that is safe to use from many goroutines
+// Every maze has its own (see maze.Maze.Rand), so the same seed always gives the same maze.
+type Rand struct {
+	r  *rand.Rand
+	mu sync.Mutex
+}
+
+// NewRand returns a source of random numbers seeded with seed
+func NewRand(seed int64) *Rand {
+	return &Rand{r: rand.New(rand.NewSource(seed))}
 }
 
 // Random returns a random number in [min, max)
-func Random(min, max int) int {
+func (r *Rand) Random(min, max int) int {
 	if min == max {
 		return min
 	}
-	return rand.Intn(max-min) + min
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.Intn(max-min) + min
+}
+
+// Random64 returns a random number in [min, max)
+func (r *Rand) Random64(min, max int64) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.Int63n(max-min) + min
+}
+
+// lastSeed is the last seed returned by NewSeed, atomic
+var lastSeed int64
+
+// NewSeed returns a seed for a maze that did not ask for one, based on the time
+// Every call returns a different seed, also when called at the same time, so mazes created together differ.
+func NewSeed() int64 {
+	for {
+		last := atomic.LoadInt64(&lastSeed)
+		seed := time.Now().UnixNano()
+		if seed <= last {
+			seed = last + 1
+		}
+		if atomic.CompareAndSwapInt64(&lastSeed, last, seed) {
+			return seed
+		}
+	}
+}
+
+// defaultRand is used for everything not part of a maze
+var defaultRand = NewRand(time.Now().UnixNano())
+
+// Random returns a random number in [min, max)
+func Random(min, max int) int {
+	return defaultRand.Random(min, max)
 }
 
 // Random64 returns a random number in [min, max)
 func Random64(min, max int64) int64 {
-	return rand.Int63n(max-min) + min
+	return defaultRand.Random64(min, max)
 }
 
 // AffineTransform x (in the range [a, b] to a number in [c, d]
@@ -131,13 +176,29 @@ func LocationFromState(rows, columns, l int64) (*pb.MazeLocation, error) {
 
 // StateFromLocation returns the state number given a location
 func StateFromLocation(rows, columns int64, l *pb.MazeLocation) (int, error) {
+	return StateFromWrappedLocation(rows, columns, "", l)
+}
+
+// StateFromWrappedLocation returns the state number given a location on a grid that wraps around its edges
+// wrap is "horizontal", "vertical" or "both" (see MazeConfig.Wrap); coordinates past a wrapped edge continue
+// on the opposite edge, so they map to the same state as the cell they wrap around to.
+func StateFromWrappedLocation(rows, columns int64, wrap string, l *pb.MazeLocation) (int, error) {
 	if l == nil {
 		return 0, fmt.Errorf("location is nil...")
 	}
-	if l.X > columns || l.Y > rows {
+
+	x, y := l.X, l.Y
+	if (wrap == "horizontal" || wrap == "both") && columns > 0 {
+		x = (x%columns + columns) % columns
+	}
+	if (wrap == "vertical" || wrap == "both") && rows > 0 {
+		y = (y%rows + rows) % rows
+	}
+
+	if x < 0 || x >= columns || y < 0 || y >= rows {
 		return 0, fmt.Errorf("requested coordinates (%v) are outside the grid (columns, rows) (%v, %v)", l, columns, rows)
 	}
-	return int(l.X + l.Y*columns), nil
+	return int(x + y*columns), nil
 }
 
 func LocsSame(l, m *pb.MazeLocation) bool {
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"log"
+	"sync"
 	"testing"
 
 	pb "github.com/DanTulovsky/mazes/proto"
@@ -141,6 +142,7 @@ var statefromlocationtests = []struct {
 }{
 	{l: &pb.MazeLocation{}, rows: 10, columns: 10, expected: 0, wantErr: false},
 	{l: &pb.MazeLocation{X: 23}, rows: 10, columns: 10, expected: 0, wantErr: true},
+	{l: &pb.MazeLocation{Y: 10}, rows: 10, columns: 10, expected: 0, wantErr: true},
 	{l: &pb.MazeLocation{X: 1}, rows: 10, columns: 10, expected: 1, wantErr: false},
 	{l: &pb.MazeLocation{X: 2, Y: 1}, rows: 3, columns: 4, expected: 6, wantErr: false},
 	{l: &pb.MazeLocation{Y: 2}, rows: 4, columns: 3, expected: 6, wantErr: false},
@@ -164,6 +166,46 @@ func TestStateFromLocation(t *testing.T) {
 	}
 }
 
+var statefromwrappedlocationtests = []struct {
+	l        *pb.MazeLocation
+	wrap     string
+	rows     int64
+	columns  int64
+	expected int
+	wantErr  bool
+}{
+	{l: &pb.MazeLocation{X: 2, Y: 1}, wrap: "", rows: 3, columns: 4, expected: 6, wantErr: false},
+	{l: &pb.MazeLocation{X: 23}, wrap: "", rows: 10, columns: 10, expected: 0, wantErr: true},
+	{l: &pb.MazeLocation{X: 10}, wrap: "", rows: 10, columns: 10, expected: 0, wantErr: true},
+	{l: &pb.MazeLocation{Y: -1}, wrap: "", rows: 10, columns: 10, expected: 0, wantErr: true},
+	{l: &pb.MazeLocation{X: 4, Y: 2}, wrap: "vertical", rows: 3, columns: 4, expected: 0, wantErr: true},
+	{l: &pb.MazeLocation{X: 23}, wrap: "horizontal", rows: 10, columns: 10, expected: 3, wantErr: false},
+	{l: &pb.MazeLocation{X: -1, Y: 1}, wrap: "horizontal", rows: 3, columns: 4, expected: 7, wantErr: false},
+	{l: &pb.MazeLocation{X: 1, Y: 5}, wrap: "horizontal", rows: 3, columns: 4, expected: 0, wantErr: true},
+	{l: &pb.MazeLocation{X: 1, Y: 5}, wrap: "vertical", rows: 3, columns: 4, expected: 9, wantErr: false},
+	{l: &pb.MazeLocation{X: 4, Y: -1}, wrap: "both", rows: 3, columns: 4, expected: 8, wantErr: false},
+}
+
+func TestStateFromWrappedLocation(t *testing.T) {
+	for _, tt := range statefromwrappedlocationtests {
+		state, err := StateFromWrappedLocation(tt.rows, tt.columns, tt.wrap, tt.l)
+		if err != nil {
+			if !tt.wantErr {
+				t.Fatalf("failed to find state from l (%v): %v", tt.l, err)
+			} else {
+				continue
+			}
+		}
+		if tt.wantErr {
+			t.Errorf("expected error for location %v (wrap=%v)", tt.l, tt.wrap)
+		}
+
+		if state != tt.expected {
+			t.Errorf("expected: %v; received: %v; location: %v (wrap=%v)", tt.expected, state, tt.l, tt.wrap)
+		}
+	}
+}
+
 var locsametests = []struct {
 	l        *pb.MazeLocation
 	m        *pb.MazeLocation
@@ -256,3 +298,26 @@ func TestDecay(t *testing.T) {
 		}
 	}
 }
+
+func TestNewSeed(t *testing.T) {
+	// mazes created at the same time still get different seeds
+	seeds := make(chan int64, 1000)
+	var wg sync.WaitGroup
+	for i := 0; i < cap(seeds); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			seeds <- NewSeed()
+		}()
+	}
+	wg.Wait()
+	close(seeds)
+
+	seen := make(map[int64]bool)
+	for s := range seeds {
+		if s == 0 || seen[s] {
+			t.Errorf("NewSeed() returned %v twice, or 0", s)
+		}
+		seen[s] = true
+	}
+}